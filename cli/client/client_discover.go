@@ -18,6 +18,7 @@ package client
 
 import (
 	"errors"
+	"fmt"
 	"os/exec"
 	"strings"
 
@@ -34,6 +35,19 @@ func DiscoverKubernetesCLI() (string, error) {
 	return "", errors.New("Could not find any supported CLI")
 }
 
+// discoverClientGo used to discover the client-go client, which is registered after the kubeconfig is loaded.
+func discoverClientGo() (string, error) {
+	client, ok := clientSet[CLIClientGo].(*KubernetesClientGo)
+	if !ok {
+		return "", errors.New("The client-go client is not registered")
+	}
+
+	if err := client.ping(); err != nil {
+		return "", fmt.Errorf("Could not connect to the Kubernetes API server, error: %v", err)
+	}
+	return CLIClientGo, nil
+}
+
 // discoverKubeCLI used to discover kubectl CLI.
 func discoverKubeCLI() (string, error) {
 	_, err := exec.Command(CLIKubernetes, "version").CombinedOutput()
@@ -69,9 +83,10 @@ func verifyOpenShiftAPIResources() bool {
 	return false
 }
 
-// LoadSupportedCLI used to load all supported CLI, e.g. kubectl, oc
+// LoadSupportedCLI used to load all supported CLI, e.g. client-go, kubectl, oc
 func LoadSupportedCLI() []func() (string, error) {
 	return []func() (string, error){
+		discoverClientGo,
 		discoverKubeCLI,
 		discoverOpenShiftCLI,
 	}
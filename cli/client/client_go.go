@@ -0,0 +1,524 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilYaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+
+	"huawei-csi-driver/cli/helper"
+	backendClientSet "huawei-csi-driver/pkg/client/clientset/versioned"
+)
+
+const (
+	// CLIClientGo is the name of the client which calls the api server by client-go directly
+	CLIClientGo = "client-go"
+
+	defaultNamespace       = "default"
+	inClusterNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+	requestTimeout         = 60 * time.Second
+	yamlDecoderBufferSize  = 4096
+)
+
+// snapshotGroupVersion is the group version of the VolumeSnapshotContent
+var snapshotGroupVersion = schema.GroupVersion{Group: "snapshot.storage.k8s.io", Version: "v1"}
+
+// kindResourceTypes maps the kinds in the yaml to the resource types
+var kindResourceTypes = map[string]ResourceType{
+	"ConfigMap":             ConfigMap,
+	"Secret":                Secret,
+	"StorageBackendClaim":   Storagebackendclaim,
+	"StorageBackendContent": StoragebackendclaimContent,
+	"PersistentVolume":      PersistentVolume,
+	"PersistentVolumeClaim": PersistentVolumeClaim,
+	"VolumeSnapshotContent": VolumeSnapshotContent,
+	"VolumeAttachment":      VolumeAttachment,
+	"Node":                  Node,
+	"Pod":                   Pod,
+}
+
+// KubernetesClientGo is the KubernetesClient which calls the api server by client-go and the generated clientset,
+// no kubectl or oc is needed
+type KubernetesClientGo struct {
+	kubeClient     kubernetes.Interface
+	backendClient  backendClientSet.Interface
+	snapshotClient rest.Interface
+	namespace      string
+
+	// kubeConfig and kubeContext are passed to kubectl or oc which executes the commands in the pods
+	kubeConfig  string
+	kubeContext string
+}
+
+// resourceRequest defines how to request the resources of a resource type
+type resourceRequest struct {
+	restClient rest.Interface
+	resource   string
+	namespaced bool
+}
+
+// NewKubernetesClientGo used to create the client-go client by the kubeconfig file and the context of it, the
+// in-cluster config of the service account is used if inCluster is true
+func NewKubernetesClientGo(kubeConfig, kubeContext string, inCluster bool) (*KubernetesClientGo, error) {
+	if inCluster {
+		restConfig, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, fmt.Errorf("get in-cluster config failed, error: %v", err)
+		}
+		return newKubernetesClientGoForConfig(restConfig, getInClusterNamespace())
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeConfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: kubeContext}
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load kubeconfig failed, error: %v", err)
+	}
+
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, fmt.Errorf("get namespace of kubeconfig failed, error: %v", err)
+	}
+
+	client, err := newKubernetesClientGoForConfig(restConfig, namespace)
+	if err != nil {
+		return nil, err
+	}
+	client.kubeConfig, client.kubeContext = kubeConfig, kubeContext
+	return client, nil
+}
+
+func newKubernetesClientGoForConfig(restConfig *rest.Config, namespace string) (*KubernetesClientGo, error) {
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	backendClient, err := backendClientSet.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotClient, err := newSnapshotRESTClient(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	return &KubernetesClientGo{kubeClient: kubeClient, backendClient: backendClient, snapshotClient: snapshotClient,
+		namespace: namespace}, nil
+}
+
+// newSnapshotRESTClient used to create the rest client of the snapshot.storage.k8s.io group, the clientset of the
+// external-snapshotter is not needed since the raw objects are requested only
+func newSnapshotRESTClient(restConfig *rest.Config) (rest.Interface, error) {
+	config := rest.CopyConfig(restConfig)
+	config.GroupVersion = &snapshotGroupVersion
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+	return rest.RESTClientFor(config)
+}
+
+func getInClusterNamespace() string {
+	if namespace := os.Getenv("POD_NAMESPACE"); namespace != "" {
+		return namespace
+	}
+
+	data, err := os.ReadFile(inClusterNamespaceFile)
+	if err != nil {
+		return defaultNamespace
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// CLI return current cli command
+func (k *KubernetesClientGo) CLI() string {
+	return CLIClientGo
+}
+
+// GetNameSpace used to get the namespace of the current context, or the namespace of the service account in
+// the cluster
+func (k *KubernetesClientGo) GetNameSpace() (string, error) {
+	return k.namespace, nil
+}
+
+// OperateResourceByYaml operate the resources in the yaml, which may contain several documents
+// operate supported: Create, Delete, Apply. Apply replaces the resource if it exists, otherwise creates it.
+func (k *KubernetesClientGo) OperateResourceByYaml(yamlData, operate string, ignoreNotfound bool) error {
+	return k.operateResourcesByYaml(yamlData, operate, ignoreNotfound, false)
+}
+
+// DryRunResourceByYaml submit the resources in the yaml to the api server with dryRun=All, so that they are
+// validated and admitted by the webhooks without persisting. operate supported: Create, Apply
+func (k *KubernetesClientGo) DryRunResourceByYaml(yamlData, operate string) error {
+	if operate != Create && operate != Apply {
+		return fmt.Errorf("operate %s is not supported in dry-run", operate)
+	}
+	return k.operateResourcesByYaml(yamlData, operate, false, true)
+}
+
+func (k *KubernetesClientGo) operateResourcesByYaml(yamlData, operate string, ignoreNotfound, dryRun bool) error {
+	decoder := utilYaml.NewYAMLOrJSONDecoder(strings.NewReader(yamlData), yamlDecoderBufferSize)
+	for {
+		obj := &unstructured.Unstructured{}
+		err := decoder.Decode(&obj.Object)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("decode yaml failed, error: %v", err)
+		}
+
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		if err = k.operateResource(obj, operate, ignoreNotfound, dryRun); err != nil {
+			return err
+		}
+	}
+}
+
+func (k *KubernetesClientGo) operateResource(obj *unstructured.Unstructured, operate string,
+	ignoreNotfound, dryRun bool) error {
+	resourceType, ok := kindResourceTypes[obj.GetKind()]
+	if !ok {
+		return fmt.Errorf("kind %s is not supported", obj.GetKind())
+	}
+
+	req, err := k.getResourceRequest(resourceType)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	namespace := obj.GetNamespace()
+	switch operate {
+	case Create:
+		return k.createResource(ctx, req, namespace, obj, dryRun)
+	case Delete:
+		err = req.newRequest(http.MethodDelete, k.getNamespace(namespace)).Name(obj.GetName()).
+			Do(ctx).Error()
+		if apiErrors.IsNotFound(err) && ignoreNotfound {
+			return nil
+		}
+		return err
+	case Apply:
+		return k.applyResource(ctx, req, namespace, obj, dryRun)
+	default:
+		return fmt.Errorf("operate %s is not supported", operate)
+	}
+}
+
+func (k *KubernetesClientGo) createResource(ctx context.Context, req resourceRequest, namespace string,
+	obj *unstructured.Unstructured, dryRun bool) error {
+	body, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	return withDryRun(req.newRequest(http.MethodPost, k.getNamespace(namespace)), dryRun).Body(body).
+		Do(ctx).Error()
+}
+
+func (k *KubernetesClientGo) applyResource(ctx context.Context, req resourceRequest, namespace string,
+	obj *unstructured.Unstructured, dryRun bool) error {
+	raw, err := req.newRequest(http.MethodGet, k.getNamespace(namespace)).Name(obj.GetName()).
+		Do(ctx).Raw()
+	if apiErrors.IsNotFound(err) {
+		return k.createResource(ctx, req, namespace, obj, dryRun)
+	}
+	if err != nil {
+		return err
+	}
+
+	existing := &unstructured.Unstructured{}
+	if err = existing.UnmarshalJSON(raw); err != nil {
+		return err
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+
+	body, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return withDryRun(req.newRequest(http.MethodPut, k.getNamespace(namespace)), dryRun).Name(obj.GetName()).
+		Body(body).Do(ctx).Error()
+}
+
+// DeleteResourceByQualifiedNames delete resource based on the specified qualified names, e.g. secret/name
+func (k *KubernetesClientGo) DeleteResourceByQualifiedNames(qualifiedNames []string, namespace string) (string,
+	error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	var out strings.Builder
+	for _, qualifiedName := range qualifiedNames {
+		resourceType, name, found := strings.Cut(qualifiedName, "/")
+		if !found || name == "" {
+			return out.String(), fmt.Errorf("qualified name %s is invalid", qualifiedName)
+		}
+
+		req, err := k.getResourceRequest(normalizeResourceType(resourceType))
+		if err != nil {
+			return out.String(), err
+		}
+
+		err = req.newRequest(http.MethodDelete, k.getNamespace(namespace)).Name(name).Do(ctx).Error()
+		if apiErrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return out.String(), err
+		}
+		out.WriteString(fmt.Sprintf("%s \"%s\" deleted\n", resourceType, name))
+	}
+
+	return out.String(), nil
+}
+
+// GetResource get resources based on the specified resourceType, name and outputType, the output is the same as
+// kubectl with --ignore-not-found. outputType supported: json, yaml
+func (k *KubernetesClientGo) GetResource(names []string, namespace, outputType string,
+	resourceType ResourceType) ([]byte, error) {
+	if outputType != "json" && outputType != "yaml" {
+		return nil, fmt.Errorf("output type %s is not supported by %s", outputType, CLIClientGo)
+	}
+
+	req, err := k.getResourceRequest(resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	var items []map[string]interface{}
+	if len(names) == 0 {
+		items, err = k.listResources(ctx, req, namespace)
+	} else {
+		items, err = k.getResources(ctx, req, namespace, names)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var out []byte
+	if len(names) == 1 {
+		if len(items) == 0 {
+			return nil, nil
+		}
+		out, err = json.Marshal(items[0])
+	} else {
+		out, err = json.Marshal(map[string]interface{}{"apiVersion": "v1", "kind": "List", "items": items})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if outputType == "yaml" {
+		return yaml.JSONToYAML(out)
+	}
+	return out, nil
+}
+
+func (k *KubernetesClientGo) getResources(ctx context.Context, req resourceRequest, namespace string,
+	names []string) ([]map[string]interface{}, error) {
+	var items []map[string]interface{}
+	for _, name := range names {
+		raw, err := req.newRequest(http.MethodGet, k.getNamespace(namespace)).Name(name).Do(ctx).Raw()
+		if apiErrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var item map[string]interface{}
+		if err = json.Unmarshal(raw, &item); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (k *KubernetesClientGo) listResources(ctx context.Context, req resourceRequest,
+	namespace string) ([]map[string]interface{}, error) {
+	raw, err := req.newRequest(http.MethodGet, k.getNamespace(namespace)).Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		APIVersion string                   `json:"apiVersion"`
+		Kind       string                   `json:"kind"`
+		Items      []map[string]interface{} `json:"items"`
+	}
+	if err = json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+
+	// the items of the list returned by the api server have no type meta
+	for _, item := range list.Items {
+		item["apiVersion"] = list.APIVersion
+		item["kind"] = strings.TrimSuffix(list.Kind, "List")
+	}
+	return list.Items, nil
+}
+
+// CheckResourceExist check whether resource exists based on the specified args.
+func (k *KubernetesClientGo) CheckResourceExist(name, namespace string, resourceType ResourceType) (bool, error) {
+	req, err := k.getResourceRequest(resourceType)
+	if err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	err = req.newRequest(http.MethodGet, k.getNamespace(namespace)).Name(name).Do(ctx).Error()
+	if apiErrors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// GetPodLogs get the logs of the container of the pod by the log subresource
+func (k *KubernetesClientGo) GetPodLogs(name, namespace, container string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	return k.kubeClient.CoreV1().Pods(k.getNamespace(namespace)).
+		GetLogs(name, &corev1.PodLogOptions{Container: container}).DoRaw(ctx)
+}
+
+// ExecCmdInPod execute the command in the container of the pod. The exec subresource is streamed by SPDY which is
+// not built into oceanctl, so the command is executed by kubectl or oc with the same kubeconfig and context.
+func (k *KubernetesClientGo) ExecCmdInPod(name, namespace, container string, command ...string) ([]byte, error) {
+	cli, err := discoverKubeCLI()
+	if err != nil {
+		if cli, err = discoverOpenShiftCLI(); err != nil {
+			return nil, fmt.Errorf("%s or %s is required to execute the command in pod %s",
+				CLIKubernetes, CLIOpenShift, name)
+		}
+	}
+
+	var args []string
+	if k.kubeConfig != "" {
+		args = append(args, "--kubeconfig", k.kubeConfig)
+	}
+	if k.kubeContext != "" {
+		args = append(args, "--context", k.kubeContext)
+	}
+	args = append(args, execPodArgs(name, k.getNamespace(namespace), container, command)...)
+	return helper.ExecReturnStdOut(cli, args)
+}
+
+// ping used to check whether the api server is reachable
+func (k *KubernetesClientGo) ping() error {
+	_, err := k.kubeClient.Discovery().ServerVersion()
+	return err
+}
+
+func (k *KubernetesClientGo) getResourceRequest(resourceType ResourceType) (resourceRequest, error) {
+	coreClient := k.kubeClient.CoreV1().RESTClient()
+	storageClient := k.kubeClient.StorageV1().RESTClient()
+	xuanwuClient := k.backendClient.XuanwuV1().RESTClient()
+	switch resourceType {
+	case ConfigMap:
+		return resourceRequest{restClient: coreClient, resource: "configmaps", namespaced: true}, nil
+	case Secret:
+		return resourceRequest{restClient: coreClient, resource: "secrets", namespaced: true}, nil
+	case Storagebackendclaim:
+		return resourceRequest{restClient: xuanwuClient, resource: "storagebackendclaims", namespaced: true}, nil
+	case StoragebackendclaimContent:
+		return resourceRequest{restClient: xuanwuClient, resource: "storagebackendcontents"}, nil
+	case PersistentVolume:
+		return resourceRequest{restClient: coreClient, resource: "persistentvolumes"}, nil
+	case PersistentVolumeClaim:
+		return resourceRequest{restClient: coreClient, resource: "persistentvolumeclaims", namespaced: true}, nil
+	case VolumeSnapshotContent:
+		return resourceRequest{restClient: k.snapshotClient, resource: "volumesnapshotcontents"}, nil
+	case VolumeAttachment:
+		return resourceRequest{restClient: storageClient, resource: "volumeattachments"}, nil
+	case Node:
+		return resourceRequest{restClient: coreClient, resource: "nodes"}, nil
+	case Pod:
+		return resourceRequest{restClient: coreClient, resource: "pods", namespaced: true}, nil
+	default:
+		return resourceRequest{}, fmt.Errorf("resource type %s is not supported", resourceType)
+	}
+}
+
+func (k *KubernetesClientGo) getNamespace(namespace string) string {
+	if namespace == "" {
+		return k.namespace
+	}
+	return namespace
+}
+
+func (r resourceRequest) newRequest(verb, namespace string) *rest.Request {
+	request := r.restClient.Verb(verb)
+	if r.namespaced {
+		request = request.Namespace(namespace)
+	}
+	return request.Resource(r.resource)
+}
+
+// withDryRun used to set the dryRun=All param of the request if dryRun is true
+func withDryRun(request *rest.Request, dryRun bool) *rest.Request {
+	if dryRun {
+		return request.Param("dryRun", metaV1.DryRunAll)
+	}
+	return request
+}
+
+// normalizeResourceType used to convert the plural or the capitalized resource type to the ResourceType
+func normalizeResourceType(resourceType string) ResourceType {
+	return ResourceType(strings.TrimSuffix(strings.ToLower(resourceType), "s"))
+}
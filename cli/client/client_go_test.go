@@ -0,0 +1,223 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+const (
+	notFoundStatus = `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`
+	secretPath     = "/api/v1/namespaces/huawei-csi/secrets/secret-1"
+	claimsPath     = "/apis/xuanwu.huawei.io/v1/namespaces/huawei-csi/storagebackendclaims"
+	podLogPath     = "/api/v1/namespaces/huawei-csi/pods/pod-1/log"
+)
+
+// fakeAPIServer serves the secrets named secret-1 and records the other requests
+type fakeAPIServer struct {
+	requests []string
+	queries  []string
+	bodies   []string
+}
+
+func (f *fakeAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	f.queries = append(f.queries, r.URL.RawQuery)
+	f.bodies = append(f.bodies, string(body))
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == secretPath:
+		_, _ = w.Write([]byte(`{"kind":"Secret","apiVersion":"v1",` +
+			`"metadata":{"name":"secret-1","namespace":"huawei-csi","resourceVersion":"7"}}`))
+	case r.Method == http.MethodGet && r.URL.Path == claimsPath:
+		_, _ = w.Write([]byte(`{"kind":"StorageBackendClaimList","apiVersion":"xuanwu.huawei.io/v1",` +
+			`"items":[{"metadata":{"name":"backend-1"}}]}`))
+	case r.Method == http.MethodGet && r.URL.Path == podLogPath:
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("log of " + r.URL.Query().Get("container")))
+	case r.Method == http.MethodGet:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(notFoundStatus))
+	default:
+		_, _ = w.Write(body)
+	}
+}
+
+func newTestClientGo(t *testing.T) (*KubernetesClientGo, *fakeAPIServer) {
+	fake := &fakeAPIServer{}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client, err := newKubernetesClientGoForConfig(&rest.Config{Host: server.URL}, "huawei-csi")
+	if err != nil {
+		t.Fatalf("newKubernetesClientGoForConfig() error = %v", err)
+	}
+	return client, fake
+}
+
+func TestClientGoGetResource(t *testing.T) {
+	client, _ := newTestClientGo(t)
+
+	out, err := client.GetResource([]string{"secret-1"}, "", "json", Secret)
+	if err != nil || !strings.Contains(string(out), `"name":"secret-1"`) {
+		t.Errorf("GetResource() of the secret got = %s, error = %v", out, err)
+	}
+
+	out, err = client.GetResource([]string{"secret-2"}, "", "json", Secret)
+	if err != nil || len(out) != 0 {
+		t.Errorf("GetResource() of the not found secret got = %s, error = %v", out, err)
+	}
+
+	out, err = client.GetResource(nil, "huawei-csi", "json", Storagebackendclaim)
+	if err != nil {
+		t.Fatalf("GetResource() of the claims error = %v", err)
+	}
+	var list struct {
+		Kind  string                   `json:"kind"`
+		Items []map[string]interface{} `json:"items"`
+	}
+	_ = json.Unmarshal(out, &list)
+	if list.Kind != "List" || len(list.Items) != 1 || list.Items[0]["kind"] != "StorageBackendClaim" {
+		t.Errorf("GetResource() of the claims got = %s", out)
+	}
+}
+
+func TestClientGoOperateResourceByYaml(t *testing.T) {
+	client, fake := newTestClientGo(t)
+
+	yaml := `apiVersion: v1
+kind: Secret
+metadata:
+  name: secret-1
+  namespace: huawei-csi
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: configmap-1
+`
+	if err := client.OperateResourceByYaml(yaml, Apply, false); err != nil {
+		t.Fatalf("OperateResourceByYaml() error = %v", err)
+	}
+
+	want := []string{
+		"GET /api/v1/namespaces/huawei-csi/secrets/secret-1",
+		"PUT /api/v1/namespaces/huawei-csi/secrets/secret-1",
+		"GET /api/v1/namespaces/huawei-csi/configmaps/configmap-1",
+		"POST /api/v1/namespaces/huawei-csi/configmaps",
+	}
+	if !reflect.DeepEqual(fake.requests, want) {
+		t.Errorf("OperateResourceByYaml() requests = %v, want %v", fake.requests, want)
+	}
+	if !strings.Contains(fake.bodies[1], `"resourceVersion":"7"`) {
+		t.Errorf("OperateResourceByYaml() should update the secret of the resource version, got %s", fake.bodies[1])
+	}
+}
+
+func TestClientGoDryRunResourceByYaml(t *testing.T) {
+	client, fake := newTestClientGo(t)
+
+	yaml := `apiVersion: v1
+kind: Secret
+metadata:
+  name: secret-1
+  namespace: huawei-csi
+`
+	if err := client.DryRunResourceByYaml(yaml, Apply); err != nil {
+		t.Fatalf("DryRunResourceByYaml() error = %v", err)
+	}
+
+	want := []string{
+		"GET /api/v1/namespaces/huawei-csi/secrets/secret-1",
+		"PUT /api/v1/namespaces/huawei-csi/secrets/secret-1",
+	}
+	if !reflect.DeepEqual(fake.requests, want) {
+		t.Errorf("DryRunResourceByYaml() requests = %v, want %v", fake.requests, want)
+	}
+	if fake.queries[0] != "" || fake.queries[1] != "dryRun=All" {
+		t.Errorf("DryRunResourceByYaml() should only update the secret in dry-run, got queries %v", fake.queries)
+	}
+
+	if err := client.DryRunResourceByYaml(yaml, Delete); err == nil {
+		t.Error("DryRunResourceByYaml() of delete should fail")
+	}
+}
+
+func TestClientGoDeleteResourceByQualifiedNames(t *testing.T) {
+	client, fake := newTestClientGo(t)
+
+	out, err := client.DeleteResourceByQualifiedNames([]string{"secret/secret-1", "storagebackendclaim/backend-1"},
+		"huawei-csi")
+	if err != nil {
+		t.Fatalf("DeleteResourceByQualifiedNames() error = %v", err)
+	}
+
+	want := []string{
+		"DELETE /api/v1/namespaces/huawei-csi/secrets/secret-1",
+		"DELETE /apis/xuanwu.huawei.io/v1/namespaces/huawei-csi/storagebackendclaims/backend-1",
+	}
+	if !reflect.DeepEqual(fake.requests, want) || !strings.Contains(out, `secret "secret-1" deleted`) {
+		t.Errorf("DeleteResourceByQualifiedNames() got = %s, requests = %v", out, fake.requests)
+	}
+
+	if _, err = client.DeleteResourceByQualifiedNames([]string{"deployment/deployment-1"}, "huawei-csi"); err == nil {
+		t.Errorf("DeleteResourceByQualifiedNames() of the unsupported resource should fail")
+	}
+}
+
+func TestClientGoGetClusterResources(t *testing.T) {
+	client, fake := newTestClientGo(t)
+
+	for _, resourceType := range []ResourceType{PersistentVolume, VolumeSnapshotContent, VolumeAttachment, Node} {
+		if _, err := client.GetResource([]string{"name-1"}, "huawei-csi", "json", resourceType); err != nil {
+			t.Errorf("GetResource() of %s error = %v", resourceType, err)
+		}
+	}
+
+	want := []string{
+		"GET /api/v1/persistentvolumes/name-1",
+		"GET /apis/snapshot.storage.k8s.io/v1/volumesnapshotcontents/name-1",
+		"GET /apis/storage.k8s.io/v1/volumeattachments/name-1",
+		"GET /api/v1/nodes/name-1",
+	}
+	if !reflect.DeepEqual(fake.requests, want) {
+		t.Errorf("GetResource() requests = %v, want %v", fake.requests, want)
+	}
+}
+
+func TestClientGoGetPodLogs(t *testing.T) {
+	client, fake := newTestClientGo(t)
+
+	out, err := client.GetPodLogs("pod-1", "", "huawei-csi-driver")
+	if err != nil || string(out) != "log of huawei-csi-driver" {
+		t.Errorf("GetPodLogs() got = %s, error = %v", out, err)
+	}
+
+	if _, err = client.GetPodLogs("pod-2", "", "huawei-csi-driver"); err == nil {
+		t.Errorf("GetPodLogs() of the not found pod should fail, requests = %v", fake.requests)
+	}
+}
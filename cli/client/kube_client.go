@@ -33,6 +33,12 @@ const (
 	Secret                     ResourceType = "secret"
 	Storagebackendclaim        ResourceType = "storagebackendclaim"
 	StoragebackendclaimContent ResourceType = "storagebackendcontent"
+	PersistentVolume           ResourceType = "persistentvolume"
+	PersistentVolumeClaim      ResourceType = "persistentvolumeclaim"
+	VolumeSnapshotContent      ResourceType = "volumesnapshotcontent"
+	VolumeAttachment           ResourceType = "volumeattachment"
+	Node                       ResourceType = "node"
+	Pod                        ResourceType = "pod"
 
 	Create = "create" // used to create resource
 	Delete = "delete" // used to delete resource
@@ -63,6 +69,12 @@ func (k *KubernetesCLI) OperateResourceByYaml(yaml, operate string, ignoreNotfou
 	return helper.ExecWithStdin(k.cli, []byte(yaml), args)
 }
 
+// DryRunResourceByYaml submit the resources to the api server without persisting them
+// operate supported: Create, Apply
+func (k *KubernetesCLI) DryRunResourceByYaml(yaml, operate string) error {
+	return helper.ExecWithStdin(k.cli, []byte(yaml), []string{operate, "-f", "-", "--dry-run=server"})
+}
+
 // DeleteResourceByQualifiedNames delete resource based on the specified qualified names
 func (k *KubernetesCLI) DeleteResourceByQualifiedNames(qualifiedNames []string, namespace string) (string, error) {
 	args := []string{"delete"}
@@ -95,6 +107,22 @@ func (k *KubernetesCLI) CheckResourceExist(name, namespace string, resourceType
 	return k.checkResourceExist(args)
 }
 
+// GetPodLogs get the logs of the container of the pod
+func (k *KubernetesCLI) GetPodLogs(name, namespace, container string) ([]byte, error) {
+	args := []string{"logs", name, "--namespace", namespace, "--container", container}
+	return helper.ExecReturnStdOut(k.cli, args)
+}
+
+// ExecCmdInPod execute the command in the container of the pod, and return the output of the command
+func (k *KubernetesCLI) ExecCmdInPod(name, namespace, container string, command ...string) ([]byte, error) {
+	return helper.ExecReturnStdOut(k.cli, execPodArgs(name, namespace, container, command))
+}
+
+func execPodArgs(name, namespace, container string, command []string) []string {
+	args := []string{"exec", name, "--namespace", namespace, "--container", container, "--"}
+	return append(args, command...)
+}
+
 func (k *KubernetesCLI) checkResourceExist(args []string) (bool, error) {
 	out, err := helper.ExecReturnStdOut(k.cli, args)
 	if err != nil {
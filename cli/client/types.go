@@ -24,7 +24,10 @@ type KubernetesClient interface {
 	CLI() string
 	GetNameSpace() (string, error)
 	OperateResourceByYaml(yaml, operate string, ignoreNotfound bool) error
+	DryRunResourceByYaml(yaml, operate string) error
 	DeleteResourceByQualifiedNames(qualifiedNames []string, namespace string) (string, error)
 	GetResource(name []string, namespace, outputType string, resourceType ResourceType) ([]byte, error)
 	CheckResourceExist(name, namespace string, resourceType ResourceType) (bool, error)
+	GetPodLogs(name, namespace, container string) ([]byte, error)
+	ExecCmdInPod(name, namespace, container string, command ...string) ([]byte, error)
 }
@@ -0,0 +1,73 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package command
+
+import (
+	"github.com/spf13/cobra"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/cmd/options"
+	"huawei-csi-driver/cli/config"
+	"huawei-csi-driver/cli/helper"
+	"huawei-csi-driver/cli/resources"
+)
+
+func init() {
+	options.NewFlagsOptions(checkBackendCmd).
+		WithNameSpace(false).
+		WithOutPutFormat().
+		WithProvisioner().
+		WithDecryption().
+		WithParent(checkCmd)
+}
+
+var (
+	checkBackendExample = helper.Examples(`
+		# Check the status and the login of specified backends in default(huawei-csi) namespace
+		oceanctl check backend <name...>
+
+		# Check the backend in specified namespace
+		oceanctl check backend <name> -n <namespace>
+
+		# Check the backend whose password is encrypted by the key file
+		oceanctl check backend <name> --key-file /path/to/key`)
+)
+
+var checkBackendCmd = &cobra.Command{
+	Use:     "backend <name>...",
+	Short:   "Check whether one or more backends are bound, online and able to login the storage",
+	Example: checkBackendExample,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCheckBackend(args)
+	},
+}
+
+func runCheckBackend(backendNames []string) error {
+	res := resources.NewResourceBuilder().
+		ResourceNames(string(client.Storagebackendclaim), backendNames...).
+		NamespaceParam(config.Namespace).
+		DefaultNamespace().
+		Output(config.OutputFormat).
+		Build()
+
+	validator := resources.NewValidatorBuilder(res).ValidateNameIsExist().ValidateOutputFormat().Build()
+	if err := validator.Validate(); err != nil {
+		return helper.PrintlnError(err)
+	}
+
+	return resources.NewBackend(res).Check()
+}
@@ -0,0 +1,72 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package command
+
+import (
+	"github.com/spf13/cobra"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/cmd/options"
+	"huawei-csi-driver/cli/config"
+	"huawei-csi-driver/cli/helper"
+	"huawei-csi-driver/cli/resources"
+)
+
+func init() {
+	options.NewFlagsOptions(checkNodeCmd).
+		WithNameSpace(false).
+		WithOutPutFormat().
+		WithProvisioner().
+		WithParent(checkCmd)
+}
+
+var (
+	checkNodeExample = helper.Examples(`
+		# Check the initiators, the multipath services and the portals of the backends on specified nodes
+		oceanctl check node <node-name...>
+
+		# Check the node of huawei-csi in specified namespace
+		oceanctl check node <node-name> -n <namespace>
+
+		# Check the node and print the results in json format
+		oceanctl check node <node-name> -o json`)
+)
+
+var checkNodeCmd = &cobra.Command{
+	Use:     "node <name>...",
+	Short:   "Check whether one or more nodes are ready to attach the volumes of huawei-csi",
+	Example: checkNodeExample,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCheckNode(args)
+	},
+}
+
+func runCheckNode(nodeNames []string) error {
+	res := resources.NewResourceBuilder().
+		ResourceNames(string(client.Node), nodeNames...).
+		NamespaceParam(config.Namespace).
+		DefaultNamespace().
+		Output(config.OutputFormat).
+		Build()
+
+	validator := resources.NewValidatorBuilder(res).ValidateNameIsExist().ValidateOutputFormat().Build()
+	if err := validator.Validate(); err != nil {
+		return helper.PrintlnError(err)
+	}
+
+	return resources.NewNode(res).Check()
+}
@@ -0,0 +1,71 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package command
+
+import (
+	"github.com/spf13/cobra"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/cmd/options"
+	"huawei-csi-driver/cli/config"
+	"huawei-csi-driver/cli/helper"
+	"huawei-csi-driver/cli/resources"
+)
+
+func init() {
+	options.NewFlagsOptions(collectCmd).
+		WithNameSpace(false).
+		WithProvisioner().
+		WithCollection().
+		WithParent(RootCmd)
+}
+
+var (
+	collectExample = helper.Examples(`
+		# Collect the backends, volumes, logs and host information of huawei-csi in default(huawei-csi) namespace
+		oceanctl collect
+
+		# Collect huawei-csi in specified namespace into the specified directory
+		oceanctl collect -n <namespace> -d /path/to/dir
+
+		# Collect the node pods, volumes and volume attachments of the specified nodes only
+		oceanctl collect --node <node-name>,<node-name>
+
+		# Collect the volumes whose PVCs are in specified namespace only
+		oceanctl collect --volume-namespace <namespace>`)
+)
+
+var collectCmd = &cobra.Command{
+	Use:     "collect",
+	Short:   "Collect the backends, volumes, logs and host information of huawei-csi into a tar.gz bundle",
+	Example: collectExample,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCollect()
+	},
+}
+
+func runCollect() error {
+	res := resources.NewResourceBuilder().
+		ResourceNames(string(client.Node), config.Nodes...).
+		NamespaceParam(config.Namespace).
+		DefaultNamespace().
+		VolumeNamespace(config.VolumeNamespace).
+		OutputDir(config.OutputDir).
+		Build()
+
+	return resources.NewCollector(res).Collect()
+}
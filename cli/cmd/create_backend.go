@@ -33,6 +33,7 @@ func init() {
 		WithInputFileType().
 		WithProvisioner().
 		WithNotValidateName().
+		WithDryRun().
 		WithParent(CreateCmd)
 }
 
@@ -52,6 +53,12 @@ var (
 
 		# Create backend with not validate backend name
 		oceanctl create backend -f /path/to/backend.yaml -i yaml --not-validate-name
+
+		# Print the objects of the backend and validate them like the webhook without creating them
+		oceanctl create backend -f /path/to/backend.yaml -i yaml --dry-run=client
+
+		# Submit the objects of the backend to the api server without persisting them
+		oceanctl create backend -f /path/to/backend.yaml -i yaml --dry-run=server
 	`)
 )
 
@@ -70,7 +77,13 @@ func runCreateBackend() error {
 		NamespaceParam(config.Namespace).
 		FileName(config.FileName).
 		FileType(config.FileType).
+		DryRun(config.DryRun).
 		Build()
 
+	validator := resources.NewValidatorBuilder(res).ValidateDryRun().Build()
+	if err := validator.Validate(); err != nil {
+		return helper.PrintlnError(err)
+	}
+
 	return resources.NewBackend(res).Create()
 }
@@ -0,0 +1,69 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package command
+
+import (
+	"github.com/spf13/cobra"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/cmd/options"
+	"huawei-csi-driver/cli/config"
+	"huawei-csi-driver/cli/helper"
+	"huawei-csi-driver/cli/resources"
+)
+
+func init() {
+	options.NewFlagsOptions(diffBackendCmd).
+		WithNameSpace(false).
+		WithFilename(true).
+		WithInputFileType().
+		WithProvisioner().
+		WithNotValidateName().
+		WithParent(diffCmd)
+}
+
+var (
+	diffBackendExample = helper.Examples(`
+		# Diff the backends in backend.yaml file against the live objects in default(huawei-csi) namespace
+		oceanctl diff backend -f /path/to/backend.yaml -i yaml
+
+		# Diff the backends in specified namespace
+		oceanctl diff backend -f /path/to/backend.yaml -i yaml -n <namespace>
+
+		# Diff the backends in config.json file
+		oceanctl diff backend -f /path/to/configmap.json -i json`)
+)
+
+var diffBackendCmd = &cobra.Command{
+	Use:     "backend",
+	Short:   "Diff the backends in the file against the configmaps and storageBackendClaims in Kubernetes",
+	Example: diffBackendExample,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDiffBackend()
+	},
+}
+
+func runDiffBackend() error {
+	res := resources.NewResourceBuilder().
+		ResourceTypes(string(client.Storagebackendclaim)).
+		NamespaceParam(config.Namespace).
+		FileName(config.FileName).
+		FileType(config.FileType).
+		Build()
+
+	return resources.NewBackend(res).Diff()
+}
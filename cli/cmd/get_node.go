@@ -0,0 +1,70 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package command
+
+import (
+	"github.com/spf13/cobra"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/cmd/options"
+	"huawei-csi-driver/cli/config"
+	"huawei-csi-driver/cli/helper"
+	"huawei-csi-driver/cli/resources"
+)
+
+func init() {
+	options.NewFlagsOptions(getNodeCmd).
+		WithOutPutFormat().
+		WithProvisioner().
+		WithDecryption().
+		WithParent(getCmd)
+}
+
+var (
+	getNodeExample = helper.Examples(`
+		# List the volumes provisioned by huawei-csi attached to all nodes
+		oceanctl get node
+
+		# List the volumes attached to specified nodes
+		oceanctl get node <node-name...>
+
+		# List the volumes attached to the node with the lun on the storage (such as WWN and mapped hosts)
+		oceanctl get node <node-name> -o wide`)
+)
+
+var getNodeCmd = &cobra.Command{
+	Use:     "node [<name>...]",
+	Short:   "Get the volumes provisioned by huawei-csi attached to one or more nodes",
+	Example: getNodeExample,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runGetNode(args)
+	},
+}
+
+func runGetNode(nodeNames []string) error {
+	res := resources.NewResourceBuilder().
+		ResourceNames(string(client.Node), nodeNames...).
+		Output(config.OutputFormat).
+		Build()
+
+	validator := resources.NewValidatorBuilder(res).ValidateOutputFormat().Build()
+	if err := validator.Validate(); err != nil {
+		return helper.PrintlnError(err)
+	}
+
+	return resources.NewNode(res).Get()
+}
@@ -0,0 +1,78 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package command
+
+import (
+	"github.com/spf13/cobra"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/cmd/options"
+	"huawei-csi-driver/cli/config"
+	"huawei-csi-driver/cli/helper"
+	"huawei-csi-driver/cli/resources"
+)
+
+func init() {
+	options.NewFlagsOptions(getSnapshotCmd).
+		WithNameSpace(false).
+		WithOutPutFormat().
+		WithProvisioner().
+		WithDecryption().
+		WithParent(getCmd)
+}
+
+var (
+	getSnapshotExample = helper.Examples(`
+		# List all volume snapshot contents provisioned by huawei-csi
+		oceanctl get snapshot
+
+		# List the snapshot contents whose VolumeSnapshots are in specified namespace
+		oceanctl get snapshot -n <namespace>
+
+		# List specified snapshots by the names of the VolumeSnapshotContents
+		oceanctl get snapshot <content-name...>
+
+		# List all snapshots with the snapshot on the storage (such as ID and WWN)
+		oceanctl get snapshot -o wide
+
+		# Get a single snapshot with YAML output format
+		oceanctl get snapshot <content-name> -o yaml`)
+)
+
+var getSnapshotCmd = &cobra.Command{
+	Use:     "snapshot [<content-name>...]",
+	Short:   "Get one or more volume snapshots provisioned by huawei-csi with the snapshots on Ocean Storage",
+	Example: getSnapshotExample,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runGetSnapshot(args)
+	},
+}
+
+func runGetSnapshot(snapshotNames []string) error {
+	res := resources.NewResourceBuilder().
+		ResourceNames(string(client.VolumeSnapshotContent), snapshotNames...).
+		NamespaceParam(config.Namespace).
+		Output(config.OutputFormat).
+		Build()
+
+	validator := resources.NewValidatorBuilder(res).ValidateOutputFormat().Build()
+	if err := validator.Validate(); err != nil {
+		return helper.PrintlnError(err)
+	}
+
+	return resources.NewSnapshot(res).Get()
+}
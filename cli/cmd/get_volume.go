@@ -0,0 +1,81 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package command
+
+import (
+	"github.com/spf13/cobra"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/cmd/options"
+	"huawei-csi-driver/cli/config"
+	"huawei-csi-driver/cli/helper"
+	"huawei-csi-driver/cli/resources"
+)
+
+func init() {
+	options.NewFlagsOptions(getVolumeCmd).
+		WithNameSpace(false).
+		WithOutPutFormat().
+		WithProvisioner().
+		WithDecryption().
+		WithParent(getCmd)
+}
+
+var (
+	getVolumeExample = helper.Examples(`
+		# List all volumes provisioned by huawei-csi
+		oceanctl get volume
+
+		# List the volumes whose PVCs are in specified namespace
+		oceanctl get volume -n <namespace>
+
+		# List specified volumes by the names of the PVs
+		oceanctl get volume <pv-name...>
+
+		# List all volumes with the lun or filesystem on the storage (such as WWN, pool, qos and pairs)
+		oceanctl get volume -o wide
+
+		# List all volumes whose backend password is encrypted with the key
+		oceanctl get volume -o wide --key-file /path/to/key
+
+		# Get a single volume with JSON output format
+		oceanctl get volume <pv-name> -o json`)
+)
+
+var getVolumeCmd = &cobra.Command{
+	Use:     "volume [<pv-name>...]",
+	Short:   "Get one or more volumes provisioned by huawei-csi with the objects on Ocean Storage",
+	Example: getVolumeExample,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runGetVolume(args)
+	},
+}
+
+func runGetVolume(volumeNames []string) error {
+	res := resources.NewResourceBuilder().
+		ResourceNames(string(client.PersistentVolume), volumeNames...).
+		NamespaceParam(config.Namespace).
+		Output(config.OutputFormat).
+		Build()
+
+	validator := resources.NewValidatorBuilder(res).ValidateOutputFormat().Build()
+	if err := validator.Validate(); err != nil {
+		return helper.PrintlnError(err)
+	}
+
+	return resources.NewVolume(res).Get()
+}
@@ -101,6 +101,47 @@ func (b *FlagsOptions) WithNotValidateName() *FlagsOptions {
 	return b
 }
 
+// WithEncryption This function will add the flags of the keys used to encrypt the stored values
+func (b *FlagsOptions) WithEncryption() *FlagsOptions {
+	b.cmd.PersistentFlags().StringSliceVarP(&config.EncryptedKeys, "keys", "k", []string{"password"},
+		"keys of the encrypted values in the secret")
+	b.cmd.PersistentFlags().StringVarP(&config.KeyFile, "key-file", "", "", "path to the file of the key "+
+		"which the values are encrypted with")
+	b.cmd.PersistentFlags().StringVarP(&config.NewKeyFile, "new-key-file", "", "", "path to the file of the key "+
+		"which the values are re-encrypted with, default is the key of key-file")
+	b.cmd.PersistentFlags().StringVarP(&config.KMSEndpoint, "kms-endpoint", "", "", "endpoint of the KMS plugin, "+
+		"e.g. unix:///var/run/kms/kms.sock. If set, the values are re-encrypted by the envelope encryption")
+	return b
+}
+
+// WithDecryption This function will add the flags used to decrypt the password of the backend secret
+func (b *FlagsOptions) WithDecryption() *FlagsOptions {
+	b.cmd.PersistentFlags().StringVarP(&config.KeyFile, "key-file", "", "", "path to the file of the key "+
+		"which the password of the backend is encrypted with")
+	b.cmd.PersistentFlags().StringVarP(&config.KMSEndpoint, "kms-endpoint", "", "", "endpoint of the KMS plugin "+
+		"which the password of the backend is encrypted by, e.g. unix:///var/run/kms/kms.sock")
+	return b
+}
+
+// WithCollection This function will add the flags to select the objects collected into the diagnostic bundle
+func (b *FlagsOptions) WithCollection() *FlagsOptions {
+	b.cmd.PersistentFlags().StringSliceVarP(&config.Nodes, "node", "", nil, "nodes whose driver pods, "+
+		"volume attachments and volumes are collected, default all nodes")
+	b.cmd.PersistentFlags().StringVarP(&config.VolumeNamespace, "volume-namespace", "", "",
+		"namespace of the PVCs whose volumes are collected, default all namespaces")
+	b.cmd.PersistentFlags().StringVarP(&config.OutputDir, "output-dir", "d", ".", "directory which the "+
+		"bundle is written to")
+	return b
+}
+
+// WithDryRun This function will add the dry-run flag to render and validate the objects without persisting them
+func (b *FlagsOptions) WithDryRun() *FlagsOptions {
+	b.cmd.PersistentFlags().StringVarP(&config.DryRun, "dry-run", "", config.DryRunNone, "must be none, client "+
+		"or server. If client, only print the objects that would be sent and validate them like the webhook. If "+
+		"server, submit the objects to the api server as well without persisting them")
+	return b
+}
+
 func (b *FlagsOptions) markPersistentFlagRequired(name string) {
 	// Because only 'no such flag' error will be returned, and we have ensured
 	// that the incoming parameters are correct, so no err will be handled.
@@ -108,3 +149,13 @@ func (b *FlagsOptions) markPersistentFlagRequired(name string) {
 		log.Errorf("MarkPersistentFlagRequired failed, error: %v", err)
 	}
 }
+
+// WithKubeConfig this function will add the flags to connect to the Kubernetes API server by client-go
+func (b *FlagsOptions) WithKubeConfig() *FlagsOptions {
+	b.cmd.PersistentFlags().StringVarP(&config.KubeConfig, "kubeconfig", "", "",
+		"path to the kubeconfig file, kubectl or oc is used if the API server can't be connected by it")
+	b.cmd.PersistentFlags().StringVarP(&config.KubeContext, "context", "", "", "the kubeconfig context to use")
+	b.cmd.PersistentFlags().BoolVarP(&config.InCluster, "in-cluster", "", false,
+		"use the service account of the pod to connect to the API server")
+	return b
+}
@@ -0,0 +1,32 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package command
+
+import (
+	"github.com/spf13/cobra"
+
+	"huawei-csi-driver/cli/cmd/options"
+)
+
+func init() {
+	options.NewFlagsOptions(reEncryptCmd).WithParent(RootCmd)
+}
+
+var reEncryptCmd = &cobra.Command{
+	Use:   "reencrypt",
+	Short: "Re-encrypt the encrypted values of a resource for Ocean Storage in Kubernetes",
+}
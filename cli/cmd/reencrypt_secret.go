@@ -0,0 +1,71 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package command
+
+import (
+	"github.com/spf13/cobra"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/cmd/options"
+	"huawei-csi-driver/cli/config"
+	"huawei-csi-driver/cli/helper"
+	"huawei-csi-driver/cli/resources"
+)
+
+func init() {
+	options.NewFlagsOptions(reEncryptSecretCmd).
+		WithNameSpace(false).
+		WithEncryption().
+		WithParent(reEncryptCmd)
+}
+
+var (
+	reEncryptSecretExample = helper.Examples(`
+		# Re-encrypt the password of the secret in default(huawei-csi) namespace with a new key
+		oceanctl reencrypt secret <name> --key-file /path/to/old.key --new-key-file /path/to/new.key
+
+		# Upgrade the legacy encrypted password of the secret in specified namespace to the current format
+		oceanctl reencrypt secret <name> -n namespace --key-file /path/to/key
+
+		# Re-encrypt the values of the specified keys of the secret by the KMS plugin
+		oceanctl reencrypt secret <name> --keys password,user --key-file /path/to/key \
+			--kms-endpoint unix:///var/run/kms/kms.sock`)
+)
+
+var reEncryptSecretCmd = &cobra.Command{
+	Use:     "secret <name>",
+	Short:   "Re-encrypt the encrypted values of a secret for Ocean Storage in Kubernetes",
+	Example: reEncryptSecretExample,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runReEncryptSecret(args)
+	},
+}
+
+func runReEncryptSecret(secretNames []string) error {
+	res := resources.NewResourceBuilder().
+		ResourceNames(string(client.Secret), secretNames...).
+		NamespaceParam(config.Namespace).
+		DefaultNamespace().
+		Build()
+
+	validator := resources.NewValidatorBuilder(res).ValidateNameIsExist().ValidateNameIsSingle().Build()
+	if err := validator.Validate(); err != nil {
+		return helper.PrintlnError(err)
+	}
+
+	return resources.NewSecret(res).ReEncrypt()
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/cmd/options"
 	"huawei-csi-driver/cli/config"
 	"huawei-csi-driver/utils/log"
 )
@@ -35,7 +36,19 @@ var RootCmd = &cobra.Command{
 	},
 }
 
+func init() {
+	options.NewFlagsOptions(RootCmd).WithKubeConfig()
+}
+
 func discoverOperating() error {
+	kubeClient, err := client.NewKubernetesClientGo(config.KubeConfig, config.KubeContext, config.InCluster)
+	if err != nil && (config.KubeConfig != "" || config.KubeContext != "" || config.InCluster) {
+		return err
+	}
+	if err == nil {
+		client.RegisterClient(client.CLIClientGo, kubeClient)
+	}
+
 	clientName, err := client.DiscoverKubernetesCLI()
 	if err != nil {
 		return err
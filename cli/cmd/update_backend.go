@@ -30,6 +30,7 @@ func init() {
 	options.NewFlagsOptions(updateBackendCmd).
 		WithNameSpace(false).
 		WithPassword(true).
+		WithDryRun().
 		WithParent(updateCmd)
 }
 
@@ -39,7 +40,10 @@ var (
 		oceanctl update backend <name>  --password
 
 	    # Update backend account information in specified namespace
-		oceanctl update backend <name> -n namespace --password`)
+		oceanctl update backend <name> -n namespace --password
+
+		# Validate the new account information like the webhook without updating the backend
+		oceanctl update backend <name> --password --dry-run=client`)
 )
 
 var updateBackendCmd = &cobra.Command{
@@ -56,9 +60,11 @@ func runUpdateBackend(backendNames []string) error {
 		ResourceNames(string(client.Storagebackendclaim), backendNames...).
 		NamespaceParam(config.Namespace).
 		DefaultNamespace().
+		DryRun(config.DryRun).
 		Build()
 
-	validator := resources.NewValidatorBuilder(res).ValidateNameIsExist().ValidateNameIsSingle().Build()
+	validator := resources.NewValidatorBuilder(res).ValidateNameIsExist().ValidateNameIsSingle().ValidateDryRun().
+		Build()
 	if err := validator.Validate(); err != nil {
 		return helper.PrintlnError(err)
 	}
@@ -38,11 +38,23 @@ const (
 
 	// DefaultInputFormat default input format
 	DefaultInputFormat = "yaml"
+
+	// DryRunNone the objects are persisted
+	DryRunNone = "none"
+
+	// DryRunClient the objects are rendered and validated by oceanctl without being sent to the api server
+	DryRunClient = "client"
+
+	// DryRunServer the objects are also submitted to the api server without persisting
+	DryRunServer = "server"
 )
 
 var (
 	// SupportedFormats supported output format
 	SupportedFormats = []string{"json", "wide", "yaml"}
+
+	// SupportedDryRunModes supported dry-run modes
+	SupportedDryRunModes = []string{DryRunNone, DryRunClient, DryRunServer}
 )
 
 var (
@@ -70,6 +82,39 @@ var (
 	// NotValidateName the value of validate flag, set by options.WithNotValidateName
 	NotValidateName bool
 
+	// EncryptedKeys the value of keys flag, set by options.WithEncryption().
+	EncryptedKeys []string
+
+	// KeyFile the value of key-file flag, set by options.WithEncryption().
+	KeyFile string
+
+	// NewKeyFile the value of new-key-file flag, set by options.WithEncryption().
+	NewKeyFile string
+
+	// KMSEndpoint the value of kms-endpoint flag, set by options.WithEncryption().
+	KMSEndpoint string
+
+	// KubeConfig the value of kubeconfig flag, set by options.WithKubeConfig().
+	KubeConfig string
+
+	// KubeContext the value of context flag, set by options.WithKubeConfig().
+	KubeContext string
+
+	// InCluster the value of in-cluster flag, set by options.WithKubeConfig().
+	InCluster bool
+
+	// Nodes the value of node flag, set by options.WithCollection().
+	Nodes []string
+
+	// VolumeNamespace the value of volume-namespace flag, set by options.WithCollection().
+	VolumeNamespace string
+
+	// OutputDir the value of output-dir flag, set by options.WithCollection().
+	OutputDir string
+
+	// DryRun the value of dry-run flag, set by options.WithDryRun().
+	DryRun string
+
 	// Client when the discoverOperating() function executes successfully, this field will be set.
 	Client client.KubernetesClient
 )
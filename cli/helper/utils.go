@@ -129,11 +129,30 @@ func PrintNotFoundBackend(names ...string) {
 	}
 }
 
+// PrintNotFoundSecret print not found secret
+func PrintNotFoundSecret(names ...string) {
+	for _, name := range names {
+		fmt.Printf("Error from server (NotFound): secret \"%s\" not found\n", name)
+	}
+}
+
 // PrintNoResourceBackend print not found backend
 func PrintNoResourceBackend(namespace string) {
 	fmt.Printf("No backends found in %s namespace\n", namespace)
 }
 
+// PrintNotFoundResource print not found resource of the resource type, e.g. volume
+func PrintNotFoundResource(resourceType string, names ...string) {
+	for _, name := range names {
+		fmt.Printf("Error from server (NotFound): %s \"%s\" not found\n", resourceType, name)
+	}
+}
+
+// PrintNoResource print no resource of the resource type is found
+func PrintNoResource(resourceType string) {
+	fmt.Printf("No %ss found\n", resourceType)
+}
+
 // GetPrintFunc get print function by format type
 func GetPrintFunc[T any](format string) func(t []T) {
 	switch format {
@@ -169,13 +188,18 @@ func ReadRow[T any](t T) []string {
 }
 
 // ReadStruct read struct
-// T is a struct
+// T is a struct, the fields of the embedded struct are read as the fields of T
 // readFunc is a read struct func, e.g. read struct filed value
 func ReadStruct[T any, O any](t T, readFunc func(field reflect.StructField, value reflect.Value) (O, bool)) []O {
 	var result []O
 	filedType := reflect.TypeOf(t)
 	filedValue := reflect.ValueOf(t)
 	for i := 0; i < filedType.NumField(); i++ {
+		if filedType.Field(i).Anonymous && filedType.Field(i).Type.Kind() == reflect.Struct {
+			result = append(result, ReadStruct(filedValue.Field(i).Interface(), readFunc)...)
+			continue
+		}
+
 		if item, ok := readFunc(filedType.Field(i), filedValue.Field(i)); ok {
 			result = append(result, item)
 		}
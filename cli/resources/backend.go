@@ -17,6 +17,7 @@
 package resources
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -25,6 +26,8 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8string "k8s.io/utils/strings"
 
 	"huawei-csi-driver/cli/client"
@@ -75,7 +78,10 @@ func (b *Backend) Get() error {
 	}
 
 	if b.resource.output == "wide" {
-		helper.PrintBackend(wideShows, notFoundBackends, helper.PrintWithTable[BackendShowWide])
+		conditionShows := helper.MapTo(wideShows, func(wide BackendShowWide) BackendConditionShowWide {
+			return newBackendConditionShowWide(wide, claims)
+		})
+		helper.PrintBackend(conditionShows, notFoundBackends, helper.PrintWithTable[BackendConditionShowWide])
 		return nil
 	}
 
@@ -95,6 +101,41 @@ func (b *Backend) Get() error {
 	return nil
 }
 
+// BackendConditionShowWide is the wide show of the backend with the condition of the claim
+type BackendConditionShowWide struct {
+	BackendShowWide
+	Condition string `show:"CONDITION"`
+	Reason    string `show:"REASON"`
+}
+
+// newBackendConditionShowWide used to show the first false condition of the claim of the backend, or the Synced
+// condition if all conditions are true
+func newBackendConditionShowWide(wide BackendShowWide,
+	claims []xuanwuV1.StorageBackendClaim) BackendConditionShowWide {
+	show := BackendConditionShowWide{BackendShowWide: wide}
+	for _, claim := range claims {
+		if claim.Namespace != wide.Namespace || claim.Name != wide.Name || claim.Status == nil {
+			continue
+		}
+
+		for _, conditionType := range []string{xuanwuV1.ConditionReachable, xuanwuV1.ConditionAuthenticated,
+			xuanwuV1.ConditionPoolsAvailable, xuanwuV1.ConditionSynced} {
+			condition := meta.FindStatusCondition(claim.Status.Conditions, conditionType)
+			if condition == nil {
+				continue
+			}
+
+			show.Condition = fmt.Sprintf("%s=%s", condition.Type, condition.Status)
+			show.Reason = condition.Reason
+			if condition.Status != metav1.ConditionTrue {
+				return show
+			}
+		}
+	}
+
+	return show
+}
+
 func (b *Backend) Delete() error {
 	storageBackendClaimClient := client.NewCommonCallHandler[xuanwuV1.StorageBackendClaim](config.Client)
 	claims, err := storageBackendClaimClient.QueryList(b.resource.namespace, b.resource.names...)
@@ -134,6 +175,10 @@ func (b *Backend) Update() error {
 		return nil
 	}
 
+	if b.isDryRun() {
+		return b.dryRunUpdate(oldClaim)
+	}
+
 	nameWithUUid := helper.AppendUid(oldClaim.Name, config.DefaultUidLength)
 	if err = createSecretWithUid(oldClaim, nameWithUUid); err != nil {
 		return err
@@ -165,18 +210,27 @@ func (b *Backend) Update() error {
 }
 
 func createSecretWithUid(claim xuanwuV1.StorageBackendClaim, uuid string) error {
+	secret, err := newAccountSecret(claim.Namespace, uuid)
+	if err != nil {
+		return err
+	}
+
+	secretClient := client.NewCommonCallHandler[corev1.Secret](config.Client)
+	return secretClient.Create(secret)
+}
+
+// newAccountSecret used to build the secret of the account which is entered by the user
+func newAccountSecret(namespace, name string) (corev1.Secret, error) {
 	backendConfig := &BackendConfiguration{
-		Name:      uuid,
-		NameSpace: claim.Namespace,
+		Name:      name,
+		NameSpace: namespace,
 	}
 
 	secretConfig, err := backendConfig.ToSecretConfig()
 	if err != nil {
-		return err
+		return corev1.Secret{}, err
 	}
-
-	secretClient := client.NewCommonCallHandler[corev1.Secret](config.Client)
-	return secretClient.Create(secretConfig.ToSecret())
+	return secretConfig.ToSecret(), nil
 }
 
 func getNotFoundBackends(queryResult []xuanwuV1.StorageBackendClaim, queryNames []string) []string {
@@ -308,6 +362,11 @@ func (b *Backend) Create() error {
 		return helper.LogErrorf("fetch configured backend failed: error: %v", err)
 	}
 
+	configOneBackend := ConfigOneBackend
+	if b.isDryRun() {
+		configOneBackend = b.dryRunConfigOneBackend
+	}
+
 	backends := MergeBackends(notConfiguredBackends, configuredBackends)
 	for {
 		selectedBackend, err := selectOneBackend(backends)
@@ -320,7 +379,7 @@ func (b *Backend) Create() error {
 			continue
 		}
 
-		if err := ConfigOneBackend(selectedBackend); err != nil {
+		if err := configOneBackend(selectedBackend); err != nil {
 			fmt.Printf("failed to configure the backend account. %v\n", err)
 			continue
 		}
@@ -382,12 +441,12 @@ func ConfigOneBackend(backendConfig *BackendConfiguration) error {
 	}
 
 	// create configmap resource
-	mapConfig, err := backendConfig.ToConfigMapConfig()
+	configMap, err := newBackendConfigMap(backendConfig)
 	if err != nil {
 		return err
 	}
 	configMapClient := client.NewCommonCallHandler[corev1.ConfigMap](config.Client)
-	if err = configMapClient.Create(mapConfig.ToConfigMap()); err != nil {
+	if err = configMapClient.Create(configMap); err != nil {
 		return err
 	}
 
@@ -414,6 +473,46 @@ func ConfigOneBackend(backendConfig *BackendConfiguration) error {
 	return nil
 }
 
+// newBackendConfigMap used to render the configmap of the backend. The certificate of the storage is verified for
+// the new backend unless verifyCert is configured in the backend file, while the backends configured before keep
+// skipping the verification since verifyCert is absent in their configmaps.
+func newBackendConfigMap(backendConfig *BackendConfiguration) (corev1.ConfigMap, error) {
+	mapConfig, err := backendConfig.ToConfigMapConfig()
+	if err != nil {
+		return corev1.ConfigMap{}, err
+	}
+
+	configMap := mapConfig.ToConfigMap()
+	if err = enableCertVerification(&configMap); err != nil {
+		return corev1.ConfigMap{}, err
+	}
+	return configMap, nil
+}
+
+func enableCertVerification(configMap *corev1.ConfigMap) error {
+	var csiConfig map[string]interface{}
+	if err := json.Unmarshal([]byte(configMap.Data["csi.json"]), &csiConfig); err != nil {
+		return fmt.Errorf("unmarshal csi.json of configmap %s/%s failed, error: %v", configMap.Namespace,
+			configMap.Name, err)
+	}
+
+	backend, ok := csiConfig["backends"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("backends not found in csi.json of configmap %s/%s", configMap.Namespace, configMap.Name)
+	}
+	if _, exist := backend["verifyCert"]; exist {
+		return nil
+	}
+
+	backend["verifyCert"] = true
+	data, err := json.Marshal(csiConfig)
+	if err != nil {
+		return err
+	}
+	configMap.Data["csi.json"] = string(data)
+	return nil
+}
+
 func selectOneBackend(backendList []*BackendConfiguration) (*BackendConfiguration, error) {
 	printBackendsStatusTable(backendList)
 	number, err := helper.GetSelectedNumber("Please enter the backend number to configure "+
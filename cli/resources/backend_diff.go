@@ -0,0 +1,159 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resources
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	corev1 "k8s.io/api/core/v1"
+	k8string "k8s.io/utils/strings"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/config"
+	"huawei-csi-driver/cli/helper"
+	xuanwuV1 "huawei-csi-driver/client/apis/xuanwu/v1"
+)
+
+const diffContextLines = 3
+
+// Diff used to show the differences between the backends in the file and the live objects in the cluster. The
+// backend config of the configmap and the spec of the storageBackendClaim are compared, the secret is not since
+// the account is entered interactively.
+func (b *Backend) Diff() error {
+	backends, err := b.LoadBackendFile()
+	if err != nil {
+		return helper.LogErrorf("load backend failed: error: %v", err)
+	}
+	backends, err = b.preProcessBackend(backends)
+	if err != nil {
+		return helper.LogErrorf("pre process backend failed: error: %v", err)
+	}
+
+	var names []string
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var diffs []string
+	for _, name := range names {
+		backendDiffs, err := diffBackend(backends[name])
+		if err != nil {
+			return helper.LogErrorf("diff backend failed, error: %v", err)
+		}
+		diffs = append(diffs, backendDiffs...)
+	}
+
+	if len(diffs) == 0 {
+		helper.PrintResult("No differences found\n")
+		return nil
+	}
+	helper.PrintResult(strings.Join(diffs, ""))
+	return nil
+}
+
+// diffBackend returns the unified diffs of the configmap and the storageBackendClaim of the backend
+func diffBackend(backendConfig *BackendConfiguration) ([]string, error) {
+	configMap, err := newBackendConfigMap(backendConfig)
+	if err != nil {
+		return nil, err
+	}
+	claim := backendConfig.ToStorageBackendClaimConfig().ToStorageBackendClaim()
+
+	configMapClient := client.NewCommonCallHandler[corev1.ConfigMap](config.Client)
+	liveConfigMap, err := configMapClient.QueryByName(configMap.Namespace, configMap.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	claimClient := client.NewCommonCallHandler[xuanwuV1.StorageBackendClaim](config.Client)
+	liveClaim, err := claimClient.QueryByName(claim.Namespace, claim.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	storageConfig, err := parseBackendConfig(configMap)
+	if err != nil {
+		return nil, err
+	}
+	configMapName := "ConfigMap/" + k8string.JoinQualifiedName(configMap.Namespace, configMap.Name)
+	configMapDiff, err := diffObjects(configMapName, !reflect.DeepEqual(liveConfigMap, corev1.ConfigMap{}),
+		func() (interface{}, error) { return parseBackendConfig(liveConfigMap) }, storageConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	claimExist := !reflect.DeepEqual(liveClaim, xuanwuV1.StorageBackendClaim{})
+	if claimExist {
+		// the secret is replaced by oceanctl update backend, so the secretMeta is not compared
+		claim.Spec.SecretMeta = liveClaim.Spec.SecretMeta
+	}
+	claimName := "StorageBackendClaim/" + k8string.JoinQualifiedName(claim.Namespace, claim.Name)
+	claimDiff, err := diffObjects(claimName, claimExist,
+		func() (interface{}, error) { return liveClaim.Spec, nil }, claim.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []string
+	for _, diff := range []string{configMapDiff, claimDiff} {
+		if diff != "" {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs, nil
+}
+
+// diffObjects returns the unified diff of the yaml of the live object and the object in the file, the live object
+// is got by getLive only if it exists
+func diffObjects(name string, liveExist bool, getLive func() (interface{}, error),
+	object interface{}) (string, error) {
+	var liveLines []string
+	if liveExist {
+		live, err := getLive()
+		if err != nil {
+			return "", err
+		}
+		if liveLines, err = toDiffLines(live); err != nil {
+			return "", err
+		}
+	}
+
+	lines, err := toDiffLines(object)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        liveLines,
+		FromFile: "live/" + name,
+		B:        lines,
+		ToFile:   "file/" + name,
+		Context:  diffContextLines,
+	})
+}
+
+func toDiffLines(object interface{}) ([]string, error) {
+	data, err := helper.StructToYAML(object)
+	if err != nil {
+		return nil, err
+	}
+	return difflib.SplitLines(strings.TrimSuffix(string(data), "\n")), nil
+}
@@ -0,0 +1,197 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8string "k8s.io/utils/strings"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/config"
+	"huawei-csi-driver/cli/helper"
+	xuanwuV1 "huawei-csi-driver/client/apis/xuanwu/v1"
+	"huawei-csi-driver/pkg/webhook"
+)
+
+// isDryRun returns whether the objects of the backend are only rendered and validated without persisting
+func (b *Backend) isDryRun() bool {
+	return b.resource.dryRun != "" && b.resource.dryRun != config.DryRunNone
+}
+
+// dryRunConfigOneBackend used to render the configmap, the secret and the storageBackendClaim of the backend
+// without persisting them. The claim is validated like the webhook does by the rendered objects, and the objects
+// are submitted to the api server with dryRun=All as well in the server mode.
+func (b *Backend) dryRunConfigOneBackend(backendConfig *BackendConfiguration) error {
+	claim := backendConfig.ToStorageBackendClaimConfig().ToStorageBackendClaim()
+
+	configMap, err := newBackendConfigMap(backendConfig)
+	if err != nil {
+		return err
+	}
+
+	secret, err := newAccountSecret(backendConfig.NameSpace, backendConfig.Name)
+	if err != nil {
+		return err
+	}
+
+	if err = printDryRunObjects(configMap, maskSecret(secret), claim); err != nil {
+		return err
+	}
+
+	storageConfig, err := parseBackendConfig(configMap)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if err = webhook.ValidateCreate(ctx, &claim, newDryRunStorageInfoGetter(storageConfig, secret)); err != nil {
+		return fmt.Errorf("validate backend %s failed, error: %v", backendConfig.Name, err)
+	}
+
+	if b.resource.dryRun == config.DryRunServer {
+		if err = submitDryRunObjects(client.Create, configMap, secret, claim); err != nil {
+			return fmt.Errorf("submit backend %s to the api server failed, error: %v", backendConfig.Name, err)
+		}
+	}
+
+	helper.PrintResult(fmt.Sprintf("Backend %s is validated (%s dry run)\n", backendConfig.Name,
+		b.resource.dryRun))
+	return nil
+}
+
+// dryRunUpdate used to render the new secret of the backend and the storageBackendClaim referring to it without
+// persisting them, the claim is validated by the rendered secret and the configmap of the backend in the cluster
+func (b *Backend) dryRunUpdate(oldClaim xuanwuV1.StorageBackendClaim) error {
+	nameWithUUid := helper.AppendUid(oldClaim.Name, config.DefaultUidLength)
+	secret, err := newAccountSecret(oldClaim.Namespace, nameWithUUid)
+	if err != nil {
+		return err
+	}
+
+	newClaim := oldClaim.DeepCopy()
+	newClaim.Spec.SecretMeta = k8string.JoinQualifiedName(newClaim.Namespace, nameWithUUid)
+	newClaim.ManagedFields = nil
+	if err = printDryRunObjects(maskSecret(secret), *newClaim); err != nil {
+		return err
+	}
+
+	storageConfig, err := loadBackendConfig(oldClaim.Spec.ConfigMapMeta)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	err = webhook.ValidateUpdate(ctx, newClaim, &oldClaim, newDryRunStorageInfoGetter(storageConfig, secret))
+	if err != nil {
+		return fmt.Errorf("validate backend %s failed, error: %v", oldClaim.Name, err)
+	}
+
+	if b.resource.dryRun == config.DryRunServer {
+		if err = submitDryRunObjects(client.Create, secret); err != nil {
+			return fmt.Errorf("submit secret of backend %s to the api server failed, error: %v", oldClaim.Name, err)
+		}
+		if err = submitDryRunObjects(client.Apply, *newClaim); err != nil {
+			return fmt.Errorf("submit backend %s to the api server failed, error: %v", oldClaim.Name, err)
+		}
+	}
+
+	helper.PrintResult(fmt.Sprintf("Backend %s is validated (%s dry run)\n", oldClaim.Name, b.resource.dryRun))
+	return nil
+}
+
+// newDryRunStorageInfoGetter used to build the storage info of the claim by the backend config and the rendered
+// secret instead of the objects in the cluster. Nil is returned for the storages whose login can't be validated
+// in the cli, so that only the claim and the backend config are validated.
+func newDryRunStorageInfoGetter(storageConfig map[string]interface{},
+	secret corev1.Secret) webhook.StorageInfoGetter {
+	return func(ctx context.Context, claim *xuanwuV1.StorageBackendClaim) (map[string]interface{}, error) {
+		storage, _ := storageConfig["storage"].(string)
+		if !isLoginValidatable(storage) {
+			printWarning("validating the login of the storage %s is not supported in dry-run", storage)
+			return nil, nil
+		}
+
+		secretMeta := k8string.JoinQualifiedName(secret.Namespace, secret.Name)
+		user, password, err := decodeBackendAccount(ctx, secretMeta, getRenderedSecretData(secret))
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig, err := newStorageTLSConfig(storageConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return newValidateParam(storageConfig, k8string.JoinQualifiedName(claim.Namespace, claim.Name),
+			claim.Spec.SecretMeta, user, password, tlsConfig), nil
+	}
+}
+
+// getRenderedSecretData returns the data of the rendered secret, the stringData is merged into the data like the
+// api server does
+func getRenderedSecretData(secret corev1.Secret) map[string][]byte {
+	data := make(map[string][]byte, len(secret.Data)+len(secret.StringData))
+	for key, value := range secret.Data {
+		data[key] = value
+	}
+	for key, value := range secret.StringData {
+		data[key] = []byte(value)
+	}
+	return data
+}
+
+// maskSecret returns a copy of the secret whose values are masked, so that the password is not printed
+func maskSecret(secret corev1.Secret) corev1.Secret {
+	masked := secret.DeepCopy()
+	for key := range masked.Data {
+		masked.Data[key] = []byte(maskedValue)
+	}
+	for key := range masked.StringData {
+		masked.StringData[key] = maskedValue
+	}
+	return *masked
+}
+
+// printDryRunObjects used to print the rendered objects as yaml documents
+func printDryRunObjects(objects ...interface{}) error {
+	for _, object := range objects {
+		data, err := helper.StructToYAML(object)
+		if err != nil {
+			return err
+		}
+		helper.PrintResult(fmt.Sprintf("---\n%s", data))
+	}
+	return nil
+}
+
+// submitDryRunObjects used to submit the objects to the api server with dryRun=All, so that they are validated and
+// admitted by the webhooks in the cluster without persisting
+func submitDryRunObjects(operate string, objects ...interface{}) error {
+	for _, object := range objects {
+		data, err := helper.StructToYAML(object)
+		if err != nil {
+			return err
+		}
+		if err = config.Client.DryRunResourceByYaml(string(data), operate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
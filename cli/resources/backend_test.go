@@ -0,0 +1,47 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resources
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestEnableCertVerification(t *testing.T) {
+	cases := []struct {
+		name       string
+		csiJson    string
+		wantVerify interface{}
+	}{
+		{"New backend", `{"backends":{"name":"backend1","storage":"oceanstor-san"}}`, true},
+		{"Verify disabled in backend file", `{"backends":{"name":"backend1","verifyCert":false}}`, false},
+	}
+
+	for _, c := range cases {
+		configMap := corev1.ConfigMap{Data: map[string]string{"csi.json": c.csiJson}}
+		if err := enableCertVerification(&configMap); err != nil {
+			t.Errorf("%s: enableCertVerification failed, error: %v", c.name, err)
+			continue
+		}
+
+		backend, err := parseBackendConfig(configMap)
+		if err != nil || backend["verifyCert"] != c.wantVerify {
+			t.Errorf("%s: verifyCert is %v, want %v, error: %v", c.name, backend["verifyCert"], c.wantVerify, err)
+		}
+	}
+}
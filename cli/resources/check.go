@@ -0,0 +1,73 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resources
+
+import (
+	"fmt"
+
+	"huawei-csi-driver/cli/helper"
+)
+
+const (
+	checkPass = "Pass"
+	checkFail = "Fail"
+	checkSkip = "Skip"
+)
+
+// CheckShow the content echoed by executing the oceanctl check node|backend, each row is the result of a probe
+type CheckShow struct {
+	Target string `show:"TARGET" json:"target"`
+	Check  string `show:"CHECK" json:"check"`
+	Result string `show:"RESULT" json:"result"`
+	Detail string `show:"DETAIL" json:"detail"`
+	Hint   string `show:"HINT" json:"hint,omitempty"`
+}
+
+// checkReport collects the results of the probes of a node or a backend
+type checkReport struct {
+	target string
+	rows   []CheckShow
+}
+
+func (r *checkReport) pass(check, detail string) {
+	r.rows = append(r.rows, CheckShow{Target: r.target, Check: check, Result: checkPass, Detail: detail})
+}
+
+func (r *checkReport) fail(check, detail, hint string) {
+	r.rows = append(r.rows, CheckShow{Target: r.target, Check: check, Result: checkFail, Detail: detail, Hint: hint})
+}
+
+func (r *checkReport) skip(check, detail string) {
+	r.rows = append(r.rows, CheckShow{Target: r.target, Check: check, Result: checkSkip, Detail: detail})
+}
+
+// printCheckShows used to print the results of the probes, an error is returned if any of them failed so that
+// the exit code of oceanctl is not zero
+func printCheckShows(shows []CheckShow, output string) error {
+	helper.GetPrintFunc[CheckShow](output)(shows)
+
+	var failed int
+	for _, show := range shows {
+		if show.Result == checkFail {
+			failed++
+		}
+	}
+	if failed != 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(shows))
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	k8string "k8s.io/utils/strings"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/config"
+	"huawei-csi-driver/cli/helper"
+	xuanwuV1 "huawei-csi-driver/client/apis/xuanwu/v1"
+	"huawei-csi-driver/csi/backend/plugin"
+)
+
+// Check used to run the probes of the driver on the backends, e.g. the phase of the storageBackendClaim, the
+// online status reported by the sidecar and the login validation of the storage done by the webhook
+func (b *Backend) Check() error {
+	claimClient := client.NewCommonCallHandler[xuanwuV1.StorageBackendClaim](config.Client)
+
+	var shows []CheckShow
+	for _, name := range b.resource.names {
+		report := &checkReport{target: name}
+		claim, err := claimClient.QueryByName(b.resource.namespace, name)
+		if err != nil {
+			return helper.LogErrorf("query sbc resource failed, error: %v", err)
+		}
+
+		checkBackend(report, claim)
+		shows = append(shows, report.rows...)
+	}
+	return printCheckShows(shows, b.resource.output)
+}
+
+func checkBackend(report *checkReport, claim xuanwuV1.StorageBackendClaim) {
+	if reflect.DeepEqual(claim, xuanwuV1.StorageBackendClaim{}) {
+		report.fail("claim", "storageBackendClaim is not found",
+			"Create the backend by oceanctl create backend -f <file>")
+		return
+	}
+
+	var phase xuanwuV1.StorageBackendPhase
+	if claim.Status != nil {
+		phase = claim.Status.Phase
+	}
+	if phase != xuanwuV1.BackendBound {
+		report.fail("claim", fmt.Sprintf("storageBackendClaim is not bound, phase: %s", phase),
+			"Check the logs of the huawei-csi-controller pod, the storageBackendContent may fail to be created")
+	} else {
+		report.pass("claim", "storageBackendClaim is bound to "+claim.Status.BoundContentName)
+		checkBackendContent(report, claim.Status.BoundContentName)
+	}
+
+	checkBackendLogin(report, claim)
+}
+
+func checkBackendContent(report *checkReport, contentName string) {
+	contentClient := client.NewCommonCallHandler[xuanwuV1.StorageBackendContent](config.Client)
+	content, err := contentClient.QueryByName("", contentName)
+	if err != nil || reflect.DeepEqual(content, xuanwuV1.StorageBackendContent{}) {
+		report.fail("content", fmt.Sprintf("query storageBackendContent %s failed, error: %v", contentName, err),
+			"Check the logs of the huawei-csi-controller pod")
+		return
+	}
+
+	if content.Status == nil || !content.Status.Online {
+		report.fail("content", fmt.Sprintf("storageBackendContent %s is offline", contentName),
+			"Check the logs of the storage-backend-sidecar container of the huawei-csi-controller pod, the "+
+				"storage may be unreachable or the password may be incorrect")
+		return
+	}
+	report.pass("content", fmt.Sprintf("storageBackendContent %s is online", contentName))
+}
+
+// checkBackendLogin used to validate the login of the storage by the plugin of the driver, which is the same as
+// the webhook does when the backend is created or updated
+func checkBackendLogin(report *checkReport, claim xuanwuV1.StorageBackendClaim) {
+	const check = "login"
+	backendConfig, err := loadBackendConfig(claim.Spec.ConfigMapMeta)
+	if err != nil {
+		report.fail(check, fmt.Sprintf("load backend config failed, error: %v", err),
+			"Check the configmap of the backend, it must contain a valid csi.json")
+		return
+	}
+
+	storage, _ := backendConfig["storage"].(string)
+	p := plugin.GetPlugin(storage)
+	if p == nil || !isLoginValidatable(storage) {
+		report.skip(check, fmt.Sprintf("validating the login of the storage %s is not supported", storage))
+		return
+	}
+
+	ctx := context.Background()
+	user, password, err := getBackendAccount(ctx, claim.Spec.SecretMeta)
+	if err != nil {
+		report.fail(check, fmt.Sprintf("get account of backend failed, error: %v", err),
+			"Specify the --key-file or the --kms-endpoint if the password is encrypted")
+		return
+	}
+
+	tlsConfig, err := newStorageTLSConfig(backendConfig)
+	if err != nil {
+		report.fail(check, fmt.Sprintf("build tls config of backend failed, error: %v", err),
+			"Check the certificates of the backend in the secrets and the configmaps")
+		return
+	}
+
+	param := newValidateParam(backendConfig, k8string.JoinQualifiedName(claim.Namespace, claim.Name),
+		claim.Spec.SecretMeta, user, password, tlsConfig)
+	if err = p.Validate(ctx, param); err != nil {
+		report.fail(check, fmt.Sprintf("validate backend failed, error: %v", err),
+			fmt.Sprintf("Check the urls and the parameters of the backend, if the password is changed, update it "+
+				"by oceanctl update backend %s -n %s --password", claim.Name, claim.Namespace))
+		return
+	}
+	report.pass(check, fmt.Sprintf("login storage as %s successfully", user))
+}
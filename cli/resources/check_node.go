@@ -0,0 +1,309 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/strings/slices"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/config"
+	"huawei-csi-driver/cli/helper"
+	"huawei-csi-driver/connector"
+	"huawei-csi-driver/connector/fibrechannel"
+	connutils "huawei-csi-driver/connector/utils"
+	"huawei-csi-driver/proto"
+	"huawei-csi-driver/utils"
+)
+
+const (
+	serviceActive       = "active"
+	serviceInactive     = "inactive"
+	serviceNotInstalled = "not installed"
+)
+
+// Check used to run the probes of the driver on the nodes, e.g. the initiators, the FC HBAs, the multipath
+// services and the portals of the backends. The probes are executed on the host by the huawei-csi-node pods.
+func (n *Node) Check() error {
+	backends, err := fetchStorageBackends()
+	if err != nil {
+		return helper.LogErrorf("query storagebackendcontent resource failed, error: %v", err)
+	}
+
+	var backendNames []string
+	for name := range backends {
+		backendNames = append(backendNames, name)
+	}
+	sort.Strings(backendNames)
+
+	var configuredBackends []*storageBackend
+	for _, name := range backendNames {
+		if err = backends[name].loadConfig(); err != nil {
+			printWarning("load config of backend %s failed, error: %v", name, err)
+			continue
+		}
+		configuredBackends = append(configuredBackends, backends[name])
+	}
+
+	pods, err := client.NewCommonCallHandler[corev1.Pod](config.Client).QueryList(n.resource.namespace)
+	if err != nil {
+		return helper.LogErrorf("query pod resource failed, error: %v", err)
+	}
+
+	var shows []CheckShow
+	for _, name := range n.resource.names {
+		report := &checkReport{target: name}
+		checkNode(report, findNodePod(pods, name), configuredBackends)
+		shows = append(shows, report.rows...)
+	}
+	return printCheckShows(shows, n.resource.output)
+}
+
+func checkNode(report *checkReport, pod *corev1.Pod, backends []*storageBackend) {
+	if pod == nil {
+		report.fail("node pod", "no huawei-csi-node pod is found on the node",
+			"Check the daemonset huawei-csi-node, the node may be unschedulable or its taints are not tolerated")
+		return
+	}
+	if !isPodReady(*pod) {
+		report.fail("node pod", fmt.Sprintf("pod %s is %s and not ready", pod.Name, pod.Status.Phase),
+			"Check the events and the logs of the pod, e.g. the multipath type in the args may be incorrect")
+		return
+	}
+	report.pass("node pod", fmt.Sprintf("pod %s is ready", pod.Name))
+
+	container := getDriverContainer(*pod)
+	if container == nil {
+		report.fail("node pod", fmt.Sprintf("container %s is not found in pod %s", driverContainer, pod.Name),
+			"Reinstall huawei-csi by the helm chart or the yaml files of the same version")
+		return
+	}
+
+	protocols := getBackendProtocols(backends)
+	checkISCSIInitiator(report, *pod, slices.Contains(protocols, "iscsi"))
+	checkFCHBAs(report, *pod, slices.Contains(protocols, "fc") || slices.Contains(protocols, "fc-nvme"))
+	checkNVMeInitiator(report, *pod, slices.Contains(protocols, "roce") || slices.Contains(protocols, "fc-nvme"))
+	checkMultipath(report, *pod, *container, backends)
+	checkPortals(report, *pod, backends)
+}
+
+// checkISCSIInitiator used to check the iSCSI initiator which is reported by host.NewNodeHostInfo
+func checkISCSIInitiator(report *checkReport, pod corev1.Pod, required bool) {
+	const check = "iSCSI initiator"
+	if !required {
+		report.skip(check, "no backend uses the iscsi protocol")
+		return
+	}
+
+	out, err := execHostCommand(pod, proto.ISCSIInitiatorCmd)
+	initiator := strings.TrimSpace(string(out))
+	if err != nil || initiator == "" {
+		report.fail(check, fmt.Sprintf("no iSCSI initiator is found, error: %v", err),
+			"Install open-iscsi or iscsi-initiator-utils on the node and set the InitiatorName in "+
+				"/etc/iscsi/initiatorname.iscsi")
+		return
+	}
+	report.pass(check, initiator)
+}
+
+// checkFCHBAs used to check the FC HBAs whose port is online, which is the same as getAvailableFcHBAsInfo
+func checkFCHBAs(report *checkReport, pod corev1.Pod, required bool) {
+	const check = "FC HBAs"
+	if !required {
+		report.skip(check, "no backend uses the fc or fc-nvme protocol")
+		return
+	}
+
+	const hint = "Check the driver, the cables and the zoning of the FC HBAs, at least one port must be Online"
+	out, err := execHostCommand(pod, fibrechannel.FCHostsCmd)
+	if err != nil {
+		report.fail(check, fmt.Sprintf("no FC host is found, error: %v", err), hint)
+		return
+	}
+
+	var onlineHosts, offlineHosts []string
+	for _, host := range strings.Fields(string(out)) {
+		state, err := execHostCommand(pod, fmt.Sprintf(fibrechannel.FCPortStateCmd, host))
+		if err == nil && strings.TrimSpace(string(state)) == fibrechannel.FCPortOnline {
+			onlineHosts = append(onlineHosts, host)
+		} else {
+			offlineHosts = append(offlineHosts, host)
+		}
+	}
+
+	if len(onlineHosts) == 0 {
+		report.fail(check, fmt.Sprintf("no FC HBA is online, offline HBAs: [%s]", strings.Join(offlineHosts, ",")),
+			hint)
+		return
+	}
+	report.pass(check, fmt.Sprintf("online HBAs: [%s], offline HBAs: [%s]", strings.Join(onlineHosts, ","),
+		strings.Join(offlineHosts, ",")))
+}
+
+// checkNVMeInitiator used to check the NVMe initiator which is reported by host.NewNodeHostInfo
+func checkNVMeInitiator(report *checkReport, pod corev1.Pod, required bool) {
+	const check = "NVMe initiator"
+	if !required {
+		report.skip(check, "no backend uses the roce or fc-nvme protocol")
+		return
+	}
+
+	out, err := execHostCommand(pod, proto.RoCEInitiatorCmd)
+	initiator := strings.TrimSpace(string(out))
+	if err != nil || initiator == "" {
+		report.fail(check, fmt.Sprintf("no NVMe initiator is found, error: %v", err),
+			"Install nvme-cli on the node and generate /etc/nvme/hostnqn by nvme gen-hostnqn")
+		return
+	}
+	report.pass(check, initiator)
+}
+
+// checkMultipath used to check the multipath type in the args of the driver and the state of the multipath
+// services, which is the same as the driver checks at startup
+func checkMultipath(report *checkReport, pod corev1.Pod, container corev1.Container, backends []*storageBackend) {
+	volumeUseMultiPath, err := strconv.ParseBool(getContainerArg(container, "volume-use-multipath", "true"))
+	if err != nil {
+		volumeUseMultiPath = true
+	}
+	scsiMultipathType := getContainerArg(container, "scsi-multipath-type", connector.DMMultiPath)
+	nvmeMultipathType := getContainerArg(container, "nvme-multipath-type", connector.HWUltraPathNVMe)
+	multipathConfig := map[string]interface{}{
+		"SCSIMultipathType":  scsiMultipathType,
+		"NVMeMultipathType":  nvmeMultipathType,
+		"volumeUseMultiPath": volumeUseMultiPath,
+	}
+
+	const check = "multipath type"
+	const hint = "scsi-multipath-type must be one of DM-multipath, HW-UltraPath and HW-UltraPath-NVMe, " +
+		"and DM-multipath is the only one supported by fusionstorage-san. nvme-multipath-type must be " +
+		"HW-UltraPath-NVMe. Update them in the values.yaml of the helm chart"
+	detail := fmt.Sprintf("volume-use-multipath=%t, scsi-multipath-type=%s, nvme-multipath-type=%s",
+		volumeUseMultiPath, scsiMultipathType, nvmeMultipathType)
+	if !slices.Contains([]string{connector.DMMultiPath, connector.HWUltraPath, connector.HWUltraPathNVMe},
+		scsiMultipathType) || nvmeMultipathType != connector.HWUltraPathNVMe {
+		report.fail(check, detail, hint)
+		return
+	}
+
+	ctx := context.Background()
+	backendConfigs := helper.MapTo(backends, func(backend *storageBackend) map[string]interface{} {
+		return backend.config
+	})
+	requiredServices, err := utils.GetRequiredMultipath(ctx, multipathConfig, backendConfigs)
+	if err != nil {
+		report.fail(check, fmt.Sprintf("%s, error: %v", detail, err), hint)
+		return
+	}
+	report.pass(check, detail)
+
+	for _, service := range requiredServices {
+		state, err := queryServiceState(pod, service)
+		if err != nil || state != serviceActive {
+			report.fail("service "+service, fmt.Sprintf("state: %s, error: %v", state, err),
+				fmt.Sprintf("Start the service by systemctl enable --now %s, it's required by the multipath "+
+					"type of the protocols of the backends", service))
+			continue
+		}
+		report.pass("service "+service, state)
+	}
+
+	for _, service := range utils.GetForbiddenMultipath(ctx, multipathConfig, backendConfigs) {
+		state, err := queryServiceState(pod, service)
+		if err != nil || (state != serviceInactive && state != serviceNotInstalled) {
+			report.fail("service "+service, fmt.Sprintf("state: %s, error: %v", state, err),
+				fmt.Sprintf("Stop the service by systemctl disable --now %s, it must not run when "+
+					"volume-use-multipath is false", service))
+			continue
+		}
+		report.pass("service "+service, state)
+	}
+}
+
+// checkPortals used to ping the portals of the iscsi and roce backends from the node, which is the same as the
+// driver does before the login of the portals
+func checkPortals(report *checkReport, pod corev1.Pod, backends []*storageBackend) {
+	for _, backend := range backends {
+		parameters, _ := backend.config["parameters"].(map[string]interface{})
+		protocol, _ := parameters["protocol"].(string)
+		if protocol != "iscsi" && protocol != "roce" {
+			continue
+		}
+
+		portals, _ := parameters["portals"].([]interface{})
+		for _, portal := range portals {
+			check := fmt.Sprintf("portal %v of backend %s", portal, backend.name)
+			if _, err := execHostCommand(pod, fmt.Sprintf(connector.PingCommand, portal)); err != nil {
+				report.fail(check, fmt.Sprintf("portal is unreachable, error: %v", err),
+					"Check the network between the node and the logical port of the storage, the portal must "+
+						"be reachable from the host network")
+				continue
+			}
+			report.pass(check, "portal is reachable")
+		}
+	}
+}
+
+// queryServiceState used to get the state of the service on the host, e.g. active
+func queryServiceState(pod corev1.Pod, service string) (string, error) {
+	out, err := execHostCommand(pod, fmt.Sprintf(connutils.ServiceStatusCmd, service))
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "could not be found") {
+			return serviceNotInstalled, nil
+		}
+		return "", err
+	}
+
+	state, _, err := connutils.ParseServiceStates(string(out))
+	return state, err
+}
+
+// getBackendProtocols returns the protocols of the backends, e.g. iscsi
+func getBackendProtocols(backends []*storageBackend) []string {
+	var protocols []string
+	for _, backend := range backends {
+		parameters, _ := backend.config["parameters"].(map[string]interface{})
+		protocol, _ := parameters["protocol"].(string)
+		if protocol != "" && !slices.Contains(protocols, protocol) {
+			protocols = append(protocols, protocol)
+		}
+	}
+	return protocols
+}
+
+func findNodePod(pods []corev1.Pod, nodeName string) *corev1.Pod {
+	for i := range pods {
+		if pods[i].Labels["app"] == nodeApp && pods[i].Spec.NodeName == nodeName {
+			return &pods[i]
+		}
+	}
+	return nil
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
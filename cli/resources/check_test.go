@@ -0,0 +1,221 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resources
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/config"
+	xuanwuV1 "huawei-csi-driver/client/apis/xuanwu/v1"
+	"huawei-csi-driver/connector"
+	connutils "huawei-csi-driver/connector/utils"
+	"huawei-csi-driver/csi/backend/plugin"
+	"huawei-csi-driver/proto"
+	"huawei-csi-driver/utils/log"
+)
+
+const (
+	logName = "resourcesTest.log"
+)
+
+func TestMain(m *testing.M) {
+	log.MockInitLogging(logName)
+	defer log.MockStopLogging(logName)
+
+	m.Run()
+}
+
+// commandResult is the stubbed output of a host command
+type commandResult struct {
+	out string
+	err error
+}
+
+// fakeCheckClient returns the stubbed output of the host commands executed in the node pod
+type fakeCheckClient struct {
+	client.KubernetesClient
+	commands map[string]commandResult
+}
+
+func (f *fakeCheckClient) ExecCmdInPod(_, _, _ string, command ...string) ([]byte, error) {
+	result, ok := f.commands[command[len(command)-1]]
+	if !ok {
+		return nil, fmt.Errorf("command %s is not stubbed", command[len(command)-1])
+	}
+	return []byte(result.out), result.err
+}
+
+func newReadyNodePod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "huawei-csi-node-abcde", Namespace: "huawei-csi"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: driverContainer}}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, Conditions: []corev1.PodCondition{
+			{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+		}},
+	}
+}
+
+func newISCSIBackend() *storageBackend {
+	return &storageBackend{name: "backend1", config: map[string]interface{}{
+		"storage": oceanstorSan,
+		"parameters": map[string]interface{}{
+			"protocol": "iscsi",
+			"portals":  []interface{}{"192.168.1.10"},
+		},
+	}}
+}
+
+func getCheckResults(report *checkReport) map[string]string {
+	results := map[string]string{}
+	for _, row := range report.rows {
+		results[row.Check] = row.Result
+	}
+	return results
+}
+
+func TestCheckNode(t *testing.T) {
+	statusCmd := fmt.Sprintf(connutils.ServiceStatusCmd, "multipathd.service")
+	pingCmd := fmt.Sprintf(connector.PingCommand, "192.168.1.10")
+	notReadyPod := newReadyNodePod()
+	notReadyPod.Status.Conditions[0].Status = corev1.ConditionFalse
+	invalidMultipathPod := newReadyNodePod()
+	invalidMultipathPod.Spec.Containers[0].Args = []string{"--scsi-multipath-type=unknown"}
+
+	cases := []struct {
+		name        string
+		pod         *corev1.Pod
+		commands    map[string]commandResult
+		wantResults map[string]string
+	}{
+		{"NoPod", nil, nil, map[string]string{"node pod": checkFail}},
+		{"PodNotReady", notReadyPod, nil, map[string]string{"node pod": checkFail}},
+		{"InvalidMultipathType", invalidMultipathPod, map[string]commandResult{
+			proto.ISCSIInitiatorCmd: {out: "iqn.1994-05.com.redhat:node1"},
+			pingCmd:                 {out: "3 packets transmitted, 3 received"},
+		}, map[string]string{
+			"node pod":        checkPass,
+			"iSCSI initiator": checkPass,
+			"FC HBAs":         checkSkip,
+			"NVMe initiator":  checkSkip,
+			"multipath type":  checkFail,
+			"portal 192.168.1.10 of backend backend1": checkPass,
+		}},
+		{"Pass", newReadyNodePod(), map[string]commandResult{
+			proto.ISCSIInitiatorCmd: {out: "iqn.1994-05.com.redhat:node1"},
+			statusCmd:               {out: "   Active: active (running) since Mon 2023-06-05 10:00:00 CST"},
+			pingCmd:                 {out: "3 packets transmitted, 3 received"},
+		}, map[string]string{
+			"node pod":                   checkPass,
+			"iSCSI initiator":            checkPass,
+			"FC HBAs":                    checkSkip,
+			"NVMe initiator":             checkSkip,
+			"multipath type":             checkPass,
+			"service multipathd.service": checkPass,
+			"portal 192.168.1.10 of backend backend1": checkPass,
+		}},
+		{"Fail", newReadyNodePod(), map[string]commandResult{
+			proto.ISCSIInitiatorCmd: {out: ""},
+			statusCmd:               {out: "   Active: inactive (dead)"},
+			pingCmd:                 {err: errors.New("exit status 1")},
+		}, map[string]string{
+			"node pod":                   checkPass,
+			"iSCSI initiator":            checkFail,
+			"FC HBAs":                    checkSkip,
+			"NVMe initiator":             checkSkip,
+			"multipath type":             checkPass,
+			"service multipathd.service": checkFail,
+			"portal 192.168.1.10 of backend backend1": checkFail,
+		}},
+	}
+
+	originClient := config.Client
+	defer func() { config.Client = originClient }()
+	for _, c := range cases {
+		config.Client = &fakeCheckClient{commands: c.commands}
+		report := &checkReport{target: "node1"}
+		checkNode(report, c.pod, []*storageBackend{newISCSIBackend()})
+		if results := getCheckResults(report); !reflect.DeepEqual(results, c.wantResults) {
+			t.Errorf("Test case %s failed, results: %v, want: %v", c.name, results, c.wantResults)
+		}
+	}
+}
+
+func TestCheckBackend(t *testing.T) {
+	boundClaim := xuanwuV1.StorageBackendClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "backend1", Namespace: "huawei-csi"},
+		Spec:       xuanwuV1.StorageBackendClaimSpec{ConfigMapMeta: "huawei-csi/backend1"},
+		Status:     &xuanwuV1.StorageBackendClaimStatus{Phase: xuanwuV1.BackendBound, BoundContentName: "content1"},
+	}
+	unboundClaim := boundClaim
+	unboundClaim.Status = &xuanwuV1.StorageBackendClaimStatus{Phase: xuanwuV1.BackendPending}
+
+	cases := []struct {
+		name        string
+		claim       xuanwuV1.StorageBackendClaim
+		storage     string
+		validateErr error
+		wantResults map[string]string
+	}{
+		{"NotFound", xuanwuV1.StorageBackendClaim{}, oceanstorSan, nil,
+			map[string]string{"claim": checkFail}},
+		{"Pass", boundClaim, oceanstorSan, nil,
+			map[string]string{"claim": checkPass, "content": checkPass, "login": checkPass}},
+		{"LoginFailed", boundClaim, oceanstorSan, errors.New("the user name or password is incorrect"),
+			map[string]string{"claim": checkPass, "content": checkPass, "login": checkFail}},
+		{"NotBound", unboundClaim, oceanstorSan, nil,
+			map[string]string{"claim": checkFail, "login": checkPass}},
+		{"LoginNotSupported", boundClaim, "fusionstorage-san", nil,
+			map[string]string{"claim": checkPass, "content": checkPass, "login": checkSkip}},
+	}
+
+	for _, c := range cases {
+		patches := gomonkey.ApplyFunc(checkBackendContent, func(report *checkReport, contentName string) {
+			report.pass("content", fmt.Sprintf("storageBackendContent %s is online", contentName))
+		})
+		patches.ApplyFunc(loadBackendConfig, func(string) (map[string]interface{}, error) {
+			return map[string]interface{}{"storage": c.storage, "urls": []interface{}{"https://127.0.0.1:8088"}},
+				nil
+		})
+		patches.ApplyFunc(getBackendAccount, func(context.Context, string) (string, string, error) {
+			return "admin", "password", nil
+		})
+		patches.ApplyFunc(newStorageTLSConfig, func(map[string]interface{}) (*tls.Config, error) {
+			return &tls.Config{InsecureSkipVerify: true}, nil
+		})
+		patches.ApplyMethod(reflect.TypeOf(&plugin.OceanstorSanPlugin{}), "Validate",
+			func(*plugin.OceanstorSanPlugin, context.Context, map[string]interface{}) error {
+				return c.validateErr
+			})
+
+		report := &checkReport{target: "backend1"}
+		checkBackend(report, c.claim)
+		if results := getCheckResults(report); !reflect.DeepEqual(results, c.wantResults) {
+			t.Errorf("Test case %s failed, results: %v, want: %v", c.name, results, c.wantResults)
+		}
+		patches.Reset()
+	}
+}
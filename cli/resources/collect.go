@@ -0,0 +1,487 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resources
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8string "k8s.io/utils/strings"
+	"k8s.io/utils/strings/slices"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/config"
+	"huawei-csi-driver/cli/helper"
+	"huawei-csi-driver/utils"
+	"huawei-csi-driver/utils/version"
+)
+
+const (
+	controllerApp     = "huawei-csi-controller"
+	nodeApp           = "huawei-csi-node"
+	driverContainer   = "huawei-csi-driver"
+	defaultLogFileDir = "/var/log/huawei"
+
+	maskedValue      = "***"
+	bundleFilePerm   = 0600
+	bundleTimeFormat = "20060102150405"
+)
+
+// hostCommand is a command executed on the host of the node pod, the output is written to the file
+type hostCommand struct {
+	file    string
+	command string
+}
+
+// hostCommands are the commands which show the multipath and the connections of the host
+var hostCommands = []hostCommand{
+	{file: "multipath.txt", command: "multipath -ll"},
+	{file: "iscsiadm-session.txt", command: "iscsiadm -m session"},
+	{file: "iscsiadm-node.txt", command: "iscsiadm -m node"},
+	{file: "nvme-list.txt", command: "nvme list"},
+}
+
+// hostCommandPrefix enters the namespaces of the host in the node pod, which is the same as the driver does
+var hostCommandPrefix = []string{"nsenter", "-i/proc/1/ns/ipc", "-m/proc/1/ns/mnt", "-n/proc/1/ns/net",
+	"-u/proc/1/ns/uts", "/bin/sh", "-c"}
+
+type Collector struct {
+	// resource of request
+	resource *Resource
+
+	bundleName string
+	writer     *tar.Writer
+	writeErr   error
+	warnings   []string
+}
+
+// NewCollector initialize a Collector instance
+func NewCollector(resource *Resource) *Collector {
+	return &Collector{resource: resource}
+}
+
+// Collect used to collect the backends, the volumes, the logs of the driver pods and the connections of the nodes
+// into a tar.gz bundle. The objects which can't be collected are recorded in the warnings.txt of the bundle.
+func (c *Collector) Collect() error {
+	c.bundleName = "huawei-csi-collect-" + time.Now().Format(bundleTimeFormat)
+	bundlePath := filepath.Join(c.resource.outputDir, c.bundleName+".tar.gz")
+	file, err := os.OpenFile(bundlePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, bundleFilePerm)
+	if err != nil {
+		return helper.LogErrorf("create bundle file failed, error: %v", err)
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	c.writer = tar.NewWriter(gzipWriter)
+
+	c.collectBackends()
+	c.collectVolumes()
+	c.collectPods()
+	c.writeFile("warnings.txt", []byte(strings.Join(c.warnings, "\n")))
+
+	if c.writeErr != nil {
+		return helper.LogErrorf("write bundle file failed, error: %v", c.writeErr)
+	}
+	if err = c.writer.Close(); err != nil {
+		return helper.LogErrorf("close tar writer failed, error: %v", err)
+	}
+	if err = gzipWriter.Close(); err != nil {
+		return helper.LogErrorf("close gzip writer failed, error: %v", err)
+	}
+
+	fmt.Printf("Collected to %s\n", bundlePath)
+	return nil
+}
+
+// collectBackends used to collect the storageBackendClaims and storageBackendContents of the provisioner with the
+// configmaps and the secrets of them, the sensitive values are masked
+func (c *Collector) collectBackends() {
+	claims := filterObjectsByProvider(c.queryObjects(client.Storagebackendclaim, c.resource.namespace))
+	contents := filterObjectsByProvider(c.queryObjects(client.StoragebackendclaimContent, ""))
+
+	var configmaps, secrets []map[string]interface{}
+	for _, claim := range claims {
+		spec, _ := claim["spec"].(map[string]interface{})
+		if configmapMeta, ok := spec["configmapMeta"].(string); ok && configmapMeta != "" {
+			namespace, name := k8string.SplitQualifiedName(configmapMeta)
+			configmaps = append(configmaps, c.queryObjects(client.ConfigMap, namespace, name)...)
+		}
+		if secretMeta, ok := spec["secretMeta"].(string); ok && secretMeta != "" {
+			namespace, name := k8string.SplitQualifiedName(secretMeta)
+			secrets = append(secrets, c.queryObjects(client.Secret, namespace, name)...)
+		}
+	}
+
+	for _, secret := range secrets {
+		maskSecretData(secret)
+	}
+
+	writeObjects(c, "backends/storagebackendclaims.yaml", maskObjects(claims))
+	writeObjects(c, "backends/storagebackendcontents.yaml", maskObjects(contents))
+	writeObjects(c, "backends/configmaps.yaml", maskObjects(configmaps))
+	writeObjects(c, "backends/secrets.yaml", maskObjects(secrets))
+}
+
+// collectVolumes used to collect the persistentVolumes of the provisioner with the claims and the volumeAttachments
+// of them. The volumes are filtered by the namespace of the claims and the nodes attached to if they are specified.
+func (c *Collector) collectVolumes() {
+	volumes, err := fetchVolumesOfProvisioner()
+	if err != nil {
+		c.warn("query pv resource failed, error: %v", err)
+		return
+	}
+	if c.resource.volumeNamespace != "" {
+		volumes = filterVolumesByClaimNamespace(volumes, c.resource.volumeNamespace)
+	}
+
+	attachments, err := fetchVolumeAttachments()
+	if err != nil {
+		c.warn("query volumeattachment resource failed, error: %v", err)
+	}
+	attachments = filterAttachmentsByNodes(attachments, c.resource.names)
+	if len(c.resource.names) != 0 {
+		volumes = filterVolumesByAttachments(volumes, attachments)
+	}
+	attachments = filterAttachmentsByVolumes(attachments, volumes)
+
+	claimClient := client.NewCommonCallHandler[corev1.PersistentVolumeClaim](config.Client)
+	var claims []corev1.PersistentVolumeClaim
+	for _, pv := range volumes {
+		if pv.Spec.ClaimRef == nil {
+			continue
+		}
+		claim, err := claimClient.QueryByName(pv.Spec.ClaimRef.Namespace, pv.Spec.ClaimRef.Name)
+		if err != nil {
+			c.warn("query pvc %s/%s failed, error: %v", pv.Spec.ClaimRef.Namespace, pv.Spec.ClaimRef.Name, err)
+			continue
+		}
+		if claim.Name != "" {
+			claims = append(claims, claim)
+		}
+	}
+
+	writeObjects(c, "volumes/persistentvolumes.yaml", volumes)
+	writeObjects(c, "volumes/persistentvolumeclaims.yaml", claims)
+	writeObjects(c, "volumes/volumeattachments.yaml", attachments)
+}
+
+// collectPods used to collect the logs of all containers of the driver pods, the log files of the driver and the
+// output of the host commands on the nodes, and the versions of the driver
+func (c *Collector) collectPods() {
+	versions := []string{fmt.Sprintf("oceanctl: %s", config.CliVersion)}
+	pods, err := client.NewCommonCallHandler[corev1.Pod](config.Client).QueryList(c.resource.namespace)
+	if err != nil {
+		c.warn("query pod resource failed, error: %v", err)
+	}
+
+	collectedDirs := make(map[string]bool)
+	for _, pod := range filterDriverPods(pods, c.resource.names) {
+		for _, container := range pod.Spec.Containers {
+			logs, err := config.Client.GetPodLogs(pod.Name, pod.Namespace, container.Name)
+			if err != nil {
+				c.warn("get logs of container %s of pod %s failed, error: %v", container.Name, pod.Name, err)
+				continue
+			}
+			c.writeFile(path.Join("pods", pod.Name, container.Name+".log"), logs)
+		}
+
+		container := getDriverContainer(pod)
+		if container == nil {
+			c.warn("container %s is not found in pod %s", driverContainer, pod.Name)
+			continue
+		}
+
+		out, err := config.Client.ExecCmdInPod(pod.Name, pod.Namespace, driverContainer, "cat",
+			version.DefaultVersionFile)
+		if err != nil {
+			c.warn("get version of pod %s failed, error: %v", pod.Name, err)
+		} else {
+			versions = append(versions, fmt.Sprintf("%s: %s", pod.Name, strings.TrimSpace(string(out))))
+		}
+
+		// the log directory of the host is mounted by both the controller and the node pods on the same node
+		logFileDir := getLogFileDir(*container)
+		if !collectedDirs[pod.Spec.NodeName+logFileDir] {
+			collectedDirs[pod.Spec.NodeName+logFileDir] = true
+			c.collectLogFiles(pod, logFileDir)
+		}
+
+		if pod.Labels["app"] == nodeApp {
+			c.collectHostCommands(pod)
+		}
+	}
+
+	c.writeFile("version.txt", []byte(strings.Join(versions, "\n")+"\n"))
+}
+
+// collectLogFiles used to collect the log files of the driver in the log directory, the files are written to the
+// directory of the node in the bundle
+func (c *Collector) collectLogFiles(pod corev1.Pod, logFileDir string) {
+	out, err := config.Client.ExecCmdInPod(pod.Name, pod.Namespace, driverContainer, "find", logFileDir,
+		"-type", "f")
+	if err != nil {
+		c.warn("list log files in %s of pod %s failed, error: %v", logFileDir, pod.Name, err)
+		return
+	}
+
+	for _, logFile := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if logFile == "" {
+			continue
+		}
+		data, err := config.Client.ExecCmdInPod(pod.Name, pod.Namespace, driverContainer, "cat", logFile)
+		if err != nil {
+			c.warn("get log file %s of pod %s failed, error: %v", logFile, pod.Name, err)
+			continue
+		}
+		c.writeFile(path.Join("nodes", pod.Spec.NodeName, logFile), data)
+	}
+}
+
+// collectHostCommands used to collect the output of the host commands, the error is written to the file instead
+// if the command failed, e.g. nvme is not installed on the host
+func (c *Collector) collectHostCommands(pod corev1.Pod) {
+	for _, hostCmd := range hostCommands {
+		out, err := execHostCommand(pod, hostCmd.command)
+		if err != nil {
+			out = []byte(fmt.Sprintf("execute %s failed, error: %v\n", hostCmd.command, err))
+		}
+		c.writeFile(path.Join("nodes", pod.Spec.NodeName, "commands", hostCmd.file), out)
+	}
+}
+
+// queryObjects used to query the objects as the unstructured maps, all objects in the namespace are queried if no
+// name is specified
+func (c *Collector) queryObjects(resourceType client.ResourceType, namespace string,
+	names ...string) []map[string]interface{} {
+	out, err := config.Client.GetResource(names, namespace, "json", resourceType)
+	if err != nil {
+		c.warn("query %s resource failed, error: %v", resourceType, err)
+		return nil
+	}
+	if len(out) == 0 {
+		return nil
+	}
+
+	var object map[string]interface{}
+	if err = json.Unmarshal(out, &object); err != nil {
+		c.warn("unmarshal %s resource failed, error: %v", resourceType, err)
+		return nil
+	}
+	if object["kind"] != "List" {
+		return []map[string]interface{}{object}
+	}
+
+	items, _ := object["items"].([]interface{})
+	var result []map[string]interface{}
+	for _, item := range items {
+		if itemObject, ok := item.(map[string]interface{}); ok {
+			result = append(result, itemObject)
+		}
+	}
+	return result
+}
+
+func (c *Collector) writeFile(name string, data []byte) {
+	if c.writeErr != nil {
+		return
+	}
+
+	header := &tar.Header{
+		Name:     path.Join(c.bundleName, name),
+		Mode:     bundleFilePerm,
+		Size:     int64(len(data)),
+		ModTime:  time.Now(),
+		Typeflag: tar.TypeReg,
+	}
+	if c.writeErr = c.writer.WriteHeader(header); c.writeErr != nil {
+		return
+	}
+	_, c.writeErr = c.writer.Write(data)
+}
+
+func (c *Collector) warn(format string, args ...interface{}) {
+	printWarning(format, args...)
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
+}
+
+// writeObjects used to write the objects to the bundle as a yaml list
+func writeObjects[T any](c *Collector, name string, objects []T) {
+	data, err := helper.StructToYAML(map[string]interface{}{"apiVersion": "v1", "kind": "List", "items": objects})
+	if err != nil {
+		c.warn("convert %s to yaml failed, error: %v", name, err)
+		return
+	}
+	c.writeFile(name, data)
+}
+
+func filterObjectsByProvider(objects []map[string]interface{}) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, object := range objects {
+		spec, _ := object["spec"].(map[string]interface{})
+		if spec["provider"] == getProvisioner() {
+			result = append(result, object)
+		}
+	}
+	return result
+}
+
+func filterVolumesByAttachments(volumes []corev1.PersistentVolume,
+	attachments []storagev1.VolumeAttachment) []corev1.PersistentVolume {
+	attached := make(map[string]bool)
+	for _, attachment := range attachments {
+		attached[*attachment.Spec.Source.PersistentVolumeName] = true
+	}
+
+	var result []corev1.PersistentVolume
+	for _, pv := range volumes {
+		if attached[pv.Name] {
+			result = append(result, pv)
+		}
+	}
+	return result
+}
+
+func filterAttachmentsByVolumes(attachments []storagev1.VolumeAttachment,
+	volumes []corev1.PersistentVolume) []storagev1.VolumeAttachment {
+	volumeSet := make(map[string]bool)
+	for _, pv := range volumes {
+		volumeSet[pv.Name] = true
+	}
+
+	var result []storagev1.VolumeAttachment
+	for _, attachment := range attachments {
+		if volumeSet[*attachment.Spec.Source.PersistentVolumeName] {
+			result = append(result, attachment)
+		}
+	}
+	return result
+}
+
+// filterDriverPods used to get the controller pods and the node pods on the nodes, all node pods are returned if
+// no node is specified
+func filterDriverPods(pods []corev1.Pod, nodes []string) []corev1.Pod {
+	var result []corev1.Pod
+	for _, pod := range pods {
+		switch pod.Labels["app"] {
+		case controllerApp:
+			result = append(result, pod)
+		case nodeApp:
+			if len(nodes) == 0 || slices.Contains(nodes, pod.Spec.NodeName) {
+				result = append(result, pod)
+			}
+		}
+	}
+	return result
+}
+
+func getDriverContainer(pod corev1.Pod) *corev1.Container {
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == driverContainer {
+			return &pod.Spec.Containers[i]
+		}
+	}
+	return nil
+}
+
+// getLogFileDir returns the log directory in the args of the driver container, or the default one if not set
+func getLogFileDir(container corev1.Container) string {
+	return getContainerArg(container, "log-file-dir", defaultLogFileDir)
+}
+
+// getContainerArg returns the value of the flag in the args of the container, or the default value if not set
+func getContainerArg(container corev1.Container, name, defaultValue string) string {
+	prefix := "--" + name + "="
+	for _, arg := range container.Args {
+		if strings.HasPrefix(arg, prefix) {
+			return strings.TrimPrefix(arg, prefix)
+		}
+	}
+	return defaultValue
+}
+
+// execHostCommand used to execute the shell command on the host of the node pod
+func execHostCommand(pod corev1.Pod, command string) ([]byte, error) {
+	args := append(append([]string{}, hostCommandPrefix...), command)
+	return config.Client.ExecCmdInPod(pod.Name, pod.Namespace, driverContainer, args...)
+}
+
+// maskSecretData used to mask all values of the secret, since any of them may be sensitive
+func maskSecretData(secret map[string]interface{}) {
+	for _, key := range []string{"data", "stringData"} {
+		data, ok := secret[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for dataKey := range data {
+			data[dataKey] = maskedValue
+		}
+	}
+}
+
+func maskObjects(objects []map[string]interface{}) []interface{} {
+	var result []interface{}
+	for _, object := range objects {
+		result = append(result, maskValue("", object))
+	}
+	return result
+}
+
+// maskValue used to mask the values of the user and password keys. The other strings are masked by
+// utils.MaskSensitiveInfo, and the ones in JSON format such as the csi.json of the configmap and the
+// last-applied-configuration annotation are masked by the keys as well.
+func maskValue(key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for childKey, childValue := range v {
+			v[childKey] = maskValue(childKey, childValue)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = maskValue(key, v[i])
+		}
+		return v
+	case string:
+		if isSensitiveKey(key) {
+			return maskedValue
+		}
+
+		var object map[string]interface{}
+		if err := json.Unmarshal([]byte(v), &object); err == nil {
+			if masked, err := json.Marshal(maskValue(key, object)); err == nil {
+				return string(masked)
+			}
+		}
+		return utils.MaskSensitiveInfo(v)
+	default:
+		return v
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	lowerKey := strings.ToLower(key)
+	return lowerKey == "user" || strings.Contains(lowerKey, "password")
+}
@@ -0,0 +1,124 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resources
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/config"
+)
+
+const (
+	testUser     = "backend-admin"
+	testPassword = "Backend@Pass123"
+)
+
+// fakeCollectClient returns the backend objects of the resource type, the other methods are not used
+type fakeCollectClient struct {
+	client.KubernetesClient
+	objects map[client.ResourceType]interface{}
+}
+
+func (f *fakeCollectClient) GetResource(_ []string, _, _ string, resourceType client.ResourceType) (
+	[]byte, error) {
+	object, ok := f.objects[resourceType]
+	if !ok {
+		return nil, nil
+	}
+	return json.Marshal(object)
+}
+
+func newFakeCollectClient() *fakeCollectClient {
+	csiJSON := `{"backends":{"name":"backend1","storage":"oceanstor-san","user":"` + testUser +
+		`","password":"` + testPassword + `"}}`
+	encode := func(value string) string { return base64.StdEncoding.EncodeToString([]byte(value)) }
+	lastApplied, _ := json.Marshal(map[string]interface{}{"data": map[string]string{"csi.json": csiJSON}})
+
+	return &fakeCollectClient{objects: map[client.ResourceType]interface{}{
+		client.Storagebackendclaim: map[string]interface{}{"kind": "List", "items": []interface{}{
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "backend1", "namespace": "huawei-csi"},
+				"spec": map[string]interface{}{"provider": getProvisioner(),
+					"configmapMeta": "huawei-csi/backend1", "secretMeta": "huawei-csi/backend1"},
+			},
+		}},
+		client.ConfigMap: map[string]interface{}{
+			"kind": "ConfigMap",
+			"metadata": map[string]interface{}{"name": "backend1", "annotations": map[string]interface{}{
+				"kubectl.kubernetes.io/last-applied-configuration": string(lastApplied),
+			}},
+			"data": map[string]interface{}{"csi.json": csiJSON},
+		},
+		client.Secret: map[string]interface{}{
+			"kind":       "Secret",
+			"metadata":   map[string]interface{}{"name": "backend1"},
+			"data":       map[string]interface{}{"user": encode(testUser), "password": encode(testPassword)},
+			"stringData": map[string]interface{}{"password": testPassword},
+		},
+	}}
+}
+
+func TestCollectBackendsMasksCredentials(t *testing.T) {
+	originClient := config.Client
+	config.Client = newFakeCollectClient()
+	defer func() { config.Client = originClient }()
+
+	var bundle bytes.Buffer
+	collector := &Collector{resource: &Resource{ResourceBuilder: &ResourceBuilder{}}, bundleName: "bundle",
+		writer: tar.NewWriter(&bundle)}
+	collector.collectBackends()
+	if err := collector.writer.Close(); err != nil || collector.writeErr != nil {
+		t.Fatalf("TestCollectBackendsMasksCredentials failed, close error: %v, write error: %v",
+			err, collector.writeErr)
+	}
+
+	files := map[string]string{}
+	reader := tar.NewReader(&bundle)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("TestCollectBackendsMasksCredentials failed, read bundle error: %v", err)
+		}
+		data, _ := io.ReadAll(reader)
+		files[header.Name] = string(data)
+	}
+
+	sensitive := []string{testUser, testPassword, base64.StdEncoding.EncodeToString([]byte(testUser)),
+		base64.StdEncoding.EncodeToString([]byte(testPassword))}
+	for _, name := range []string{"bundle/backends/configmaps.yaml", "bundle/backends/secrets.yaml"} {
+		content, exist := files[name]
+		if !exist || !strings.Contains(content, maskedValue) {
+			t.Errorf("TestCollectBackendsMasksCredentials failed, %s is not collected or masked: %s", name, content)
+			continue
+		}
+		for _, value := range sensitive {
+			if strings.Contains(content, value) {
+				t.Errorf("TestCollectBackendsMasksCredentials failed, %s contains %s: %s", name, value, content)
+			}
+		}
+	}
+}
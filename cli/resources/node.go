@@ -0,0 +1,194 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resources
+
+import (
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/config"
+	"huawei-csi-driver/cli/helper"
+)
+
+type Node struct {
+	// resource of request
+	resource *Resource
+}
+
+// NodeShow the content echoed by executing the oceanctl get node, each row is a volume attached to the node
+type NodeShow struct {
+	Node     string `show:"NODE"`
+	Volume   string `show:"VOLUME"`
+	Claim    string `show:"CLAIM"`
+	Attached string `show:"ATTACHED"`
+	Backend  string `show:"BACKEND"`
+	Storage  string `show:"STORAGEVOLUME"`
+}
+
+// NodeShowWide the content echoed by executing the oceanctl get node -o wide|json|yaml, the WWN, Pool and Hosts
+// are queried from the storage
+type NodeShowWide struct {
+	Node        string `show:"NODE" json:"node"`
+	Volume      string `show:"VOLUME" json:"volume"`
+	Claim       string `show:"CLAIM" json:"claim"`
+	Attached    string `show:"ATTACHED" json:"attached"`
+	Backend     string `show:"BACKEND" json:"backend"`
+	Storage     string `show:"STORAGEVOLUME" json:"storageVolume"`
+	StorageType string `show:"STORAGETYPE" json:"storageType"`
+	WWN         string `show:"WWN" json:"wwn"`
+	Pool        string `show:"POOL" json:"pool"`
+	Hosts       string `show:"HOSTS" json:"hosts"`
+}
+
+// NewNode initialize a Node instance
+func NewNode(resource *Resource) *Node {
+	return &Node{resource: resource}
+}
+
+// Get query the volumes of the driver attached to the nodes by the volumeAttachments
+func (n *Node) Get() error {
+	notFoundNodes, err := getNotFoundNodes(n.resource.names)
+	if err != nil {
+		return helper.LogErrorf("query node resource failed, error: %v", err)
+	}
+
+	attachments, err := fetchVolumeAttachments()
+	if err != nil {
+		return helper.LogErrorf("query volumeattachment resource failed, error: %v", err)
+	}
+	attachments = filterAttachmentsByNodes(attachments, n.resource.names)
+	if len(attachments) == 0 {
+		helper.PrintNoResource("attached volume")
+		helper.PrintNotFoundResource("node", notFoundNodes...)
+		return nil
+	}
+
+	wideShows, err := fetchNodeShows(attachments, n.resource.output != "")
+	if err != nil {
+		return helper.LogErrorf("fetch node shows failed, error: %v", err)
+	}
+
+	if n.resource.output != "" {
+		helper.GetPrintFunc[NodeShowWide](n.resource.output)(wideShows)
+		helper.PrintNotFoundResource("node", notFoundNodes...)
+		return nil
+	}
+
+	helper.PrintWithTable(helper.MapTo(wideShows, func(wide NodeShowWide) NodeShow {
+		return NodeShow{
+			Node:     wide.Node,
+			Volume:   wide.Volume,
+			Claim:    wide.Claim,
+			Attached: wide.Attached,
+			Backend:  wide.Backend,
+			Storage:  wide.Storage,
+		}
+	}))
+	helper.PrintNotFoundResource("node", notFoundNodes...)
+	return nil
+}
+
+func getNotFoundNodes(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	nodeClient := client.NewCommonCallHandler[corev1.Node](config.Client)
+	nodes, err := nodeClient.QueryList("", names...)
+	if err != nil {
+		return nil, err
+	}
+
+	return getNotFoundNames(helper.MapTo(nodes, func(node corev1.Node) string {
+		return node.Name
+	}), names), nil
+}
+
+func filterAttachmentsByNodes(attachments []storagev1.VolumeAttachment,
+	nodes []string) []storagev1.VolumeAttachment {
+	if len(nodes) == 0 {
+		return attachments
+	}
+
+	var result []storagev1.VolumeAttachment
+	for _, attachment := range attachments {
+		for _, node := range nodes {
+			if attachment.Spec.NodeName == node {
+				result = append(result, attachment)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// fetchNodeShows used to join the volumeAttachments with the volumes, the rows are sorted by the node
+func fetchNodeShows(attachments []storagev1.VolumeAttachment, queryStorage bool) ([]NodeShowWide, error) {
+	// the volume of ReadWriteMany may be attached to several nodes
+	var volumeNames []string
+	volumeSet := make(map[string]bool)
+	for _, attachment := range attachments {
+		volumeName := *attachment.Spec.Source.PersistentVolumeName
+		if !volumeSet[volumeName] {
+			volumeSet[volumeName] = true
+			volumeNames = append(volumeNames, volumeName)
+		}
+	}
+
+	volumes, err := fetchVolumesOfProvisioner(volumeNames...)
+	if err != nil {
+		return nil, err
+	}
+
+	volumeShows, err := fetchVolumeShows(volumes, queryStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	volumeShowMapping := make(map[string]VolumeShowWide)
+	for _, volumeShow := range volumeShows {
+		volumeShowMapping[volumeShow.Name] = volumeShow
+	}
+
+	var result []NodeShowWide
+	for _, attachment := range attachments {
+		item := NodeShowWide{
+			Node:     attachment.Spec.NodeName,
+			Volume:   *attachment.Spec.Source.PersistentVolumeName,
+			Attached: strconv.FormatBool(attachment.Status.Attached),
+		}
+		if volumeShow, exist := volumeShowMapping[item.Volume]; exist {
+			item.Claim = volumeShow.Claim
+			item.Backend = volumeShow.Backend
+			item.Storage = volumeShow.Volume
+			item.StorageType = volumeShow.StorageType
+			item.WWN = volumeShow.WWN
+			item.Pool = volumeShow.Pool
+			item.Hosts = volumeShow.Hosts
+		}
+		result = append(result, item)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Node < result[j].Node
+	})
+	return result, nil
+}
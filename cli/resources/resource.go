@@ -50,6 +50,11 @@ type ResourceBuilder struct {
 	output string
 
 	notValidateName bool
+
+	volumeNamespace string
+	outputDir       string
+
+	dryRun string
 }
 
 // NewResourceBuilder initialize a ResourceBuilder instance
@@ -154,3 +159,24 @@ func (b *ResourceBuilder) FileType(fileType string) *ResourceBuilder {
 	b.fileType = fileType
 	return b
 }
+
+// VolumeNamespace instructs the builder to request the namespace of the claims of the volumes.
+func (b *ResourceBuilder) VolumeNamespace(namespace string) *ResourceBuilder {
+	b.volumeNamespace = namespace
+	return b
+}
+
+// OutputDir instructs the builder to request the directory which the output files are written to.
+func (b *ResourceBuilder) OutputDir(outputDir string) *ResourceBuilder {
+	b.outputDir = outputDir
+	return b
+}
+
+// DryRun instructs the builder to request the dry-run mode, default is none.
+func (b *ResourceBuilder) DryRun(dryRun string) *ResourceBuilder {
+	if dryRun == "" {
+		dryRun = config.DryRunNone
+	}
+	b.dryRun = dryRun
+	return b
+}
@@ -0,0 +1,238 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+	k8string "k8s.io/utils/strings"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/config"
+	"huawei-csi-driver/cli/helper"
+	xuanwuV1 "huawei-csi-driver/client/apis/xuanwu/v1"
+	"huawei-csi-driver/utils/pwd"
+)
+
+type Secret struct {
+	// resource of request
+	resource *Resource
+}
+
+// NewSecret initialize a Secret instance
+func NewSecret(resource *Resource) *Secret {
+	return &Secret{resource: resource}
+}
+
+// ReEncrypt decrypt the values of the secret with the old key, and encrypt them with the new key or the KMS plugin
+func (s *Secret) ReEncrypt() error {
+	secretClient := client.NewCommonCallHandler[corev1.Secret](config.Client)
+	secret, err := secretClient.QueryByName(s.resource.namespace, s.resource.names[0])
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(secret, corev1.Secret{}) {
+		helper.PrintNotFoundSecret(s.resource.names[0])
+		return nil
+	}
+
+	// the driver reads the password of the backend from the secret as plaintext, so the secret of a backend
+	// must not be re-encrypted, otherwise the driver fails to login the storage
+	if err = checkSecretNotReferenced(secret); err != nil {
+		return helper.LogErrorf("re-encrypt secret failed, error: %v", err)
+	}
+
+	if err = reEncryptSecretData(secret.Data); err != nil {
+		return helper.LogErrorf("re-encrypt secret failed, error: %v", err)
+	}
+
+	if err = secretClient.Update(secret); err != nil {
+		return helper.LogErrorf("update secret failed, error: %v", err)
+	}
+
+	helper.PrintOperateResult("secret", "re-encrypted", secret.Name)
+	return nil
+}
+
+func reEncryptSecretData(data map[string][]byte) error {
+	ctx := context.Background()
+	crypter, err := newSecretCrypter(ctx)
+	if err != nil {
+		return err
+	}
+	defer crypter.close()
+
+	for _, key := range config.EncryptedKeys {
+		value, exist := data[key]
+		if !exist {
+			return fmt.Errorf("key %s not found", key)
+		}
+
+		plaintext, err := crypter.decrypt(ctx, string(value))
+		if err != nil {
+			return fmt.Errorf("decrypt %s failed, error: %v", key, err)
+		}
+
+		code, err := crypter.encrypt(ctx, plaintext)
+		if err != nil {
+			return fmt.Errorf("encrypt %s failed, error: %v", key, err)
+		}
+
+		if err = crypter.verify(ctx, code, plaintext); err != nil {
+			return fmt.Errorf("verify %s failed, error: %v", key, err)
+		}
+		data[key] = []byte(code)
+	}
+	return nil
+}
+
+func checkSecretNotReferenced(secret corev1.Secret) error {
+	storageBackendClaimClient := client.NewCommonCallHandler[xuanwuV1.StorageBackendClaim](config.Client)
+	claims, err := storageBackendClaimClient.QueryList(secret.Namespace)
+	if err != nil {
+		return fmt.Errorf("query storage backend claims failed, error: %v", err)
+	}
+
+	secretMeta := k8string.JoinQualifiedName(secret.Namespace, secret.Name)
+	for _, claim := range claims {
+		if claim.Spec.SecretMeta == secretMeta {
+			return fmt.Errorf("secret %s is referenced by backend %s", secretMeta, claim.Name)
+		}
+	}
+	return nil
+}
+
+// secretCrypter holds the keys used to re-encrypt the values of the secret
+type secretCrypter struct {
+	key      string
+	newKey   string
+	provider *pwd.GRPCKeyProvider
+}
+
+func newSecretCrypter(ctx context.Context) (*secretCrypter, error) {
+	crypter := &secretCrypter{}
+	var err error
+	if config.KeyFile != "" {
+		if crypter.key, err = readKeyFile(config.KeyFile); err != nil {
+			return nil, err
+		}
+	}
+
+	crypter.newKey = crypter.key
+	if config.NewKeyFile != "" {
+		if crypter.newKey, err = readKeyFile(config.NewKeyFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.KMSEndpoint != "" {
+		if crypter.provider, err = pwd.NewGRPCKeyProvider(ctx, config.KMSEndpoint); err != nil {
+			return nil, err
+		}
+	} else if crypter.newKey == "" {
+		return nil, errors.New("either the key-file, new-key-file or kms-endpoint should be specified")
+	}
+
+	return crypter, nil
+}
+
+func (c *secretCrypter) decrypt(ctx context.Context, code string) (string, error) {
+	if pwd.IsEnvelope(code) {
+		if c.provider == nil {
+			return "", errors.New("the value is encrypted by the KMS plugin, the kms-endpoint should be specified")
+		}
+		return pwd.EnvelopeDecrypt(ctx, code, c.provider)
+	}
+
+	if c.key == "" {
+		return "", errors.New("the key-file should be specified")
+	}
+
+	plaintext, err := pwd.Decrypt(code, c.key)
+	if err != nil {
+		return "", err
+	}
+
+	// the legacy value has no integrity check, a wrong key-file produces garbage instead of an error
+	if pwd.IsLegacy(code) && !isPrintable(plaintext) {
+		return "", errors.New("the decrypted value is not printable, the key-file may be wrong")
+	}
+	return plaintext, nil
+}
+
+// verify used to decrypt the re-encrypted value before it is written back, so that the secret is never
+// updated with a value which cannot be decrypted to the original plaintext
+func (c *secretCrypter) verify(ctx context.Context, code, plaintext string) error {
+	var decrypted string
+	var err error
+	if pwd.IsEnvelope(code) {
+		decrypted, err = pwd.EnvelopeDecrypt(ctx, code, c.provider)
+	} else {
+		decrypted, err = pwd.Decrypt(code, c.newKey)
+	}
+	if err != nil {
+		return err
+	}
+
+	if decrypted != plaintext {
+		return errors.New("the re-encrypted value is not decrypted to the original value")
+	}
+	return nil
+}
+
+func (c *secretCrypter) encrypt(ctx context.Context, plaintext string) (string, error) {
+	if c.provider != nil {
+		return pwd.EnvelopeEncrypt(ctx, plaintext, c.provider)
+	}
+	return pwd.Encrypt(plaintext, c.newKey)
+}
+
+func (c *secretCrypter) close() {
+	if c.provider != nil {
+		_ = c.provider.Close()
+	}
+}
+
+func isPrintable(text string) bool {
+	if !utf8.ValidString(text) {
+		return false
+	}
+
+	for _, r := range text {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func readKeyFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read key file %s failed, error: %v", path, err)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
@@ -0,0 +1,269 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resources
+
+import (
+	"context"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8string "k8s.io/utils/strings"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/config"
+	"huawei-csi-driver/cli/helper"
+	"huawei-csi-driver/utils"
+)
+
+type Snapshot struct {
+	// resource of request
+	resource *Resource
+}
+
+// VolumeSnapshotContent is the part of the VolumeSnapshotContent of snapshot.storage.k8s.io/v1 used by oceanctl
+type VolumeSnapshotContent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VolumeSnapshotContentSpec    `json:"spec"`
+	Status *VolumeSnapshotContentStatus `json:"status,omitempty"`
+}
+
+// VolumeSnapshotContentSpec is the spec of the VolumeSnapshotContent
+type VolumeSnapshotContentSpec struct {
+	VolumeSnapshotRef VolumeSnapshotRef           `json:"volumeSnapshotRef"`
+	Driver            string                      `json:"driver"`
+	Source            VolumeSnapshotContentSource `json:"source"`
+}
+
+// VolumeSnapshotRef is the VolumeSnapshot bound to the VolumeSnapshotContent
+type VolumeSnapshotRef struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+// VolumeSnapshotContentSource is the source of the VolumeSnapshotContent, one of the volume handle of the dynamic
+// snapshot and the snapshot handle of the pre-provisioned snapshot is set
+type VolumeSnapshotContentSource struct {
+	VolumeHandle   *string `json:"volumeHandle,omitempty"`
+	SnapshotHandle *string `json:"snapshotHandle,omitempty"`
+}
+
+// VolumeSnapshotContentStatus is the status of the VolumeSnapshotContent
+type VolumeSnapshotContentStatus struct {
+	SnapshotHandle *string `json:"snapshotHandle,omitempty"`
+	ReadyToUse     *bool   `json:"readyToUse,omitempty"`
+	RestoreSize    *int64  `json:"restoreSize,omitempty"`
+}
+
+// SnapshotShow the content echoed by executing the oceanctl get snapshot
+type SnapshotShow struct {
+	Name     string `show:"NAME"`
+	Snapshot string `show:"SNAPSHOT"`
+	Ready    string `show:"READYTOUSE"`
+	Size     string `show:"RESTORESIZE"`
+	Backend  string `show:"BACKEND"`
+	Volume   string `show:"SOURCEVOLUME"`
+}
+
+// SnapshotShowWide the content echoed by executing the oceanctl get snapshot -o wide|json|yaml, the fields after
+// Sn are queried from the storage
+type SnapshotShowWide struct {
+	Name            string `show:"NAME" json:"name"`
+	Snapshot        string `show:"SNAPSHOT" json:"snapshot"`
+	Ready           string `show:"READYTOUSE" json:"readyToUse"`
+	Size            string `show:"RESTORESIZE" json:"restoreSize"`
+	Backend         string `show:"BACKEND" json:"backend"`
+	Volume          string `show:"SOURCEVOLUME" json:"sourceVolume"`
+	StorageSnapshot string `show:"STORAGESNAPSHOT" json:"storageSnapshot"`
+	StorageType     string `show:"STORAGETYPE" json:"storageType"`
+	Sn              string `show:"SN" json:"sn"`
+	ID              string `show:"ID" json:"id"`
+	WWN             string `show:"WWN" json:"wwn"`
+	Parent          string `show:"PARENT" json:"parent"`
+
+	// parentID is the id of the source lun or filesystem in the snapshot handle
+	parentID string
+}
+
+// NewSnapshot initialize a Snapshot instance
+func NewSnapshot(resource *Resource) *Snapshot {
+	return &Snapshot{resource: resource}
+}
+
+// Get query the volumeSnapshotContents provisioned by the driver, the namespace of the resource is used to filter
+// the contents by the namespace of the bound volumeSnapshots
+func (s *Snapshot) Get() error {
+	contents, err := fetchSnapshotContentsOfProvisioner(s.resource.names...)
+	if err != nil {
+		return helper.LogErrorf("query volumesnapshotcontent resource failed, error: %v", err)
+	}
+
+	if s.resource.namespace != "" {
+		contents = filterSnapshotContentsByNamespace(contents, s.resource.namespace)
+	}
+
+	notFoundSnapshots := getNotFoundNames(helper.MapTo(contents, func(content VolumeSnapshotContent) string {
+		return content.Name
+	}), s.resource.names)
+	if len(contents) == 0 && len(s.resource.names) == 0 {
+		helper.PrintNoResource("snapshot")
+		return nil
+	}
+
+	wideShows, err := fetchSnapshotShows(contents, s.resource.output != "")
+	if err != nil {
+		return helper.LogErrorf("fetch snapshot shows failed, error: %v", err)
+	}
+
+	if s.resource.output != "" {
+		helper.GetPrintFunc[SnapshotShowWide](s.resource.output)(wideShows)
+		helper.PrintNotFoundResource("snapshot", notFoundSnapshots...)
+		return nil
+	}
+
+	helper.PrintWithTable(helper.MapTo(wideShows, func(wide SnapshotShowWide) SnapshotShow {
+		return SnapshotShow{
+			Name:     wide.Name,
+			Snapshot: wide.Snapshot,
+			Ready:    wide.Ready,
+			Size:     wide.Size,
+			Backend:  wide.Backend,
+			Volume:   wide.Volume,
+		}
+	}))
+	helper.PrintNotFoundResource("snapshot", notFoundSnapshots...)
+	return nil
+}
+
+// fetchSnapshotContentsOfProvisioner used to query the volumeSnapshotContents of the names, the contents of the
+// other drivers are ignored
+func fetchSnapshotContentsOfProvisioner(names ...string) ([]VolumeSnapshotContent, error) {
+	contentClient := client.NewCommonCallHandler[VolumeSnapshotContent](config.Client)
+	contents, err := contentClient.QueryList("", names...)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []VolumeSnapshotContent
+	for _, content := range contents {
+		if content.Spec.Driver == getProvisioner() {
+			result = append(result, content)
+		}
+	}
+	return result, nil
+}
+
+func filterSnapshotContentsByNamespace(contents []VolumeSnapshotContent,
+	namespace string) []VolumeSnapshotContent {
+	var result []VolumeSnapshotContent
+	for _, content := range contents {
+		if content.Spec.VolumeSnapshotRef.Namespace == namespace {
+			result = append(result, content)
+		}
+	}
+	return result
+}
+
+// fetchSnapshotShows used to join the volumeSnapshotContents with the backends, the snapshots on the storage are
+// queried as well if queryStorage is true
+func fetchSnapshotShows(contents []VolumeSnapshotContent, queryStorage bool) ([]SnapshotShowWide, error) {
+	backends, err := fetchStorageBackends()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	defer logoutStorageBackends(ctx, backends)
+
+	var result []SnapshotShowWide
+	for _, content := range contents {
+		item := SnapshotShowWide{Name: content.Name}
+		if content.Spec.VolumeSnapshotRef.Name != "" {
+			item.Snapshot = k8string.JoinQualifiedName(content.Spec.VolumeSnapshotRef.Namespace,
+				content.Spec.VolumeSnapshotRef.Name)
+		}
+		if content.Spec.Source.VolumeHandle != nil {
+			_, item.Volume = utils.SplitVolumeId(*content.Spec.Source.VolumeHandle)
+		}
+
+		snapshotHandle := getSnapshotHandle(content)
+		if content.Status != nil {
+			if content.Status.ReadyToUse != nil {
+				item.Ready = strconv.FormatBool(*content.Status.ReadyToUse)
+			}
+			if content.Status.RestoreSize != nil {
+				item.Size = resource.NewQuantity(*content.Status.RestoreSize, resource.BinarySI).String()
+			}
+		}
+
+		if snapshotHandle != "" {
+			backendName, parentID, snapshotName := utils.SplitSnapshotId(snapshotHandle)
+			item.Backend, item.parentID, item.StorageSnapshot = backendName, parentID, snapshotName
+			if queryStorage {
+				item.showWithStorageOption(ctx, getStorageBackend(backends, backendName))
+			}
+		}
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+// getSnapshotHandle returns the snapshot handle in the status, or the one in the source of the pre-provisioned
+// snapshot which is not ready yet
+func getSnapshotHandle(content VolumeSnapshotContent) string {
+	if content.Status != nil && content.Status.SnapshotHandle != nil {
+		return *content.Status.SnapshotHandle
+	}
+	if content.Spec.Source.SnapshotHandle != nil {
+		return *content.Spec.Source.SnapshotHandle
+	}
+	return ""
+}
+
+// showWithStorageOption used to fill the fields of the backend and the storage snapshot, the fields are left
+// empty if the storage can't be queried
+func (s *SnapshotShowWide) showWithStorageOption(ctx context.Context, backend *storageBackend) {
+	if backend == nil {
+		printWarning("backend %s of snapshot %s is not found", s.Backend, s.Name)
+		return
+	}
+
+	// the error of the config is reported by the login
+	_ = backend.loadConfig()
+	s.StorageType = backend.getStorage()
+	s.Sn = backend.getSN()
+
+	storageSnapshot, err := backend.querySnapshot(ctx, s.parentID, s.StorageSnapshot)
+	if err != nil {
+		if err != backend.loginErr {
+			printWarning("query snapshot %s on the storage of backend %s failed, error: %v",
+				s.StorageSnapshot, backend.name, err)
+		}
+		return
+	}
+	if storageSnapshot == nil {
+		printWarning("snapshot %s does not exist on the storage of backend %s", s.StorageSnapshot, backend.name)
+		return
+	}
+
+	s.ID = storageSnapshot.ID
+	s.WWN = storageSnapshot.WWN
+	s.Parent = storageSnapshot.ParentName
+}
@@ -0,0 +1,507 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resources
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8string "k8s.io/utils/strings"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/config"
+	"huawei-csi-driver/cli/helper"
+	xuanwuV1 "huawei-csi-driver/client/apis/xuanwu/v1"
+	pkgUtils "huawei-csi-driver/pkg/utils"
+	storageClient "huawei-csi-driver/storage/oceanstor/client"
+	"huawei-csi-driver/storage/oceanstor/volume"
+	"huawei-csi-driver/utils/log"
+)
+
+const (
+	oceanstorSan   = "oceanstor-san"
+	oceanstorNas   = "oceanstor-nas"
+	oceanstorDTree = "oceanstor-dtree"
+)
+
+// qosShowKeys are the keys of the qos policy on the storage which are shown in order
+var qosShowKeys = []string{"MAXIOPS", "MINIOPS", "MAXBANDWIDTH", "MINBANDWIDTH", "LATENCY"}
+
+// storageBackend holds the objects of a backend configured in the cluster and the client logged in the storage
+type storageBackend struct {
+	name    string
+	content xuanwuV1.StorageBackendContent
+	config  map[string]interface{}
+
+	configErr error
+	cli       *storageClient.BaseClient
+	loginErr  error
+}
+
+// storageVolume is the lun or the filesystem of the volume on the storage
+type storageVolume struct {
+	ID    string
+	WWN   string
+	Pool  string
+	QoS   string
+	Pairs []string
+	Hosts []string
+}
+
+// storageSnapshot is the snapshot of the lun or the filesystem on the storage
+type storageSnapshot struct {
+	ID         string
+	WWN        string
+	ParentName string
+}
+
+// fetchStorageBackends used to get the backends of the provisioner by the storageBackendContents, the key of the
+// result is the backend name in the volume handle
+func fetchStorageBackends() (map[string]*storageBackend, error) {
+	contentClient := client.NewCommonCallHandler[xuanwuV1.StorageBackendContent](config.Client)
+	contents, err := contentClient.QueryList("")
+	if err != nil {
+		return nil, err
+	}
+
+	backends := make(map[string]*storageBackend)
+	for _, content := range contents {
+		if content.Spec.Provider != getProvisioner() || content.Spec.BackendClaim == "" {
+			continue
+		}
+
+		_, name := k8string.SplitQualifiedName(content.Spec.BackendClaim)
+		backends[name] = &storageBackend{name: name, content: content}
+	}
+	return backends, nil
+}
+
+// getStorageBackend returns the backend of the backend name in the volume handle, nil if it is not configured
+func getStorageBackend(backends map[string]*storageBackend, backendName string) *storageBackend {
+	if backend, exist := backends[backendName]; exist {
+		return backend
+	}
+	return backends[helper.GetBackendName(backendName)]
+}
+
+func getProvisioner() string {
+	if config.Provisioner != "" {
+		return config.Provisioner
+	}
+	return config.DefaultProvisioner
+}
+
+// getStorage returns the storage type of the backend, e.g. oceanstor-san
+func (b *storageBackend) getStorage() string {
+	if b == nil || b.config == nil {
+		return ""
+	}
+	storage, _ := b.config["storage"].(string)
+	return storage
+}
+
+// getSN returns the serial number of the storage reported by the sidecar
+func (b *storageBackend) getSN() string {
+	if b == nil || b.content.Status == nil {
+		return ""
+	}
+	return b.content.Status.SN
+}
+
+// loadConfig used to load the backend config of the configmap, which is the same as the config used by the driver.
+// The result is cached, so that the configmap is queried only once.
+func (b *storageBackend) loadConfig() error {
+	if b.config == nil && b.configErr == nil {
+		b.config, b.configErr = loadBackendConfig(b.content.Spec.ConfigmapMeta)
+	}
+	return b.configErr
+}
+
+func loadBackendConfig(configmapMeta string) (map[string]interface{}, error) {
+	namespace, name := k8string.SplitQualifiedName(configmapMeta)
+	configMapClient := client.NewCommonCallHandler[corev1.ConfigMap](config.Client)
+	configMap, err := configMapClient.QueryByName(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if reflect.DeepEqual(configMap, corev1.ConfigMap{}) {
+		return nil, fmt.Errorf("configmap %s not found", configmapMeta)
+	}
+	return parseBackendConfig(configMap)
+}
+
+// parseBackendConfig used to parse the backend config of the csi.json in the configmap
+func parseBackendConfig(configMap corev1.ConfigMap) (map[string]interface{}, error) {
+	var csiConfig struct {
+		Backends map[string]interface{} `json:"backends"`
+	}
+	if err := json.Unmarshal([]byte(configMap.Data["csi.json"]), &csiConfig); err != nil {
+		return nil, fmt.Errorf("unmarshal csi.json of configmap %s/%s failed, error: %v", configMap.Namespace,
+			configMap.Name, err)
+	}
+	return csiConfig.Backends, nil
+}
+
+// login used to login the storage by the account of the backend secret, the password is decrypted by the key-file
+// or the KMS plugin if they are specified. The result is cached, so that the storage is logged in only once.
+func (b *storageBackend) login(ctx context.Context) (*storageClient.BaseClient, error) {
+	if b.cli != nil || b.loginErr != nil {
+		return b.cli, b.loginErr
+	}
+
+	b.cli, b.loginErr = b.newClient(ctx)
+	if b.loginErr != nil {
+		b.loginErr = fmt.Errorf("login storage of backend %s failed, error: %v", b.name, b.loginErr)
+		log.Errorln(b.loginErr)
+		printWarning("%v", b.loginErr)
+	}
+	return b.cli, b.loginErr
+}
+
+func (b *storageBackend) newClient(ctx context.Context) (*storageClient.BaseClient, error) {
+	if err := b.loadConfig(); err != nil {
+		return nil, err
+	}
+
+	storage := b.getStorage()
+	if storage != oceanstorSan && storage != oceanstorNas {
+		return nil, fmt.Errorf("querying the storage %s is not supported", storage)
+	}
+
+	user, password, err := getBackendAccount(ctx, b.content.Spec.SecretMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := newStorageTLSConfig(b.config)
+	if err != nil {
+		return nil, err
+	}
+
+	var urls []string
+	urlList, _ := b.config["urls"].([]interface{})
+	for _, url := range urlList {
+		if urlStr, ok := url.(string); ok {
+			urls = append(urls, urlStr)
+		}
+	}
+	vStoreName, _ := b.config["vstoreName"].(string)
+
+	cli := storageClient.NewClient(&storageClient.NewClientConfig{
+		Urls:       urls,
+		User:       user,
+		VstoreName: vStoreName,
+		BackendID:  b.name,
+		TLSConfig:  tlsConfig,
+		Password:   password,
+	})
+	if err = cli.Login(ctx); err != nil {
+		return nil, err
+	}
+	return cli, nil
+}
+
+// logout used to logout the storage if it is logged in
+func (b *storageBackend) logout(ctx context.Context) {
+	if b.cli != nil {
+		b.cli.Logout(ctx)
+		b.cli = nil
+	}
+}
+
+// queryVolume used to query the lun or the filesystem of the volume, nil is returned if it does not exist
+func (b *storageBackend) queryVolume(ctx context.Context, volName string) (*storageVolume, error) {
+	cli, err := b.login(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.getStorage() == oceanstorSan {
+		return querySanVolume(ctx, cli, volName)
+	}
+	return queryNasVolume(ctx, cli, volName)
+}
+
+func querySanVolume(ctx context.Context, cli *storageClient.BaseClient, volName string) (*storageVolume, error) {
+	lun, err := cli.GetLunByName(ctx, cli.MakeLunName(volName))
+	if err != nil || lun == nil {
+		return nil, err
+	}
+
+	result := newStorageVolume(ctx, cli, lun)
+	result.WWN, _ = lun["WWN"].(string)
+
+	hosts, err := cli.GetHostsByLunId(ctx, result.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, host := range hosts {
+		if name, ok := host["NAME"].(string); ok {
+			result.Hosts = append(result.Hosts, name)
+		}
+	}
+
+	pairs, err := volume.NewSAN(cli, nil, nil, "").GetPairsStatus(ctx, volName)
+	if err != nil {
+		return nil, err
+	}
+	result.Pairs = formatPairs(pairs)
+	return result, nil
+}
+
+func queryNasVolume(ctx context.Context, cli *storageClient.BaseClient, volName string) (*storageVolume, error) {
+	fs, err := cli.GetFileSystemByName(ctx, volName)
+	if err != nil || fs == nil {
+		return nil, err
+	}
+
+	result := newStorageVolume(ctx, cli, fs)
+	pairs, err := volume.NewNAS(cli, nil, nil, "", volume.NASHyperMetro{}).GetPairsStatus(ctx, volName)
+	if err != nil {
+		return nil, err
+	}
+	result.Pairs = formatPairs(pairs)
+	return result, nil
+}
+
+// newStorageVolume used to build the storageVolume by the common fields of the lun and the filesystem
+func newStorageVolume(ctx context.Context, cli *storageClient.BaseClient,
+	obj map[string]interface{}) *storageVolume {
+	result := &storageVolume{}
+	result.ID, _ = obj["ID"].(string)
+	result.Pool, _ = obj["PARENTNAME"].(string)
+
+	qosID, _ := obj["IOCLASSID"].(string)
+	if qosID == "" {
+		return result
+	}
+
+	vStoreID, _ := obj["vstoreId"].(string)
+	qos, err := cli.GetQosByID(ctx, qosID, vStoreID)
+	if err != nil {
+		log.Warningf("Get qos %s of object %s failed, error: %v", qosID, result.ID, err)
+		result.QoS = qosID
+		return result
+	}
+	result.QoS = formatQoS(qos)
+	return result
+}
+
+// querySnapshot used to query the snapshot of the lun or the filesystem, nil is returned if it does not exist
+func (b *storageBackend) querySnapshot(ctx context.Context, parentID, snapshotName string) (*storageSnapshot,
+	error) {
+	cli, err := b.login(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot map[string]interface{}
+	if b.getStorage() == oceanstorSan {
+		snapshot, err = cli.GetLunSnapshotByName(ctx, snapshotName)
+	} else {
+		snapshot, err = cli.GetFSSnapshotByName(ctx, parentID, snapshotName)
+	}
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+
+	result := &storageSnapshot{}
+	result.ID, _ = snapshot["ID"].(string)
+	result.WWN, _ = snapshot["WWN"].(string)
+	result.ParentName, _ = snapshot["PARENTNAME"].(string)
+	return result, nil
+}
+
+// formatQoS used to format the limits of the qos policy, e.g. MAXIOPS=1000,MAXBANDWIDTH=100
+func formatQoS(qos map[string]interface{}) string {
+	var limits []string
+	for _, key := range qosShowKeys {
+		value, _ := qos[key].(string)
+		if value != "" && value != "0" {
+			limits = append(limits, fmt.Sprintf("%s=%s", key, value))
+		}
+	}
+
+	if len(limits) == 0 {
+		name, _ := qos["NAME"].(string)
+		return name
+	}
+	return strings.Join(limits, ",")
+}
+
+// formatPairs used to format the pairs of the volume, e.g. HyperMetro/1(Normal)
+func formatPairs(pairs []map[string]interface{}) []string {
+	var result []string
+	for _, pair := range pairs {
+		result = append(result, fmt.Sprintf("%v/%v(%v)", pair["Type"], pair["ID"], pair["RunningStatus"]))
+	}
+	return result
+}
+
+// getBackendAccount used to get the user and the decrypted password of the backend secret
+func getBackendAccount(ctx context.Context, secretMeta string) (string, string, error) {
+	data, err := getSecretData(secretMeta)
+	if err != nil {
+		return "", "", err
+	}
+	return decodeBackendAccount(ctx, secretMeta, data)
+}
+
+// decodeBackendAccount used to get the user and the decrypted password of the data of the backend secret
+func decodeBackendAccount(ctx context.Context, secretMeta string, data map[string][]byte) (string, string, error) {
+	password, err := decryptSecretValue(ctx, string(data["password"]))
+	if err != nil {
+		return "", "", fmt.Errorf("decrypt password of secret %s failed, error: %v", secretMeta, err)
+	}
+	if password == "" {
+		return "", "", fmt.Errorf("password of secret %s is empty", secretMeta)
+	}
+	return string(data["user"]), password, nil
+}
+
+// newValidateParam used to build the param of Plugin.Validate like the storage info of the driver. The password
+// and the tls config are given directly since the plugin can't read the secrets in the cli.
+func newValidateParam(backendConfig map[string]interface{}, claimMeta, secretMeta, user, password string,
+	tlsConfig *tls.Config) map[string]interface{} {
+	param := make(map[string]interface{}, len(backendConfig))
+	for key, value := range backendConfig {
+		param[key] = value
+	}
+
+	secretNamespace, secretName := k8string.SplitQualifiedName(secretMeta)
+	param["secretNamespace"] = secretNamespace
+	param["secretName"] = secretName
+	param["user"] = user
+	param["password"] = password
+	param["backendID"] = claimMeta
+	param["tlsConfig"] = tlsConfig
+	return param
+}
+
+// isLoginValidatable returns whether the login of the storage can be validated by the plugin in the cli
+func isLoginValidatable(storage string) bool {
+	return storage == oceanstorSan || storage == oceanstorNas || storage == oceanstorDTree
+}
+
+// decryptSecretValue used to decrypt the value of the secret by the key-file or the KMS plugin, the value is
+// returned directly if neither of them is specified
+func decryptSecretValue(ctx context.Context, value string) (string, error) {
+	if config.KeyFile == "" && config.KMSEndpoint == "" {
+		return value, nil
+	}
+
+	crypter, err := newSecretCrypter(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer crypter.close()
+
+	return crypter.decrypt(ctx, value)
+}
+
+// newStorageTLSConfig used to build the tls config of the storage client by the tls settings of the backend
+// config like the driver, the certificates are read from the secrets and the configmaps in the cluster
+func newStorageTLSConfig(backendConfig map[string]interface{}) (*tls.Config, error) {
+	backendTLSConfig, err := pkgUtils.ParseBackendTLSConfig(backendConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if !backendTLSConfig.VerifyCert {
+		return &tls.Config{InsecureSkipVerify: true}, nil
+	}
+
+	tlsConfig := &tls.Config{ServerName: backendTLSConfig.ServerName}
+	caCert, err := getCACert(backendTLSConfig)
+	if err != nil {
+		return nil, err
+	}
+	if caCert != nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("no valid PEM certificate is found in the CA bundle of the backend")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if backendTLSConfig.ClientCertSecret != "" {
+		data, err := getSecretData(backendTLSConfig.ClientCertSecret)
+		if err != nil {
+			return nil, err
+		}
+
+		cert, err := tls.X509KeyPair(data[pkgUtils.ClientCertKey], data[pkgUtils.ClientKeyKey])
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate from secret %s failed, error: %v",
+				backendTLSConfig.ClientCertSecret, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func getCACert(backendTLSConfig *pkgUtils.BackendTLSConfig) ([]byte, error) {
+	if backendTLSConfig.CACertSecret != "" {
+		data, err := getSecretData(backendTLSConfig.CACertSecret)
+		if err != nil {
+			return nil, err
+		}
+		return data[pkgUtils.CACertKey], nil
+	}
+
+	if backendTLSConfig.CACertConfigMap != "" {
+		namespace, name := k8string.SplitQualifiedName(backendTLSConfig.CACertConfigMap)
+		configMapClient := client.NewCommonCallHandler[corev1.ConfigMap](config.Client)
+		configMap, err := configMapClient.QueryByName(namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		if reflect.DeepEqual(configMap, corev1.ConfigMap{}) {
+			return nil, fmt.Errorf("CA certificate configmap %s not found", backendTLSConfig.CACertConfigMap)
+		}
+		return []byte(configMap.Data[pkgUtils.CACertKey]), nil
+	}
+
+	return nil, nil
+}
+
+func getSecretData(secretMeta string) (map[string][]byte, error) {
+	namespace, name := k8string.SplitQualifiedName(secretMeta)
+	secretClient := client.NewCommonCallHandler[corev1.Secret](config.Client)
+	secret, err := secretClient.QueryByName(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if reflect.DeepEqual(secret, corev1.Secret{}) {
+		return nil, fmt.Errorf("secret %s not found", secretMeta)
+	}
+	return secret.Data, nil
+}
+
+// printWarning used to print the warning to stderr, so that the json or yaml output is not broken
+func printWarning(format string, args ...interface{}) {
+	_, _ = fmt.Fprintf(os.Stderr, "Warning: "+format+"\n", args...)
+}
@@ -103,3 +103,14 @@ func (b *ValidatorBuilder) ValidateOutputFormat() *ValidatorBuilder {
 
 	return b
 }
+
+// ValidateDryRun used to validate the dry-run mode. For example, the following operations are illegal
+// oceanctl create backend -f /path/to/backend.yaml --dry-run=true
+func (b *ValidatorBuilder) ValidateDryRun() *ValidatorBuilder {
+	if !slices.Contains(config.SupportedDryRunModes, b.resource.dryRun) {
+		b.errs = append(b.errs, fmt.Errorf("invalid dry-run value %s, allowed values are: %v", b.resource.dryRun,
+			strings.Join(config.SupportedDryRunModes, ", ")))
+	}
+
+	return b
+}
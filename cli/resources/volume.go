@@ -0,0 +1,278 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package resources
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8string "k8s.io/utils/strings"
+
+	"huawei-csi-driver/cli/client"
+	"huawei-csi-driver/cli/config"
+	"huawei-csi-driver/cli/helper"
+	"huawei-csi-driver/utils"
+)
+
+type Volume struct {
+	// resource of request
+	resource *Resource
+}
+
+// VolumeShow the content echoed by executing the oceanctl get volume
+type VolumeShow struct {
+	Name     string `show:"NAME"`
+	Claim    string `show:"CLAIM"`
+	Capacity string `show:"CAPACITY"`
+	Status   string `show:"STATUS"`
+	Backend  string `show:"BACKEND"`
+	Volume   string `show:"VOLUME"`
+}
+
+// VolumeShowWide the content echoed by executing the oceanctl get volume -o wide|json|yaml, the fields after
+// Sn are queried from the storage
+type VolumeShowWide struct {
+	Name        string `show:"NAME" json:"name"`
+	Claim       string `show:"CLAIM" json:"claim"`
+	Capacity    string `show:"CAPACITY" json:"capacity"`
+	Status      string `show:"STATUS" json:"status"`
+	Backend     string `show:"BACKEND" json:"backend"`
+	Volume      string `show:"VOLUME" json:"volume"`
+	Nodes       string `show:"NODES" json:"nodes"`
+	StorageType string `show:"STORAGETYPE" json:"storageType"`
+	Sn          string `show:"SN" json:"sn"`
+	ID          string `show:"ID" json:"id"`
+	WWN         string `show:"WWN" json:"wwn"`
+	Pool        string `show:"POOL" json:"pool"`
+	QoS         string `show:"QOS" json:"qos"`
+	Pairs       string `show:"PAIRS" json:"pairs"`
+	Hosts       string `show:"HOSTS" json:"hosts"`
+}
+
+// NewVolume initialize a Volume instance
+func NewVolume(resource *Resource) *Volume {
+	return &Volume{resource: resource}
+}
+
+// Get query the volumes provisioned by the driver, the namespace of the resource is used to filter the volumes
+// by the namespace of the bound claims
+func (v *Volume) Get() error {
+	volumes, err := fetchVolumesOfProvisioner(v.resource.names...)
+	if err != nil {
+		return helper.LogErrorf("query pv resource failed, error: %v", err)
+	}
+
+	if v.resource.namespace != "" {
+		volumes = filterVolumesByClaimNamespace(volumes, v.resource.namespace)
+	}
+
+	notFoundVolumes := getNotFoundNames(helper.MapTo(volumes, func(pv corev1.PersistentVolume) string {
+		return pv.Name
+	}), v.resource.names)
+	if len(volumes) == 0 && len(v.resource.names) == 0 {
+		helper.PrintNoResource("volume")
+		return nil
+	}
+
+	wideShows, err := fetchVolumeShows(volumes, v.resource.output != "")
+	if err != nil {
+		return helper.LogErrorf("fetch volume shows failed, error: %v", err)
+	}
+
+	if v.resource.output != "" {
+		helper.GetPrintFunc[VolumeShowWide](v.resource.output)(wideShows)
+		helper.PrintNotFoundResource("volume", notFoundVolumes...)
+		return nil
+	}
+
+	helper.PrintWithTable(helper.MapTo(wideShows, func(wide VolumeShowWide) VolumeShow {
+		return VolumeShow{
+			Name:     wide.Name,
+			Claim:    wide.Claim,
+			Capacity: wide.Capacity,
+			Status:   wide.Status,
+			Backend:  wide.Backend,
+			Volume:   wide.Volume,
+		}
+	}))
+	helper.PrintNotFoundResource("volume", notFoundVolumes...)
+	return nil
+}
+
+// fetchVolumesOfProvisioner used to query the persistentVolumes of the names, the volumes of the other drivers
+// are ignored
+func fetchVolumesOfProvisioner(names ...string) ([]corev1.PersistentVolume, error) {
+	volumeClient := client.NewCommonCallHandler[corev1.PersistentVolume](config.Client)
+	volumes, err := volumeClient.QueryList("", names...)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []corev1.PersistentVolume
+	for _, pv := range volumes {
+		if pv.Spec.CSI != nil && pv.Spec.CSI.Driver == getProvisioner() {
+			result = append(result, pv)
+		}
+	}
+	return result, nil
+}
+
+func filterVolumesByClaimNamespace(volumes []corev1.PersistentVolume, namespace string) []corev1.PersistentVolume {
+	var result []corev1.PersistentVolume
+	for _, pv := range volumes {
+		if pv.Spec.ClaimRef != nil && pv.Spec.ClaimRef.Namespace == namespace {
+			result = append(result, pv)
+		}
+	}
+	return result
+}
+
+// fetchVolumeShows used to join the volumes with the backends and the attached nodes, the objects on the storage
+// are queried as well if queryStorage is true
+func fetchVolumeShows(volumes []corev1.PersistentVolume, queryStorage bool) ([]VolumeShowWide, error) {
+	backends, err := fetchStorageBackends()
+	if err != nil {
+		return nil, err
+	}
+
+	attachedNodes, err := fetchAttachedNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	defer logoutStorageBackends(ctx, backends)
+
+	var result []VolumeShowWide
+	for _, pv := range volumes {
+		item := VolumeShowWide{
+			Name:   pv.Name,
+			Status: string(pv.Status.Phase),
+			Nodes:  strings.Join(attachedNodes[pv.Name], ","),
+		}
+		if pv.Spec.ClaimRef != nil {
+			item.Claim = k8string.JoinQualifiedName(pv.Spec.ClaimRef.Namespace, pv.Spec.ClaimRef.Name)
+		}
+		if capacity, exist := pv.Spec.Capacity[corev1.ResourceStorage]; exist {
+			item.Capacity = capacity.String()
+		}
+
+		backendName, volName := utils.SplitVolumeId(pv.Spec.CSI.VolumeHandle)
+		item.Backend, item.Volume = backendName, volName
+		if queryStorage {
+			item.showWithStorageOption(ctx, getStorageBackend(backends, backendName))
+		}
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+// showWithStorageOption used to fill the fields of the backend and the storage object of the volume, the fields
+// are left empty if the storage can't be queried
+func (v *VolumeShowWide) showWithStorageOption(ctx context.Context, backend *storageBackend) {
+	if backend == nil {
+		printWarning("backend %s of volume %s is not found", v.Backend, v.Name)
+		return
+	}
+
+	// the error of the config is reported by the login
+	_ = backend.loadConfig()
+	v.StorageType = backend.getStorage()
+	v.Sn = backend.getSN()
+
+	storageVolume, err := backend.queryVolume(ctx, v.Volume)
+	if err != nil {
+		if err != backend.loginErr {
+			printWarning("query volume %s on the storage of backend %s failed, error: %v",
+				v.Volume, backend.name, err)
+		}
+		return
+	}
+	if storageVolume == nil {
+		printWarning("volume %s does not exist on the storage of backend %s", v.Volume, backend.name)
+		return
+	}
+
+	v.ID = storageVolume.ID
+	v.WWN = storageVolume.WWN
+	v.Pool = storageVolume.Pool
+	v.QoS = storageVolume.QoS
+	v.Pairs = strings.Join(storageVolume.Pairs, ",")
+	v.Hosts = strings.Join(storageVolume.Hosts, ",")
+}
+
+// fetchAttachedNodes used to get the nodes which the volumes of the provisioner are attached to, the key of the
+// result is the name of the persistentVolume
+func fetchAttachedNodes() (map[string][]string, error) {
+	attachments, err := fetchVolumeAttachments()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]string)
+	for _, attachment := range attachments {
+		pvName := *attachment.Spec.Source.PersistentVolumeName
+		result[pvName] = append(result[pvName], attachment.Spec.NodeName)
+	}
+	for _, nodes := range result {
+		sort.Strings(nodes)
+	}
+	return result, nil
+}
+
+// fetchVolumeAttachments used to query the volumeAttachments of the persistentVolumes of the provisioner
+func fetchVolumeAttachments() ([]storagev1.VolumeAttachment, error) {
+	attachmentClient := client.NewCommonCallHandler[storagev1.VolumeAttachment](config.Client)
+	attachments, err := attachmentClient.QueryList("")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []storagev1.VolumeAttachment
+	for _, attachment := range attachments {
+		if attachment.Spec.Attacher == getProvisioner() && attachment.Spec.Source.PersistentVolumeName != nil {
+			result = append(result, attachment)
+		}
+	}
+	return result, nil
+}
+
+func logoutStorageBackends(ctx context.Context, backends map[string]*storageBackend) {
+	for _, backend := range backends {
+		backend.logout(ctx)
+	}
+}
+
+// getNotFoundNames returns the names to query which are not in the found names
+func getNotFoundNames(foundNames, queryNames []string) []string {
+	found := make(map[string]bool, len(foundNames))
+	for _, name := range foundNames {
+		found[name] = true
+	}
+
+	var notFound []string
+	for _, name := range queryNames {
+		if !found[name] {
+			notFound = append(notFound, name)
+		}
+	}
+	return notFound
+}
@@ -0,0 +1,51 @@
+/*
+ Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v1
+
+const (
+	// ConditionAuthenticated indicates whether the storage backend is logged in successfully
+	ConditionAuthenticated = "Authenticated"
+	// ConditionReachable indicates whether the management urls of the storage backend are reachable
+	ConditionReachable = "Reachable"
+	// ConditionPoolsAvailable indicates whether all configured storage pools exist on the storage backend
+	ConditionPoolsAvailable = "PoolsAvailable"
+	// ConditionSynced indicates whether the last synchronization with the storage backend is successful
+	ConditionSynced = "Synced"
+)
+
+const (
+	// ReasonLoginSucceeded means the storage backend is logged in successfully
+	ReasonLoginSucceeded = "LoginSucceeded"
+	// ReasonWrongPassword means the user name or the password of the storage backend is incorrect
+	ReasonWrongPassword = "WrongPassword"
+	// ReasonIPLocked means the ip of the driver is locked by the storage due to too many login failures
+	ReasonIPLocked = "IPLocked"
+	// ReasonLoginFailed means the storage backend rejects the login for other reasons
+	ReasonLoginFailed = "LoginFailed"
+	// ReasonConnected means at least one management url of the storage backend is connected
+	ReasonConnected = "Connected"
+	// ReasonUnreachable means none of the management urls of the storage backend is connected
+	ReasonUnreachable = "Unreachable"
+	// ReasonCertificateInvalid means the certificate of the storage backend is not trusted
+	ReasonCertificateInvalid = "CertificateInvalid"
+	// ReasonPoolsFound means all configured storage pools exist on the storage backend
+	ReasonPoolsFound = "PoolsFound"
+	// ReasonPoolsNotFound means some configured storage pools do not exist on the storage backend
+	ReasonPoolsNotFound = "PoolsNotFound"
+	// ReasonSynced means the storage backend is synchronized successfully
+	ReasonSynced = "Synced"
+	// ReasonSyncFailed means the synchronization of the storage backend failed
+	ReasonSyncFailed = "SyncFailed"
+)
@@ -51,6 +51,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&StorageBackendClaimList{},
 		&StorageBackendContent{},
 		&StorageBackendContentList{},
+		&VolumeReplicationStatus{},
+		&VolumeReplicationStatusList{},
+		&VolumeReplicationAction{},
+		&VolumeReplicationActionList{},
 	)
 	v1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
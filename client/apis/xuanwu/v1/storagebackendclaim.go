@@ -72,6 +72,15 @@ type StorageBackendClaimStatus struct {
 
 	// MetroBackend is the backend that form hyperMetro
 	MetroBackend string `json:"metroBackend,omitempty" protobuf:"bytes,2,opt,name=metroBackend"`
+
+	// ObservedGeneration is the generation of the claim observed by the storage-backend controller
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty" protobuf:"varint,3,opt,name=observedGeneration"`
+
+	// Conditions are copied from the bound StorageBackendContent, such as Authenticated, Reachable,
+	// PoolsAvailable and Synced
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" protobuf:"bytes,4,rep,name=conditions"`
 }
 
 // StorageBackendPhase defines the phase of StorageBackend
@@ -96,6 +105,8 @@ const (
 // +kubebuilder:printcolumn:name="Protocol",type=string,priority=1,JSONPath=`.status.protocol`
 // +kubebuilder:printcolumn:name="MetroBackend",type=string,priority=1,JSONPath=`.status.metroBackend`
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Synced",type=string,priority=1,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,priority=1,JSONPath=`.status.conditions[?(@.type=="Synced")].reason`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // StorageBackendClaim is the Schema for the storageBackends API
@@ -80,6 +80,36 @@ type StorageBackendContentStatus struct {
 
 	// SN is the unique identifier of a storage device.
 	SN string `json:"sn,omitempty" protobuf:"bytes,1,opt,name=sn"`
+
+	// Pools get the capacity and the provisioning types of each storage pool of the backend.
+	Pools []StoragePool `json:"pools,omitempty" protobuf:"bytes,1,opt,name=pools"`
+
+	// ObservedGeneration is the generation of the content observed by the sidecar
+	ObservedGeneration int64 `json:"observedGeneration,omitempty" protobuf:"varint,3,opt,name=observedGeneration"`
+
+	// Conditions indicate why the backend is unavailable, such as Authenticated, Reachable, PoolsAvailable and Synced
+	Conditions []metav1.Condition `json:"conditions,omitempty" protobuf:"bytes,4,rep,name=conditions"`
+}
+
+// StoragePool defines the observed state of one storage pool of the backend
+type StoragePool struct {
+	// Name is the name of the storage pool
+	Name string `json:"name" protobuf:"bytes,1,name=name"`
+
+	// Type is the volume type provided by the storage pool, such as block or file
+	Type string `json:"type,omitempty" protobuf:"bytes,1,opt,name=type"`
+
+	// TotalCapacity is the total capacity of the storage pool, such as 100Gi
+	TotalCapacity string `json:"totalCapacity,omitempty" protobuf:"bytes,1,opt,name=totalCapacity"`
+
+	// FreeCapacity is the free capacity of the storage pool, such as 100Gi
+	FreeCapacity string `json:"freeCapacity,omitempty" protobuf:"bytes,1,opt,name=freeCapacity"`
+
+	// SupportThin indicates whether the storage pool supports thin provisioning
+	SupportThin bool `json:"supportThin,omitempty" protobuf:"bytes,1,opt,name=supportThin"`
+
+	// SupportThick indicates whether the storage pool supports thick provisioning
+	SupportThick bool `json:"supportThick,omitempty" protobuf:"bytes,1,opt,name=supportThick"`
 }
 
 // CapacityType means the capacity types
@@ -105,7 +135,11 @@ const (
 // +kubebuilder:printcolumn:name="SN",type=string,JSONPath=`.status.sn`
 // +kubebuilder:printcolumn:name="VendorName",type=string,JSONPath=`.status.vendorName`
 // +kubebuilder:printcolumn:name="ProviderVersion",type=string,JSONPath=`.status.providerVersion`
+// +kubebuilder:printcolumn:name="TotalCapacity",type=string,JSONPath=`.status.capacity.TotalCapacity`
+// +kubebuilder:printcolumn:name="FreeCapacity",type=string,JSONPath=`.status.capacity.FreeCapacity`
 // +kubebuilder:printcolumn:name="Online",type=boolean,JSONPath=`.status.online`
+// +kubebuilder:printcolumn:name="Synced",type=string,priority=1,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,priority=1,JSONPath=`.status.conditions[?(@.type=="Synced")].reason`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // StorageBackendContent is the Schema for the StorageBackendContents API
@@ -0,0 +1,118 @@
+/*
+ Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReplicationActionType defines the operation on the replication pairs of the volume
+type ReplicationActionType string
+
+const (
+	// SwitchoverAction makes the volume the primary in a planned way, the pairs must be normal
+	SwitchoverAction ReplicationActionType = "Switchover"
+	// FailoverAction splits the pairs and makes the secondary volume writable when the primary site is lost
+	FailoverAction ReplicationActionType = "Failover"
+	// ResyncAction resumes the split pairs after the failed site recovers
+	ResyncAction ReplicationActionType = "Resync"
+)
+
+// ReplicationActionPhase defines the phase of the action
+type ReplicationActionPhase string
+
+const (
+	// ReplicationActionRunning means the action is being executed by the provider
+	ReplicationActionRunning ReplicationActionPhase = "Running"
+	// ReplicationActionSucceeded means the action is finished
+	ReplicationActionSucceeded ReplicationActionPhase = "Succeeded"
+	// ReplicationActionFailed means the action is failed, it is not retried
+	ReplicationActionFailed ReplicationActionPhase = "Failed"
+)
+
+// VolumeReplicationActionSpec defines the operation on the replication pairs of the volume
+type VolumeReplicationActionSpec struct {
+	// ClaimName is the name of the PersistentVolumeClaim in the namespace of the action, the action is executed on
+	// the PersistentVolume bound to it
+	ClaimName string `json:"claimName" protobuf:"bytes,1,name=claimName"`
+
+	// Action is the operation on the replication pairs, Switchover, Failover or Resync
+	// +kubebuilder:validation:Enum=Switchover;Failover;Resync
+	Action ReplicationActionType `json:"action" protobuf:"bytes,2,name=action"`
+
+	// Reverse makes the volume the primary when resynchronizing, otherwise the volume is overwritten by the
+	// primary. It is only used by Resync.
+	// +optional
+	Reverse bool `json:"reverse,omitempty" protobuf:"varint,3,opt,name=reverse"`
+
+	// Parameters are passed to the provider, such as the authClient used to export the promoted filesystem
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty" protobuf:"bytes,4,rep,name=parameters"`
+}
+
+// VolumeReplicationActionStatus defines the observed state of the action
+type VolumeReplicationActionStatus struct {
+	// Phase is the phase of the action, Running, Succeeded or Failed
+	// +optional
+	Phase ReplicationActionPhase `json:"phase,omitempty" protobuf:"bytes,1,opt,name=phase"`
+
+	// VolumeHandle is the volume id of the PersistentVolume, the format is <backend name>.<volume name>
+	// +optional
+	VolumeHandle string `json:"volumeHandle,omitempty" protobuf:"bytes,2,opt,name=volumeHandle"`
+
+	// StartTime is the time the action is started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty" protobuf:"bytes,3,opt,name=startTime"`
+
+	// CompletionTime is the time the action is succeeded or failed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty" protobuf:"bytes,4,opt,name=completionTime"`
+
+	// Message is the reason why the action is failed
+	// +optional
+	Message string `json:"message,omitempty" protobuf:"bytes,5,opt,name=message"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName="vra"
+// +kubebuilder:printcolumn:name="Claim",type=string,JSONPath=`.spec.claimName`
+// +kubebuilder:printcolumn:name="Action",type=string,JSONPath=`.spec.action`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Message",type=string,priority=1,JSONPath=`.status.message`
+// +kubebuilder:printcolumn:name="CompletionTime",type=date,priority=1,JSONPath=`.status.completionTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// VolumeReplicationAction is the Schema for the volumeReplicationActions API, it is executed once by the
+// storage backend sidecar of the provider of the volume
+type VolumeReplicationAction struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Spec   VolumeReplicationActionSpec    `json:"spec,omitempty"`
+	Status *VolumeReplicationActionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeReplicationActionList contains a list of VolumeReplicationAction
+type VolumeReplicationActionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VolumeReplicationAction `json:"items"`
+}
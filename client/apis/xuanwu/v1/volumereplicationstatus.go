@@ -0,0 +1,114 @@
+/*
+ Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VolumeReplicationStatusSpec defines the volume whose pairs are reported
+type VolumeReplicationStatusSpec struct {
+	// VolumeName is the name of the PersistentVolume
+	VolumeName string `json:"volumeName" protobuf:"bytes,1,name=volumeName"`
+
+	// VolumeHandle is the volume id of the PersistentVolume, the format is <backend name>.<volume name>
+	VolumeHandle string `json:"volumeHandle" protobuf:"bytes,2,name=volumeHandle"`
+}
+
+// VolumeReplicationStatusStatus defines the observed state of the pairs of the volume
+type VolumeReplicationStatusStatus struct {
+	// Pairs are the hyperMetro and replication pairs of the volume on the storage
+	// +optional
+	Pairs []ReplicationPairStatus `json:"pairs,omitempty" protobuf:"bytes,1,rep,name=pairs"`
+
+	// LastUpdateTime is the time the status is queried from the storage
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty" protobuf:"bytes,2,opt,name=lastUpdateTime"`
+
+	// Message is the reason why the pairs can not be queried
+	// +optional
+	Message string `json:"message,omitempty" protobuf:"bytes,3,opt,name=message"`
+}
+
+// ReplicationPairType defines the type of the pair
+type ReplicationPairType string
+
+const (
+	// HyperMetroPair means the pair is a hyperMetro pair
+	HyperMetroPair ReplicationPairType = "HyperMetro"
+	// ReplicationPair means the pair is a remote replication pair
+	ReplicationPair ReplicationPairType = "Replication"
+)
+
+// ReplicationPairStatus defines the observed state of one pair of the volume
+type ReplicationPairStatus struct {
+	// Type is the type of the pair, HyperMetro or Replication
+	Type ReplicationPairType `json:"type" protobuf:"bytes,1,name=type"`
+
+	// PairID is the id of the pair on the storage
+	PairID string `json:"pairID" protobuf:"bytes,2,name=pairID"`
+
+	// RunningStatus is the running status of the pair, such as Normal, Synchronizing or Split
+	// +optional
+	RunningStatus string `json:"runningStatus,omitempty" protobuf:"bytes,3,opt,name=runningStatus"`
+
+	// HealthStatus is the health status of the pair, such as Normal or Fault
+	// +optional
+	HealthStatus string `json:"healthStatus,omitempty" protobuf:"bytes,4,opt,name=healthStatus"`
+
+	// SyncProgress is the percentage of the synchronization progress
+	// +optional
+	SyncProgress string `json:"syncProgress,omitempty" protobuf:"bytes,5,opt,name=syncProgress"`
+
+	// RemoteDeviceSN is the serial number of the remote storage
+	// +optional
+	RemoteDeviceSN string `json:"remoteDeviceSN,omitempty" protobuf:"bytes,6,opt,name=remoteDeviceSN"`
+
+	// LastSyncTime is the time the last synchronization is finished
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty" protobuf:"bytes,7,opt,name=lastSyncTime"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName="vrs"
+// +kubebuilder:printcolumn:name="Volume",type=string,JSONPath=`.spec.volumeName`
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.status.pairs[*].type`
+// +kubebuilder:printcolumn:name="RunningStatus",type=string,JSONPath=`.status.pairs[*].runningStatus`
+// +kubebuilder:printcolumn:name="HealthStatus",type=string,JSONPath=`.status.pairs[*].healthStatus`
+// +kubebuilder:printcolumn:name="RemoteDeviceSN",type=string,priority=1,JSONPath=`.status.pairs[*].remoteDeviceSN`
+// +kubebuilder:printcolumn:name="LastUpdateTime",type=date,priority=1,JSONPath=`.status.lastUpdateTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// VolumeReplicationStatus is the Schema for the volumeReplicationStatuses API
+type VolumeReplicationStatus struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Spec   VolumeReplicationStatusSpec    `json:"spec,omitempty"`
+	Status *VolumeReplicationStatusStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeReplicationStatusList contains a list of VolumeReplicationStatus
+type VolumeReplicationStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VolumeReplicationStatus `json:"items"`
+}
@@ -146,12 +146,20 @@ func runController(
 		ContentInformer: factory.Xuanwu().V1().StorageBackendContents(),
 		ReSyncPeriod:    app.GetGlobalConfig().ReSyncPeriod,
 		EventRecorder:   eventRecorder})
+	replicationCtrl := controller.NewReplicationController(controller.ReplicationControllerRequest{
+		ProviderName:   providerName,
+		ClientSet:      storageBackendClient,
+		Replication:    storageBackend.NewReplication(connect),
+		TimeOut:        app.GetGlobalConfig().Timeout,
+		ActionInformer: factory.Xuanwu().V1().VolumeReplicationActions(),
+		EventRecorder:  eventRecorder})
 
 	run := func(ctx context.Context) {
 		// run...
 		stopCh := make(chan struct{})
 		factory.Start(stopCh)
 		go ctrl.Run(ctx, app.GetGlobalConfig().WorkerThreads, stopCh)
+		go replicationCtrl.Run(ctx, app.GetGlobalConfig().WorkerThreads, stopCh)
 
 		// Stop the controller when stop signals are received
 		utils.WaitExitSignal(ctx, "controller")
@@ -35,6 +35,7 @@ import (
 	"huawei-csi-driver/csi/app"
 	"huawei-csi-driver/utils"
 	"huawei-csi-driver/utils/log"
+	"huawei-csi-driver/utils/metrics"
 )
 
 const (
@@ -433,6 +434,13 @@ func getSessionIdByDevice(devPath string) (string, error) {
 
 // WatchDMDevice is an aggregate drive letter monitor.
 func WatchDMDevice(ctx context.Context, lunWWN string, expectPathNumber int) (DMDeviceInfo, error) {
+	start := time.Now()
+	dm, err := watchDMDevice(ctx, lunWWN, expectPathNumber)
+	metrics.ObserveConnectorOperation(DMMultiPath, "aggregate", start, err)
+	return dm, err
+}
+
+func watchDMDevice(ctx context.Context, lunWWN string, expectPathNumber int) (DMDeviceInfo, error) {
 	log.AddContext(ctx).Infof("Watch DM Disk Generation. lunWWN: %s,expectPathNumber: %d", lunWWN, expectPathNumber)
 	var timeout = time.After(ScanVolumeTimeout)
 	var dm DMDeviceInfo
@@ -1818,6 +1826,57 @@ func GetDeviceFromMountFile(ctx context.Context, targetPath string, checkDevRef
 	return device, nil
 }
 
+// GetVolumeCondition used to check whether the device of the volume path is abnormal, the volume is abnormal
+// when the staged device is missing or the multipath device has no active path. Return the abnormal
+// flag and the reason.
+func GetVolumeCondition(ctx context.Context, volumePath string) (bool, string) {
+	device, err := GetDeviceFromMountFile(ctx, volumePath, false)
+	if err != nil {
+		log.AddContext(ctx).Warningf("Get device of volume path %s failed, error: %v", volumePath, err)
+		return false, ""
+	}
+
+	// the nfs share or the bind mounted block device is not a disk device, skip the check
+	if !strings.HasPrefix(device, "/dev/") {
+		return false, ""
+	}
+
+	realPath, err := filepath.EvalSymlinks(device)
+	if err != nil {
+		return true, fmt.Sprintf("the device %s of volume path %s is missing", device, volumePath)
+	}
+
+	dm := filepath.Base(realPath)
+	if !strings.HasPrefix(dm, "dm-") {
+		return false, ""
+	}
+
+	paths, err := getDeviceFromDM(dm)
+	if err != nil {
+		log.AddContext(ctx).Warningf("Get paths of multipath device %s failed, error: %v", dm, err)
+		return false, ""
+	}
+
+	var activePaths int
+	for _, p := range paths {
+		state, err := ioutil.ReadFile(fmt.Sprintf("/sys/block/%s/device/state", p))
+		if err != nil {
+			continue
+		}
+
+		// the scsi device is active when it's running, and the nvme device is active when it's live
+		if devState := strings.TrimSpace(string(state)); devState == "running" || devState == "live" {
+			activePaths++
+		}
+	}
+
+	if activePaths == 0 {
+		return true, fmt.Sprintf("the multipath device %s of volume path %s has no active path, paths: %v",
+			dm, volumePath, paths)
+	}
+	return false, ""
+}
+
 func getDeviceFromMountMap(targetPath string, mountMap map[string]string) string {
 	var device string
 	for mountPath, devPath := range mountMap {
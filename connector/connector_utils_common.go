@@ -20,10 +20,12 @@ package connector
 import (
 	"context"
 	"strings"
+	"time"
 
 	"huawei-csi-driver/connector/utils/lock"
 	"huawei-csi-driver/utils"
 	"huawei-csi-driver/utils/log"
+	"huawei-csi-driver/utils/metrics"
 )
 
 // GetPhysicalDevices to get physical devices
@@ -104,7 +106,10 @@ func DisConnectVolumeCommon(ctx context.Context,
 		}
 	}()
 
-	return f(ctx, tgtLunWWN)
+	start := time.Now()
+	err = f(ctx, tgtLunWWN)
+	metrics.ObserveConnectorOperation(protocol, "disconnect", start, err)
+	return err
 }
 
 // CheckHostConnectivity used to check host connectivity
@@ -139,5 +144,8 @@ func ConnectVolumeCommon(ctx context.Context,
 		}
 	}()
 
-	return f(ctx, conn)
+	start := time.Now()
+	devPath, err := f(ctx, conn)
+	metrics.ObserveConnectorOperation(protocol, "connect", start, err)
+	return devPath, err
 }
@@ -16,3 +16,12 @@
 
 // Package fibrechannel provide the way to connect/disconnect volume within FC protocol
 package fibrechannel
+
+const (
+	// FCHostsCmd is the command to list the FC hosts of the node
+	FCHostsCmd = "ls /sys/class/fc_host/"
+	// FCPortStateCmd is the command to get the port state of the FC host
+	FCPortStateCmd = "cat /sys/class/fc_host/%s/port_state"
+	// FCPortOnline is the port state of the available FC host
+	FCPortOnline = "Online"
+)
@@ -234,7 +234,7 @@ func getHostAttrName(ctx context.Context, host, portAttr string) (string, error)
 }
 
 func isPortOnline(ctx context.Context, host string) (bool, error) {
-	output, err := utils.ExecShellCmd(ctx, "cat /sys/class/fc_host/%s/port_state", host)
+	output, err := utils.ExecShellCmd(ctx, FCPortStateCmd, host)
 	if err != nil {
 		return false, err
 	}
@@ -244,7 +244,7 @@ func isPortOnline(ctx context.Context, host string) (bool, error) {
 		if line == "" {
 			continue
 		}
-		return line == "Online", nil
+		return line == FCPortOnline, nil
 	}
 
 	return false, errors.New("check port state error")
@@ -263,7 +263,7 @@ func getClassDevicePath(ctx context.Context, host string) (string, error) {
 }
 
 func getAllFcHosts(ctx context.Context) ([]string, error) {
-	output, err := utils.ExecShellCmd(ctx, "ls /sys/class/fc_host/")
+	output, err := utils.ExecShellCmd(ctx, FCHostsCmd)
 	if err != nil {
 		return nil, err
 	}
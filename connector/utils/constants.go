@@ -16,3 +16,6 @@
 
 // Package utils provides common utils for connector
 package utils
+
+// ServiceStatusCmd is the command to get the active line of the status of the service
+const ServiceStatusCmd = "systemctl status %s | grep Active"
@@ -81,7 +81,7 @@ func getServiceStates(service string) (string, string, error) {
 	var state string
 	var subState string
 
-	queryCmd := fmt.Sprintf("systemctl status %s | grep Active", service)
+	queryCmd := fmt.Sprintf(ServiceStatusCmd, service)
 	output, err := utils.ExecShellCmd(context.Background(), queryCmd)
 	if err != nil {
 		if err.Error() == "exit status 1" && strings.Contains(strings.ToLower(output), "could not be found") {
@@ -96,6 +96,15 @@ func getServiceStates(service string) (string, string, error) {
 		return state, subState, errors.New("query service status empty")
 	}
 
+	return ParseServiceStates(output)
+}
+
+// ParseServiceStates is used to parse the state and the sub state from the output of the ServiceStatusCmd,
+// e.g. "Active: active (running)"
+func ParseServiceStates(output string) (string, string, error) {
+	var state string
+	var subState string
+
 	for _, line := range strings.Split(output, "\n") {
 		pattern, err := regexp.Compile(`^[\s]*Active: ([\w]+) \(([\w]+)\)`)
 		if err != nil {
@@ -40,16 +40,21 @@ type serviceConfig struct {
 
 	Endpoint         string
 	DrEndpoint       string
+	MetricsAddress   string
+	MetricsPath      string
+	LivenessAddress  string
 	DriverName       string
 	KubeConfig       string
 	NodeName         string
 	KubeletRootDir   string
 	VolumeNamePrefix string
 
-	MaxVolumesPerNode     int
-	WebHookPort           int
-	WorkerThreads         int
-	BackendUpdateInterval int
+	MaxVolumesPerNode          int
+	WebHookPort                int
+	WorkerThreads              int
+	BackendUpdateInterval      int
+	BackendHealthCheckInterval int
+	ReplicationSyncInterval    int
 
 	LeaderLeaseDuration time.Duration
 	LeaderRenewDeadline time.Duration
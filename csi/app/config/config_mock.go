@@ -53,16 +53,21 @@ func mockServiceConfig() serviceConfig {
 
 		Endpoint:         "",
 		DrEndpoint:       "",
+		MetricsAddress:   "",
+		MetricsPath:      "",
+		LivenessAddress:  "",
 		DriverName:       "",
 		KubeConfig:       "",
 		NodeName:         "",
 		KubeletRootDir:   "",
 		VolumeNamePrefix: "",
 
-		MaxVolumesPerNode:     0,
-		WebHookPort:           0,
-		WorkerThreads:         0,
-		BackendUpdateInterval: 0,
+		MaxVolumesPerNode:          0,
+		WebHookPort:                0,
+		WorkerThreads:              0,
+		BackendUpdateInterval:      0,
+		BackendHealthCheckInterval: 0,
+		ReplicationSyncInterval:    0,
 	}
 }
 
@@ -38,15 +38,20 @@ type serviceOptions struct {
 	driverName       string
 	endpoint         string
 	drEndpoint       string
+	metricsAddress   string
+	metricsPath      string
+	livenessAddress  string
 	kubeConfig       string
 	nodeName         string
 	kubeletRootDir   string
 	volumeNamePrefix string
 
-	maxVolumesPerNode     int
-	webHookPort           int
-	backendUpdateInterval int
-	workerThreads         int
+	maxVolumesPerNode          int
+	webHookPort                int
+	backendUpdateInterval      int
+	backendHealthCheckInterval int
+	replicationSyncInterval    int
+	workerThreads              int
 
 	leaderLeaseDuration time.Duration
 	leaderRenewDeadline time.Duration
@@ -68,6 +73,16 @@ func (opt *serviceOptions) AddFlags(ff *flag.FlagSet) {
 	ff.StringVar(&opt.drEndpoint, "dr-endpoint",
 		"/var/lib/kubelet/plugins/huawei.csi.driver/dr-csi.sock",
 		"DR CSI endpoint")
+	ff.StringVar(&opt.metricsAddress, "metrics-address",
+		"",
+		"The address to expose the prometheus metrics, e.g. :8686. Metrics are disabled if it is empty")
+	ff.StringVar(&opt.metricsPath, "metrics-path",
+		"/metrics",
+		"The HTTP path to expose the prometheus metrics")
+	ff.StringVar(&opt.livenessAddress, "liveness-address",
+		"",
+		"The address to expose the liveness endpoint /livez, which does not check the backends, e.g. :9809. "+
+			"Disabled if it is empty")
 	ff.BoolVar(&opt.controller, "controller",
 		false,
 		"Run as a controller service")
@@ -77,6 +92,12 @@ func (opt *serviceOptions) AddFlags(ff *flag.FlagSet) {
 	ff.IntVar(&opt.backendUpdateInterval, "backend-update-interval",
 		60,
 		"The interval seconds to update backends status. Default is 60 seconds")
+	ff.IntVar(&opt.backendHealthCheckInterval, "backend-health-check-interval",
+		10,
+		"The interval seconds to probe the management urls of backends. Health check is disabled if it is 0")
+	ff.IntVar(&opt.replicationSyncInterval, "replication-sync-interval",
+		300,
+		"The interval seconds to report the hyperMetro and replication pairs of volumes. Disabled if it is 0")
 	ff.StringVar(&opt.kubeConfig, "kubeconfig",
 		"",
 		"absolute path to the kubeconfig file")
@@ -121,9 +142,14 @@ func (opt *serviceOptions) AddFlags(ff *flag.FlagSet) {
 func (opt *serviceOptions) ApplyFlags(cfg *config.Config) {
 	cfg.Endpoint = opt.endpoint
 	cfg.DrEndpoint = opt.drEndpoint
+	cfg.MetricsAddress = opt.metricsAddress
+	cfg.MetricsPath = opt.metricsPath
+	cfg.LivenessAddress = opt.livenessAddress
 	cfg.Controller = opt.controller
 	cfg.DriverName = opt.driverName
 	cfg.BackendUpdateInterval = opt.backendUpdateInterval
+	cfg.BackendHealthCheckInterval = opt.backendHealthCheckInterval
+	cfg.ReplicationSyncInterval = opt.replicationSyncInterval
 	cfg.KubeConfig = opt.kubeConfig
 	cfg.NodeName = opt.nodeName
 	cfg.KubeletRootDir = opt.kubeletRootDir
@@ -35,6 +35,7 @@ import (
 	"huawei-csi-driver/utils"
 	"huawei-csi-driver/utils/k8sutils"
 	"huawei-csi-driver/utils/log"
+	"huawei-csi-driver/utils/metrics"
 )
 
 const (
@@ -70,6 +71,13 @@ var (
 		{"nfsProtocol", filterByNFSProtocol},
 	}
 
+	capacityFilterFuncs = [][]interface{}{
+		{"backend", filterByBackendName},
+		{"pool", filterByStoragePool},
+		{"volumeType", filterByVolumeType},
+		{"allocType", filterByAllocType},
+	}
+
 	secondaryFilterFuncs = [][]interface{}{
 		{"volumeType", filterByVolumeType},
 		{"allocType", filterByAllocType},
@@ -103,6 +111,13 @@ type Backend struct {
 	SupportedTopologies []map[string]string
 	AccountName         string
 
+	// ConfigmapMeta and SecretMeta are the configmap and secret the backend is registered with
+	ConfigmapMeta string
+	SecretMeta    string
+	// PasswordRotationDays is the interval the password of the storage account is rotated by the driver,
+	// 0 means the password is never rotated by the driver
+	PasswordRotationDays int
+
 	MetroDomain       string
 	MetrovStorePairID string
 	MetroBackendName  string
@@ -199,18 +214,25 @@ func NewBackend(backendName string, config map[string]interface{}) (*Backend, er
 		return nil, fmt.Errorf("hyperMetro configuration in backend %s is incorrect", backendName)
 	}
 
+	passwordRotationDays, err := getPasswordRotationDays(config)
+	if err != nil {
+		return nil, fmt.Errorf("passwordRotationDays configuration in backend %s is incorrect: %v",
+			backendName, err)
+	}
+
 	return &Backend{
-		Name:                backendName,
-		Storage:             storage,
-		Available:           false,
-		SupportedTopologies: supportedTopologies,
-		Plugin:              targetPlugin,
-		Parameters:          parameters,
-		MetroDomain:         metroDomain,
-		MetrovStorePairID:   metrovStorePairID,
-		ReplicaBackendName:  replicaBackend,
-		MetroBackendName:    metroBackend,
-		AccountName:         accountName,
+		Name:                 backendName,
+		Storage:              storage,
+		Available:            false,
+		SupportedTopologies:  supportedTopologies,
+		Plugin:               targetPlugin,
+		Parameters:           parameters,
+		MetroDomain:          metroDomain,
+		MetrovStorePairID:    metrovStorePairID,
+		ReplicaBackendName:   replicaBackend,
+		MetroBackendName:     metroBackend,
+		AccountName:          accountName,
+		PasswordRotationDays: passwordRotationDays,
 	}, nil
 }
 
@@ -903,6 +925,48 @@ func filterByCapacity(requestSize int64, allocType string, candidatePools []*Sto
 	return filterPools
 }
 
+// GetPoolsCapacity used to get the total and the maximum free capacity of the available pools
+// which match the parameters and the accessible topology
+var GetPoolsCapacity = func(ctx context.Context, parameters map[string]interface{},
+	topology map[string]string) (int64, int64, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var candidatePools []*StoragePool
+	for _, backend := range csiBackends {
+		if backend.Available {
+			candidatePools = append(candidatePools, backend.Pools...)
+		}
+	}
+
+	var err error
+	for _, i := range capacityFilterFuncs {
+		key, filter := i[0].(string), i[1].(func(context.Context, string, []*StoragePool) ([]*StoragePool, error))
+		value, _ := parameters[key].(string)
+		candidatePools, err = filter(ctx, value, candidatePools)
+		if err != nil {
+			return 0, 0, fmt.Errorf("filter pool by %s failed, error: %v", key, err)
+		}
+	}
+
+	if len(topology) != 0 {
+		candidatePools = filterPoolsOnTopology(candidatePools, []map[string]string{topology})
+	}
+
+	var totalCapacity, maximumCapacity int64
+	for _, pool := range candidatePools {
+		freeCapacity, _ := pool.Capabilities["FreeCapacity"].(int64)
+		totalCapacity += freeCapacity
+		if freeCapacity > maximumCapacity {
+			maximumCapacity = freeCapacity
+		}
+	}
+
+	log.AddContext(ctx).Debugf("Get capacity of %d pools, total: %d, maximum: %d",
+		len(candidatePools), totalCapacity, maximumCapacity)
+	return totalCapacity, maximumCapacity, nil
+}
+
 func weightByFreeCapacity(candidatePools []*StoragePool) *StoragePool {
 	var selectPool *StoragePool
 
@@ -1026,6 +1090,8 @@ func RegisterOneBackend(ctx context.Context, backendID, configmapMeta, secretMet
 	if err != nil {
 		return "", err
 	}
+	bk.ConfigmapMeta = configmapMeta
+	bk.SecretMeta = secretMeta
 
 	err = analyzePools(bk, storageInfo)
 	if err != nil {
@@ -1112,6 +1178,8 @@ func RemoveOneBackend(ctx context.Context, storageBackendId string) {
 		storageBackendId, csiBackends)
 
 	finalizers.RemoveStorageBackendMutex(ctx, storageBackendId)
+	metrics.DeleteBackend(pkgUtils.MakeMetaWithNamespace(app.GetGlobalConfig().Namespace, storageBackendId))
+	removeBackendHealth(storageBackendId)
 	return
 }
 
@@ -22,11 +22,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	coreV1 "k8s.io/api/core/v1"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 
+	xuanwuv1 "huawei-csi-driver/client/apis/xuanwu/v1"
 	"huawei-csi-driver/csi/app"
+	"huawei-csi-driver/pkg/constants"
 	pkgUtils "huawei-csi-driver/pkg/utils"
 	"huawei-csi-driver/utils/log"
 )
@@ -78,6 +83,69 @@ func GetBackendCapabilities(ctx context.Context, storageBackendId string) (map[s
 	return capabilityMap, specificationMap, nil
 }
 
+// GetBackendPools used to get the capacity and provisioning types of the pools of the backend, the capacity
+// is the one refreshed by the backend update interval
+func GetBackendPools(ctx context.Context, backendName string) ([]map[string]string, error) {
+	backend := GetBackendWithFresh(ctx, backendName, false)
+	if backend == nil {
+		return nil, pkgUtils.Errorf(ctx, "Failed to get backend %s", backendName)
+	}
+
+	poolType := constants.BlockPoolType
+	if !strings.HasSuffix(backend.Storage, "-san") {
+		poolType = constants.FilePoolType
+	}
+
+	var pools []map[string]string
+	for _, pool := range backend.Pools {
+		totalCapacity, _ := pool.Capabilities["TotalCapacity"].(int64)
+		freeCapacity, _ := pool.Capabilities["FreeCapacity"].(int64)
+		supportThin, _ := pool.Capabilities["SupportThin"].(bool)
+		supportThick, _ := pool.Capabilities["SupportThick"].(bool)
+		pools = append(pools, map[string]string{
+			constants.PoolName:          pool.Name,
+			constants.PoolType:          poolType,
+			constants.PoolTotalCapacity: strconv.FormatInt(totalCapacity, 10),
+			constants.PoolFreeCapacity:  strconv.FormatInt(freeCapacity, 10),
+			constants.PoolSupportThin:   strconv.FormatBool(supportThin),
+			constants.PoolSupportThick:  strconv.FormatBool(supportThick),
+		})
+	}
+
+	return pools, nil
+}
+
+// CheckBackendPools used to check whether all configured pools of the backend exist on the storage
+func CheckBackendPools(ctx context.Context, backendName string) error {
+	backend := GetBackendWithFresh(ctx, backendName, false)
+	if backend == nil {
+		return pkgUtils.Errorf(ctx, "Failed to get backend %s", backendName)
+	}
+
+	var poolNames []string
+	for _, pool := range backend.Pools {
+		poolNames = append(poolNames, pool.Name)
+	}
+	poolCapabilities, err := backend.Plugin.UpdatePoolCapabilities(poolNames)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Cannot update pool capabilities of backend %s: %v", backendName, err)
+		return err
+	}
+
+	var notFoundPools []string
+	for _, name := range poolNames {
+		if _, exist := poolCapabilities[name]; !exist {
+			notFoundPools = append(notFoundPools, name)
+		}
+	}
+	if len(notFoundPools) != 0 {
+		return pkgUtils.NewBackendError(xuanwuv1.ConditionPoolsAvailable, xuanwuv1.ReasonPoolsNotFound,
+			fmt.Errorf("pools %v of backend %s do not exist on the storage", notFoundPools, backendName))
+	}
+
+	return nil
+}
+
 // GetBackendWithFresh used to obtain registered backends
 var GetBackendWithFresh = func(ctx context.Context, backendName string, update bool) *Backend {
 	// Registered backend exists in the cache.
@@ -105,6 +173,24 @@ var GetBackendWithFresh = func(ctx context.Context, backendName string, update b
 	return csiBackends[backendName]
 }
 
+// GetAvailableBackends used to obtain all registered and available backends, sorted by backend name
+var GetAvailableBackends = func() []*Backend {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var backends []*Backend
+	for _, backend := range csiBackends {
+		if backend.Available {
+			backends = append(backends, backend)
+		}
+	}
+
+	sort.Slice(backends, func(i, j int) bool {
+		return backends[i].Name < backends[j].Name
+	})
+	return backends
+}
+
 func isBackendOnline(ctx context.Context, claimNameMeta string) bool {
 	log.AddContext(ctx).Infof("Start to check storageBackendContent: [%s] Online status.", claimNameMeta)
 
@@ -207,6 +293,22 @@ func GetStorageBackendInfo(ctx context.Context, backendID, configmapMeta, secret
 	}
 
 	backendMapData["backendID"] = backendID
+	addMaxClientThreads(ctx, backendID, backendMapData)
 
 	return backendMapData, nil
 }
+
+// addMaxClientThreads used to override the maxClientThreads of the configmap by the one of the
+// storageBackendContent, so that each backend limits its own concurrent requests to the storage
+func addMaxClientThreads(ctx context.Context, backendID string, storageConfig map[string]interface{}) {
+	content, err := pkgUtils.GetContentByClaimMeta(ctx, backendID)
+	if err != nil {
+		log.AddContext(ctx).Infof("Get storageBackendContent of %s failed, use the maxClientThreads of "+
+			"configmap, error: %v", backendID, err)
+		return
+	}
+
+	if content.Spec.MaxClientThreads != "" {
+		storageConfig["maxClientThreads"] = content.Spec.MaxClientThreads
+	}
+}
@@ -22,16 +22,23 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/prashantv/gostub"
 	. "github.com/smartystreets/goconvey/convey"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	xuanwuv1 "huawei-csi-driver/client/apis/xuanwu/v1"
 	"huawei-csi-driver/csi/app"
 	cfg "huawei-csi-driver/csi/app/config"
+	"huawei-csi-driver/csi/backend/plugin"
 	clientSet "huawei-csi-driver/pkg/client/clientset/versioned"
+	"huawei-csi-driver/pkg/client/clientset/versioned/fake"
 	pkgUtils "huawei-csi-driver/pkg/utils"
+	"huawei-csi-driver/utils"
+	"huawei-csi-driver/utils/k8sutils"
 	"huawei-csi-driver/utils/log"
 )
 
@@ -119,6 +126,11 @@ func TestNewBackend(t *testing.T) {
 			map[string]interface{}{"storage": "oceanstor-san", "parameters": map[string]interface{}{},
 				"metroBackend": "testMetroBackend"},
 			true},
+		{"passwordRotationDaysInvalid",
+			"testBackend",
+			map[string]interface{}{"storage": "oceanstor-san", "parameters": map[string]interface{}{},
+				"passwordRotationDays": "-1"},
+			true},
 	}
 
 	for _, tt := range tests {
@@ -889,3 +901,394 @@ func TestRegisterAllBackend(t *testing.T) {
 		So(RegisterAllBackend(ctx), ShouldBeNil)
 	})
 }
+
+func TestGetPoolsCapacity(t *testing.T) {
+	csiBackends = map[string]*Backend{
+		"backend1": {Name: "backend1", Available: true, Pools: []*StoragePool{
+			{Name: "pool1", Storage: "oceanstor-san", Parent: "backend1",
+				Capabilities: map[string]interface{}{"SupportThin": true, "FreeCapacity": int64(100)}},
+			{Name: "pool2", Storage: "oceanstor-san", Parent: "backend1",
+				Capabilities: map[string]interface{}{"SupportThin": true, "FreeCapacity": int64(300)}},
+		}},
+		"backend2": {Name: "backend2", Available: true, Pools: []*StoragePool{
+			{Name: "pool1", Storage: "oceanstor-nas", Parent: "backend2",
+				Capabilities: map[string]interface{}{"SupportThin": true, "FreeCapacity": int64(500)}},
+		}, SupportedTopologies: []map[string]string{{"zone": "zone1"}}},
+	}
+	defer func() { csiBackends = make(map[string]*Backend) }()
+
+	tests := []struct {
+		name       string
+		parameters map[string]interface{}
+		topology   map[string]string
+		expect     []int64
+	}{
+		{"Lun", map[string]interface{}{}, nil, []int64{400, 300}},
+		{"FileSystem", map[string]interface{}{"volumeType": "fs"}, nil, []int64{500, 500}},
+		{"Pool", map[string]interface{}{"pool": "pool1"}, nil, []int64{100, 100}},
+		{"TopologyMismatch", map[string]interface{}{"volumeType": "fs"},
+			map[string]string{"zone": "zone2"}, []int64{0, 0}},
+		{"Thick", map[string]interface{}{"allocType": "thick"}, nil, []int64{0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			total, maximum, err := GetPoolsCapacity(ctx, tt.parameters, tt.topology)
+			if err != nil || !reflect.DeepEqual([]int64{total, maximum}, tt.expect) {
+				t.Errorf("test GetPoolsCapacity faild. got: [%d %d], expect: %v, err: %v",
+					total, maximum, tt.expect, err)
+			}
+		})
+	}
+}
+
+func TestGetBackendPools(t *testing.T) {
+	csiBackends = map[string]*Backend{
+		"backend1": {Name: "backend1", Storage: "oceanstor-nas", Pools: []*StoragePool{
+			{Name: "pool1", Storage: "oceanstor-nas", Parent: "backend1",
+				Capabilities: map[string]interface{}{"SupportThin": true, "FreeCapacity": int64(100),
+					"TotalCapacity": int64(400)}},
+		}},
+	}
+	defer func() { csiBackends = make(map[string]*Backend) }()
+
+	pools, err := GetBackendPools(ctx, "backend1")
+	expect := []map[string]string{{"Name": "pool1", "Type": "file", "TotalCapacity": "400",
+		"FreeCapacity": "100", "SupportThin": "true", "SupportThick": "false"}}
+	if err != nil || !reflect.DeepEqual(pools, expect) {
+		t.Errorf("test GetBackendPools faild. got: %v, expect: %v, err: %v", pools, expect, err)
+	}
+
+	if _, err = GetBackendPools(ctx, "backend2"); err == nil {
+		t.Errorf("test GetBackendPools of not registered backend should fail")
+	}
+}
+
+func TestCheckBackendHealth(t *testing.T) {
+	nasPlugin := &plugin.OceanstorNasPlugin{}
+	bk := &Backend{Name: "backend1", Storage: "oceanstor-nas", Available: true, Plugin: nasPlugin}
+	csiBackends = map[string]*Backend{"backend1": bk}
+	defer func() { csiBackends = make(map[string]*Backend) }()
+
+	var healths []utils.URLHealth
+	patch := gomonkey.ApplyMethod(reflect.TypeOf(nasPlugin), "CheckHealth",
+		func(_ *plugin.OceanstorNasPlugin, _ context.Context) []utils.URLHealth {
+			return healths
+		})
+	defer patch.Reset()
+
+	healths = []utils.URLHealth{{URL: "https://127.0.0.1:8088", LastError: "unconnected"}}
+	// the availability is read by the pool selection while the health check is running
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		IsAnyBackendAvailable()
+	}()
+	CheckBackendsHealth()
+	<-done
+	if bk.Available || IsAnyBackendAvailable() {
+		t.Errorf("test CheckBackendsHealth with unreachable urls should set backend unavailable")
+	}
+
+	RemoveOneBackend(ctx, "backend1")
+	if !IsAnyBackendAvailable() {
+		t.Errorf("test IsAnyBackendAvailable without backends should be true")
+	}
+}
+
+func TestSecretCredential(t *testing.T) {
+	Convey("Test the credential of the secret", t, func() {
+		oldSecret := &coreV1.Secret{
+			ObjectMeta: metaV1.ObjectMeta{CreationTimestamp: metaV1.NewTime(time.Now().Add(-48 * time.Hour))},
+			Data:       map[string][]byte{"user": []byte("admin"), "password": []byte("old")},
+		}
+
+		newSecret := oldSecret.DeepCopy()
+		newSecret.Data[pendingPasswordKey] = []byte("new")
+		So(isCredentialChanged(oldSecret, newSecret), ShouldBeFalse)
+
+		newSecret.Data["password"] = []byte("new")
+		So(isCredentialChanged(oldSecret, newSecret), ShouldBeTrue)
+
+		So(isPasswordExpired(oldSecret, 1), ShouldBeTrue)
+		So(isPasswordExpired(oldSecret, 3), ShouldBeFalse)
+
+		oldSecret.Annotations = map[string]string{PasswordRotatedAtAnnotation: time.Now().Format(time.RFC3339)}
+		So(isPasswordExpired(oldSecret, 1), ShouldBeFalse)
+	})
+}
+
+func TestRotateBackendPassword(t *testing.T) {
+	tests := []struct {
+		name string
+		// applied is whether the storage changes the password before ChangePassword returns
+		applied      bool
+		changeErr    error
+		reachable    bool
+		wantErr      bool
+		wantPassword string
+		wantPending  bool
+	}{
+		{"Success", true, nil, true, false, "new", false},
+		{"CleanFailure", false, errors.New("wrong old password"), true, true, "old", false},
+		{"AmbiguousFailureApplied", true, errors.New("timeout"), true, false, "new", false},
+		{"AmbiguousFailureUnreachable", true, errors.New("timeout"), false, true, "old", true},
+	}
+
+	var stored *coreV1.Secret
+	getPatch := gomonkey.ApplyMethod(reflect.TypeOf(app.GetGlobalConfig().K8sUtils), "GetSecret",
+		func(_ *k8sutils.KubeClient, _ context.Context, _, _ string) (*coreV1.Secret, error) {
+			return stored.DeepCopy(), nil
+		})
+	defer getPatch.Reset()
+	updatePatch := gomonkey.ApplyMethod(reflect.TypeOf(app.GetGlobalConfig().K8sUtils), "UpdateSecret",
+		func(_ *k8sutils.KubeClient, _ context.Context, secret *coreV1.Secret) (*coreV1.Secret, error) {
+			stored = secret.DeepCopy()
+			return secret, nil
+		})
+	defer updatePatch.Reset()
+
+	var storagePassword string
+	var applied, reachable bool
+	var changeErr error
+	sanPlugin := &plugin.OceanstorSanPlugin{}
+	changePatch := gomonkey.ApplyMethod(reflect.TypeOf(&plugin.OceanstorPlugin{}), "ChangePassword",
+		func(_ *plugin.OceanstorPlugin, _ context.Context, oldPassword, newPassword string) error {
+			if applied && oldPassword == storagePassword {
+				storagePassword = newPassword
+			}
+			return changeErr
+		})
+	defer changePatch.Reset()
+	verifyPatch := gomonkey.ApplyMethod(reflect.TypeOf(&plugin.OceanstorPlugin{}), "VerifyPassword",
+		func(_ *plugin.OceanstorPlugin, _ context.Context, password string) error {
+			if !reachable {
+				return errors.New("unconnected")
+			}
+			if password != storagePassword {
+				return errors.New("wrong password")
+			}
+			return nil
+		})
+	defer verifyPatch.Reset()
+
+	bk := &Backend{Name: "backend1", Storage: "oceanstor-san", Plugin: sanPlugin, SecretMeta: "huawei-csi/secret1",
+		PasswordRotationDays: 1}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored = &coreV1.Secret{
+				ObjectMeta: metaV1.ObjectMeta{Name: "secret1", Namespace: "huawei-csi",
+					CreationTimestamp: metaV1.NewTime(time.Now().Add(-48 * time.Hour))},
+				Data: map[string][]byte{"user": []byte("admin"), "password": []byte("old")},
+			}
+			storagePassword, applied, reachable, changeErr = "old", tt.applied, tt.reachable, tt.changeErr
+
+			err := rotateBackendPassword(ctx, bk)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rotateBackendPassword error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			wantPassword := tt.wantPassword
+			if wantPassword == "new" {
+				wantPassword = storagePassword
+			}
+			if string(stored.Data["password"]) != wantPassword {
+				t.Errorf("password in secret is %s, want %s", stored.Data["password"], wantPassword)
+			}
+
+			pending, exist := stored.Data[pendingPasswordKey]
+			if exist != tt.wantPending {
+				t.Errorf("pending password exists: %v, want %v", exist, tt.wantPending)
+			}
+			if exist && string(pending) != storagePassword {
+				t.Errorf("pending password should be the password of the storage")
+			}
+		})
+	}
+}
+
+func TestSyncVolumeReplicationStatuses(t *testing.T) {
+	config := cfg.MockCompletedConfig()
+	backendUtils := fake.NewSimpleClientset()
+	config.BackendUtils = backendUtils
+	stub := gostub.StubFunc(&app.GetGlobalConfig, config)
+	defer stub.Reset()
+
+	sanPlugin := &plugin.OceanstorSanPlugin{}
+	csiBackends = map[string]*Backend{"backend1": {Name: "backend1", Available: true, Plugin: sanPlugin}}
+	defer func() { csiBackends = make(map[string]*Backend) }()
+
+	pv := coreV1.PersistentVolume{ObjectMeta: metaV1.ObjectMeta{Name: "pvc-1"}}
+	pv.Spec.CSI = &coreV1.CSIPersistentVolumeSource{VolumeHandle: "backend1.pvc-1"}
+	pvs := []coreV1.PersistentVolume{pv}
+	listPatch := gomonkey.ApplyMethod(reflect.TypeOf(config.K8sUtils), "ListVolumesByDriver",
+		func(_ *k8sutils.KubeClient, _ context.Context, _ string) ([]coreV1.PersistentVolume, error) {
+			return pvs, nil
+		})
+	defer listPatch.Reset()
+
+	pairs := []map[string]interface{}{{"Type": "Replication", "ID": "1", "RunningStatus": "Split",
+		"RemoteDeviceSN": "2102351", "LastSyncTime": "1700000000"}}
+	queryPatch := gomonkey.ApplyMethod(reflect.TypeOf(sanPlugin), "QueryVolumePairs",
+		func(_ *plugin.OceanstorSanPlugin, _ context.Context, _ string) ([]map[string]interface{}, error) {
+			return pairs, nil
+		})
+	defer queryPatch.Reset()
+
+	statusClient := backendUtils.XuanwuV1().VolumeReplicationStatuses(config.Namespace)
+	Convey("Test sync the volume replication statuses", t, func() {
+		SyncVolumeReplicationStatuses(ctx)
+		status, err := statusClient.Get(ctx, "pvc-1", metaV1.GetOptions{})
+		So(err, ShouldBeNil)
+		So(status.Spec.VolumeHandle, ShouldEqual, "backend1.pvc-1")
+		So(status.Status.Pairs, ShouldHaveLength, 1)
+		So(status.Status.Pairs[0].RunningStatus, ShouldEqual, "Split")
+		So(status.Status.Pairs[0].LastSyncTime.Unix(), ShouldEqual, 1700000000)
+
+		csiBackends["backend1"].Available = false
+		SyncVolumeReplicationStatuses(ctx)
+		status, err = statusClient.Get(ctx, "pvc-1", metaV1.GetOptions{})
+		So(err, ShouldBeNil)
+		So(status.Status.Pairs, ShouldHaveLength, 1)
+		So(status.Status.Message, ShouldNotBeEmpty)
+
+		pvs = nil
+		SyncVolumeReplicationStatuses(ctx)
+		_, err = statusClient.Get(ctx, "pvc-1", metaV1.GetOptions{})
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestRevertVolumeToSnapshot(t *testing.T) {
+	sanPlugin := &plugin.OceanstorSanPlugin{}
+	csiBackends = map[string]*Backend{"backend1": {Name: "backend1", Available: true, Plugin: sanPlugin}}
+	defer func() { csiBackends = make(map[string]*Backend) }()
+
+	var parentID, snapshotName string
+	revertPatch := gomonkey.ApplyMethod(reflect.TypeOf(sanPlugin), "RevertVolumeToSnapshot",
+		func(_ *plugin.OceanstorSanPlugin, _ context.Context, _, id, name string) error {
+			parentID, snapshotName = id, name
+			return nil
+		})
+	defer revertPatch.Reset()
+
+	Convey("Test revert the volume to the snapshot", t, func() {
+		err := RevertVolumeToSnapshot(ctx, "backend1.pvc-1", "backend1.11.snapshot-1")
+		So(err, ShouldBeNil)
+		So(parentID, ShouldEqual, "11")
+		So(snapshotName, ShouldEqual, "snapshot-1")
+
+		err = RevertVolumeToSnapshot(ctx, "backend1.pvc-1", "backend2.11.snapshot-1")
+		So(err, ShouldBeError)
+
+		csiBackends["backend1"].Available = false
+		err = RevertVolumeToSnapshot(ctx, "backend1.pvc-1", "backend1.11.snapshot-1")
+		So(err, ShouldBeError)
+	})
+}
+
+func TestRevertVolumeClaimInUse(t *testing.T) {
+	config := cfg.MockCompletedConfig()
+	stub := gostub.StubFunc(&app.GetGlobalConfig, config)
+	defer stub.Reset()
+
+	usersPatch := gomonkey.ApplyMethod(reflect.TypeOf(config.K8sUtils), "ListVolumeClaimUsers",
+		func(_ *k8sutils.KubeClient, _ context.Context, _ *coreV1.PersistentVolumeClaim) ([]string, error) {
+			return []string{"pod default/mysql-0"}, nil
+		})
+	defer usersPatch.Reset()
+
+	Convey("Test revert the volume of the PVC which is in use", t, func() {
+		pvc := &coreV1.PersistentVolumeClaim{ObjectMeta: metaV1.ObjectMeta{Name: "data", Namespace: "default"}}
+		err := revertVolumeClaimToSnapshot(ctx, pvc, "backend1.pvc-1", "snapshot-1")
+		So(err, ShouldBeError)
+		So(err.Error(), ShouldContainSubstring, "pod default/mysql-0")
+	})
+}
+
+func TestModifyVolumeQoS(t *testing.T) {
+	sanPlugin := &plugin.OceanstorSanPlugin{}
+	csiBackends = map[string]*Backend{"backend1": {Name: "backend1", Available: true, Plugin: sanPlugin}}
+	defer func() { csiBackends = make(map[string]*Backend) }()
+
+	var volName, qos string
+	modifyPatch := gomonkey.ApplyMethod(reflect.TypeOf(sanPlugin), "ModifyVolumeQoS",
+		func(_ *plugin.OceanstorSanPlugin, _ context.Context, name, qosConfig string) error {
+			volName, qos = name, qosConfig
+			return nil
+		})
+	defer modifyPatch.Reset()
+
+	Convey("Test modify the qos of the volume", t, func() {
+		err := ModifyVolumeQoS(ctx, "backend1.pvc-1", `{"MAXIOPS":1000}`)
+		So(err, ShouldBeNil)
+		So(volName, ShouldEqual, "pvc-1")
+		So(qos, ShouldEqual, `{"MAXIOPS":1000}`)
+
+		err = ModifyVolumeQoS(ctx, "backend2.pvc-1", `{"MAXIOPS":1000}`)
+		So(err, ShouldBeError)
+	})
+}
+
+func TestIsVolumeClaimQoSChanged(t *testing.T) {
+	Convey("Test whether the qos annotation of the PVC is applied", t, func() {
+		pvc := &coreV1.PersistentVolumeClaim{}
+		So(isVolumeClaimQoSChanged(pvc), ShouldBeFalse)
+
+		pvc.Annotations = map[string]string{QoSAnnotation: ""}
+		So(isVolumeClaimQoSChanged(pvc), ShouldBeTrue)
+
+		pvc.Annotations[ObservedQoSAnnotation] = ""
+		So(isVolumeClaimQoSChanged(pvc), ShouldBeFalse)
+
+		pvc.Annotations[QoSAnnotation] = `{"MAXIOPS":1000}`
+		So(isVolumeClaimQoSChanged(pvc), ShouldBeTrue)
+	})
+}
+
+func TestVolumeClaimEventHandlerStopsWithLeaderContext(t *testing.T) {
+	handled := make(chan string, 1)
+	patches := gomonkey.ApplyFunc(getVolumeHandleOfClaim,
+		func(_ context.Context, pvc *coreV1.PersistentVolumeClaim) (string, bool) {
+			handled <- pvc.Name
+			return "", false
+		})
+	defer patches.Reset()
+
+	cases := []struct {
+		name        string
+		annotations map[string]string
+	}{
+		{"Revert", map[string]string{RevertToSnapshotAnnotation: "snapshot"}},
+		{"QoS", map[string]string{QoSAnnotation: `{"MAXIOPS":1000}`}},
+	}
+
+	for _, c := range cases {
+		pvc := &coreV1.PersistentVolumeClaim{ObjectMeta: metaV1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "pvc-" + c.name,
+			Annotations: c.annotations,
+		}}
+
+		leaderCtx, cancel := context.WithCancel(context.Background())
+		NewVolumeClaimEventHandler(leaderCtx).OnAdd(pvc)
+		select {
+		case name := <-handled:
+			if name != pvc.Name {
+				t.Errorf("Test case %s failed, handled %s", c.name, name)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("Test case %s failed, the leader does not handle the PVC", c.name)
+		}
+
+		// wait for the task of the PVC to finish, so the next event is not skipped as a running task
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+		NewVolumeClaimEventHandler(leaderCtx).OnUpdate(pvc, pvc)
+		select {
+		case name := <-handled:
+			t.Errorf("Test case %s failed, %s is handled after the leadership is lost", c.name, name)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
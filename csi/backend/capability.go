@@ -27,6 +27,7 @@ import (
 	"huawei-csi-driver/csi/app"
 	pkgUtils "huawei-csi-driver/pkg/utils"
 	"huawei-csi-driver/utils/log"
+	"huawei-csi-driver/utils/metrics"
 )
 
 func updatePoolCapabilitiesByBackend(backend *Backend, backendCapabilities, poolCapabilities map[string]interface{}) {
@@ -106,7 +107,7 @@ func SyncUpdateCapabilities() error {
 			return err
 		}
 
-		backend.Available = true
+		setBackendAvailable(backend, true)
 	}
 
 	return nil
@@ -134,16 +135,37 @@ func AsyncUpdateCapabilities() {
 			err := updateBackendCapabilities(b, false)
 			if err != nil {
 				log.Warningf("update backend %s capabilities failed, error: %v", b.Name, err)
-				b.Available = false
-			} else {
-				b.Available = true
 			}
+			setBackendAvailable(b, err == nil)
 		}(backend)
 	}
 
 	wait.Wait()
 }
 
+// setBackendAvailable used to set the availability of the backend with the backend lock held, since it is read by
+// the pool selection concurrently. It returns whether the availability is changed.
+func setBackendAvailable(backend *Backend, available bool) bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	changed := backend.Available != available
+	backend.Available = available
+	updateBackendMetrics(backend)
+	return changed
+}
+
+// updateBackendMetrics used to record the availability of the backend and the capacity of its pools
+func updateBackendMetrics(backend *Backend) {
+	backendID := pkgUtils.MakeMetaWithNamespace(app.GetGlobalConfig().Namespace, backend.Name)
+	metrics.SetBackendAvailable(backendID, backend.Available)
+	for _, pool := range backend.Pools {
+		totalCapacity, _ := pool.Capabilities["TotalCapacity"].(int64)
+		freeCapacity, _ := pool.Capabilities["FreeCapacity"].(int64)
+		metrics.SetPoolCapacity(backendID, pool.Name, totalCapacity, freeCapacity)
+	}
+}
+
 // LogoutBackend is to logout all storage backend
 func LogoutBackend() {
 	for _, backend := range csiBackends {
@@ -0,0 +1,283 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package backend
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+
+	"huawei-csi-driver/csi/app"
+	"huawei-csi-driver/csi/backend/plugin"
+	pkgUtils "huawei-csi-driver/pkg/utils"
+	"huawei-csi-driver/utils/log"
+	"huawei-csi-driver/utils/pwd"
+)
+
+const (
+	// PasswordRotatedAtAnnotation records the time the password in the secret is rotated by the driver
+	PasswordRotatedAtAnnotation = "xuanwu.huawei.io/password-rotated-at"
+	// pendingPasswordKey keeps the new password in the secret until the rotation is finished, so that the
+	// password is not lost if the driver exits after the password of the storage is changed
+	pendingPasswordKey = "pendingPassword"
+
+	rotatedPasswordLength = 16
+	hoursPerDay           = 24
+)
+
+// getPasswordRotationDays used to parse the passwordRotationDays of the backend config
+func getPasswordRotationDays(config map[string]interface{}) (int, error) {
+	var days int
+	switch value := config["passwordRotationDays"].(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		days = int(value)
+	case string:
+		var err error
+		if days, err = strconv.Atoi(value); err != nil {
+			return 0, fmt.Errorf("%s is not an integer", value)
+		}
+	default:
+		return 0, fmt.Errorf("%v is not an integer", value)
+	}
+
+	if days < 0 {
+		return 0, fmt.Errorf("%d can not be negative", days)
+	}
+	return days, nil
+}
+
+// UpdateBackendCredential used to log in the storage with the credential of the secret, the backend keeps the
+// current session if the login fails
+func UpdateBackendCredential(ctx context.Context, backendID, configmapMeta, secretMeta string) error {
+	_, backendName, err := pkgUtils.SplitMetaNamespaceKey(backendID)
+	if err != nil {
+		return fmt.Errorf("split backend id %s failed, error: %v", backendID, err)
+	}
+
+	mutex.Lock()
+	bk, exist := csiBackends[backendName]
+	mutex.Unlock()
+	if !exist {
+		return fmt.Errorf("backend %s is not registered", backendName)
+	}
+
+	storageInfo, err := GetStorageBackendInfo(ctx, backendID, configmapMeta, secretMeta)
+	if err != nil {
+		return err
+	}
+
+	if err = bk.Plugin.UpdateCredential(ctx, storageInfo); err != nil {
+		return err
+	}
+
+	bk.ConfigmapMeta = configmapMeta
+	bk.SecretMeta = secretMeta
+	log.AddContext(ctx).Infof("The credential of backend %s is updated by secret %s", backendName, secretMeta)
+	return nil
+}
+
+// NewSecretEventHandler returns the handler which updates the credential of the backends once the user or
+// password of their secret is changed
+func NewSecretEventHandler() cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldSecret, ok := oldObj.(*coreV1.Secret)
+			if !ok {
+				return
+			}
+			newSecret, ok := newObj.(*coreV1.Secret)
+			if !ok || !isCredentialChanged(oldSecret, newSecret) {
+				return
+			}
+
+			onSecretCredentialChanged(newSecret)
+		},
+	}
+}
+
+func isCredentialChanged(oldSecret, newSecret *coreV1.Secret) bool {
+	return !bytes.Equal(oldSecret.Data["user"], newSecret.Data["user"]) ||
+		!bytes.Equal(oldSecret.Data["password"], newSecret.Data["password"])
+}
+
+func onSecretCredentialChanged(secret *coreV1.Secret) {
+	secretMeta := pkgUtils.MakeMetaWithNamespace(secret.Namespace, secret.Name)
+	for _, bk := range getBackendsBySecret(secretMeta) {
+		go func(bk *Backend) {
+			ctx := context.Background()
+			backendID := pkgUtils.MakeMetaWithNamespace(app.GetGlobalConfig().Namespace, bk.Name)
+			log.AddContext(ctx).Infof("The credential of secret %s is changed, update backend %s",
+				secretMeta, bk.Name)
+			if err := UpdateBackendCredential(ctx, backendID, bk.ConfigmapMeta, secretMeta); err != nil {
+				log.AddContext(ctx).Errorf("Update credential of backend %s failed, error: %v", bk.Name, err)
+			}
+		}(bk)
+	}
+}
+
+func getBackendsBySecret(secretMeta string) []*Backend {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var backends []*Backend
+	for _, bk := range csiBackends {
+		if bk.SecretMeta == secretMeta {
+			backends = append(backends, bk)
+		}
+	}
+	return backends
+}
+
+// RotateBackendPasswords used to rotate the password of the storage account of the backends whose
+// passwordRotationDays is configured, the new password is written back to the secret of the backend
+func RotateBackendPasswords(ctx context.Context) {
+	mutex.Lock()
+	var backends []*Backend
+	for _, bk := range csiBackends {
+		if bk.PasswordRotationDays > 0 {
+			backends = append(backends, bk)
+		}
+	}
+	mutex.Unlock()
+
+	for _, bk := range backends {
+		if err := rotateBackendPassword(ctx, bk); err != nil {
+			log.AddContext(ctx).Errorf("Rotate password of backend %s failed, error: %v", bk.Name, err)
+		}
+	}
+}
+
+func rotateBackendPassword(ctx context.Context, bk *Backend) error {
+	rotator, ok := bk.Plugin.(plugin.PasswordRotator)
+	if !ok {
+		return fmt.Errorf("the storage %s does not support password rotation", bk.Storage)
+	}
+
+	secret, err := pkgUtils.GetBackendSecret(ctx, bk.SecretMeta)
+	if err != nil {
+		return err
+	}
+
+	if _, exist := secret.Data[pendingPasswordKey]; exist {
+		return fmt.Errorf("the last rotation of secret %s is not finished, check whether the %s in the secret "+
+			"is the password of the storage and set it as the password manually", bk.SecretMeta, pendingPasswordKey)
+	}
+
+	if !isPasswordExpired(secret, bk.PasswordRotationDays) {
+		return nil
+	}
+
+	log.AddContext(ctx).Infof("The password of backend %s is expired, start to rotate it", bk.Name)
+	newPassword, err := pwd.GeneratePassword(rotatedPasswordLength)
+	if err != nil {
+		return err
+	}
+
+	// keep the new password in the secret before the password of the storage is changed, the update fails if
+	// the secret is changed by others since it is read
+	oldPassword := string(secret.Data["password"])
+	secret.Data[pendingPasswordKey] = []byte(newPassword)
+	if _, err = app.GetGlobalConfig().K8sUtils.UpdateSecret(ctx, secret); err != nil {
+		return fmt.Errorf("save the new password to secret %s failed, error: %v", bk.SecretMeta, err)
+	}
+
+	if err = rotator.ChangePassword(ctx, oldPassword, newPassword); err != nil {
+		return onChangePasswordFailed(ctx, bk, rotator, oldPassword, newPassword, err)
+	}
+
+	return finishPasswordRotation(ctx, bk, newPassword)
+}
+
+// onChangePasswordFailed used to find out whether the password of the storage is changed, since the storage may
+// have changed it even if the response is lost. The pending password is removed only if the old password still
+// works, otherwise it is kept in the secret as the only copy of the password of the storage.
+func onChangePasswordFailed(ctx context.Context, bk *Backend, rotator plugin.PasswordRotator,
+	oldPassword, newPassword string, changeErr error) error {
+	oldErr := rotator.VerifyPassword(ctx, oldPassword)
+	if oldErr == nil {
+		if clearErr := setSecretPassword(ctx, bk.SecretMeta, ""); clearErr != nil {
+			log.AddContext(ctx).Warningf("Clear the new password of secret %s failed, error: %v",
+				bk.SecretMeta, clearErr)
+		}
+		return changeErr
+	}
+
+	if newErr := rotator.VerifyPassword(ctx, newPassword); newErr == nil {
+		log.AddContext(ctx).Warningf("Change password of backend %s returns error %v, but the new password "+
+			"is valid", bk.Name, changeErr)
+		return finishPasswordRotation(ctx, bk, newPassword)
+	}
+
+	return fmt.Errorf("change password failed and neither the old nor the new password can login the storage, "+
+		"the new password is kept as %s in secret %s, error: %v, login with the old password error: %v",
+		pendingPasswordKey, bk.SecretMeta, changeErr, oldErr)
+}
+
+func finishPasswordRotation(ctx context.Context, bk *Backend, newPassword string) error {
+	if err := setSecretPassword(ctx, bk.SecretMeta, newPassword); err != nil {
+		return fmt.Errorf("the password of the storage is changed, but set it to secret %s failed, it is saved "+
+			"as %s in the secret, error: %v", bk.SecretMeta, pendingPasswordKey, err)
+	}
+
+	log.AddContext(ctx).Infof("The password of backend %s is rotated", bk.Name)
+	return nil
+}
+
+func isPasswordExpired(secret *coreV1.Secret, rotationDays int) bool {
+	rotatedAt := secret.CreationTimestamp.Time
+	if value, exist := secret.Annotations[PasswordRotatedAtAnnotation]; exist {
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			rotatedAt = parsed
+		}
+	}
+
+	return time.Since(rotatedAt) >= time.Duration(rotationDays)*hoursPerDay*time.Hour
+}
+
+// setSecretPassword used to remove the pending password of the secret, and set the password if it is not empty
+func setSecretPassword(ctx context.Context, secretMeta, password string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := pkgUtils.GetBackendSecret(ctx, secretMeta)
+		if err != nil {
+			return err
+		}
+		if secret.Data == nil {
+			return errors.New("the data of the secret is empty")
+		}
+
+		delete(secret.Data, pendingPasswordKey)
+		if password != "" {
+			secret.Data["password"] = []byte(password)
+			if secret.Annotations == nil {
+				secret.Annotations = make(map[string]string)
+			}
+			secret.Annotations[PasswordRotatedAtAnnotation] = time.Now().Format(time.RFC3339)
+		}
+
+		_, err = app.GetGlobalConfig().K8sUtils.UpdateSecret(ctx, secret)
+		return err
+	})
+}
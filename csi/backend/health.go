@@ -0,0 +1,128 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package backend
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+
+	"huawei-csi-driver/csi/app"
+	pkgUtils "huawei-csi-driver/pkg/utils"
+	"huawei-csi-driver/utils"
+	"huawei-csi-driver/utils/log"
+)
+
+var (
+	// unreachableBackends records the backends set unavailable by the health check
+	unreachableBackends     = make(map[string]bool)
+	unreachableBackendsLock sync.Mutex
+)
+
+// CheckBackendsHealth used to probe the management urls of all registered backends. A backend is set unavailable
+// once none of its urls is reachable, and becomes available again after its capabilities are updated successfully.
+func CheckBackendsHealth() {
+	mutex.Lock()
+	backends := make([]*Backend, 0, len(csiBackends))
+	for _, backend := range csiBackends {
+		backends = append(backends, backend)
+	}
+	mutex.Unlock()
+
+	var wait sync.WaitGroup
+	for _, backend := range backends {
+		wait.Add(1)
+
+		go func(b *Backend) {
+			defer func() {
+				wait.Done()
+
+				if r := recover(); r != nil {
+					log.Errorf("Runtime error caught in health check routine: %v", r)
+					log.Errorf("%s", debug.Stack())
+				}
+			}()
+
+			checkBackendHealth(b)
+		}(backend)
+	}
+
+	wait.Wait()
+}
+
+func checkBackendHealth(backend *Backend) {
+	healths := backend.Plugin.CheckHealth(context.Background())
+	if healths == nil {
+		return
+	}
+
+	backendID := pkgUtils.MakeMetaWithNamespace(app.GetGlobalConfig().Namespace, backend.Name)
+	utils.SetBackendURLHealth(backendID, healths)
+
+	if !utils.IsAnyURLHealthy(healths) {
+		if setBackendAvailable(backend, false) {
+			log.Warningf("None of the urls of backend %s is reachable, set it unavailable: %+v",
+				backend.Name, healths)
+			setBackendUnreachable(backend.Name, true)
+		}
+		return
+	}
+
+	if !isBackendUnreachable(backend.Name) {
+		return
+	}
+
+	log.Infof("The urls of backend %s are reachable again, update its capabilities", backend.Name)
+	if err := updateBackendCapabilities(backend, false); err != nil {
+		log.Warningf("Update backend %s capabilities failed, error: %v", backend.Name, err)
+		return
+	}
+	setBackendAvailable(backend, true)
+	setBackendUnreachable(backend.Name, false)
+}
+
+func setBackendUnreachable(backendName string, unreachable bool) {
+	unreachableBackendsLock.Lock()
+	defer unreachableBackendsLock.Unlock()
+	if unreachable {
+		unreachableBackends[backendName] = true
+	} else {
+		delete(unreachableBackends, backendName)
+	}
+}
+
+func isBackendUnreachable(backendName string) bool {
+	unreachableBackendsLock.Lock()
+	defer unreachableBackendsLock.Unlock()
+	return unreachableBackends[backendName]
+}
+
+// removeBackendHealth used to forget the health of the removed backend
+func removeBackendHealth(backendName string) {
+	setBackendUnreachable(backendName, false)
+	utils.RemoveBackendURLHealth(pkgUtils.MakeMetaWithNamespace(app.GetGlobalConfig().Namespace, backendName))
+}
+
+// IsAnyBackendAvailable returns whether at least one registered backend is available,
+// it is true if no backend is registered since there is nothing to be unhealthy
+func IsAnyBackendAvailable() bool {
+	mutex.Lock()
+	registered := len(csiBackends)
+	mutex.Unlock()
+
+	return registered == 0 || len(GetAvailableBackends()) != 0
+}
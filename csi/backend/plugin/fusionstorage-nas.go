@@ -95,7 +95,7 @@ func (p *FusionStorageNasPlugin) CreateVolume(ctx context.Context, name string,
 
 	params["protocol"] = p.protocol
 
-	nas := volume.NewNAS(p.cli)
+	nas := volume.NewNAS(p.currentClient())
 	volObj, err := nas.Create(ctx, params)
 	if err != nil {
 		return nil, err
@@ -106,12 +106,24 @@ func (p *FusionStorageNasPlugin) CreateVolume(ctx context.Context, name string,
 
 func (p *FusionStorageNasPlugin) QueryVolume(ctx context.Context, name string, params map[string]interface{}) (
 	utils.Volume, error) {
-	nas := volume.NewNAS(p.cli)
+	nas := volume.NewNAS(p.currentClient())
 	return nas.Query(ctx, name)
 }
 
+// ListVolumes used to list the volumes with the given name prefix in the given pools
+func (p *FusionStorageNasPlugin) ListVolumes(ctx context.Context, prefix string, pools []string) ([]utils.Volume, error) {
+	nas := volume.NewNAS(p.currentClient())
+	return nas.List(ctx, prefix, pools)
+}
+
+// QueryVolumeCondition used to query the published nodes and the abnormal condition of the volume
+func (p *FusionStorageNasPlugin) QueryVolumeCondition(ctx context.Context, name string) (map[string]interface{}, error) {
+	nas := volume.NewNAS(p.currentClient())
+	return nas.GetCondition(ctx, name)
+}
+
 func (p *FusionStorageNasPlugin) DeleteVolume(ctx context.Context, name string) error {
-	nas := volume.NewNAS(p.cli)
+	nas := volume.NewNAS(p.currentClient())
 	return nas.Delete(ctx, name)
 }
 
@@ -122,7 +134,7 @@ func (p *FusionStorageNasPlugin) UpdateBackendCapabilities() (map[string]interfa
 		"SupportThick": false,
 		"SupportQoS":   true,
 		"SupportQuota": true,
-		"SupportClone": false,
+		"SupportClone": true,
 	}
 
 	err := p.updateNFS4Capability(capabilities)
@@ -133,20 +145,47 @@ func (p *FusionStorageNasPlugin) UpdateBackendCapabilities() (map[string]interfa
 	return capabilities, nil, nil
 }
 
+// CreateSnapshot used to create the snapshot of the filesystem
 func (p *FusionStorageNasPlugin) CreateSnapshot(ctx context.Context,
-	lunName, snapshotName string) (map[string]interface{}, error) {
-	return nil, fmt.Errorf("unimplemented")
+	fsName, snapshotName string) (map[string]interface{}, error) {
+	nas := volume.NewNAS(p.currentClient())
+	return nas.CreateSnapshot(ctx, fsName, utils.GetFSSnapshotName(snapshotName))
 }
 
+// DeleteSnapshot used to delete the snapshot of the filesystem
 func (p *FusionStorageNasPlugin) DeleteSnapshot(ctx context.Context,
 	snapshotParentID, snapshotName string) error {
-	return fmt.Errorf("unimplemented")
+	nas := volume.NewNAS(p.currentClient())
+	return nas.DeleteSnapshot(ctx, snapshotParentID, utils.GetFSSnapshotName(snapshotName))
+}
+
+// QuerySnapshot used to query the snapshot by its parent id and name, return nil if it does not exist
+func (p *FusionStorageNasPlugin) QuerySnapshot(ctx context.Context,
+	snapshotParentID, snapshotName string) (map[string]interface{}, error) {
+	nas := volume.NewNAS(p.currentClient())
+	return nas.QuerySnapshot(ctx, snapshotParentID, utils.GetFSSnapshotName(snapshotName))
+}
+
+// ListSnapshots used to list all snapshots of the volume, the names of the snapshots are the names in kubernetes
+func (p *FusionStorageNasPlugin) ListSnapshots(ctx context.Context, name string) ([]map[string]interface{}, error) {
+	nas := volume.NewNAS(p.currentClient())
+	snapshots, err := nas.ListSnapshots(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, snapshot := range snapshots {
+		if snapshotName, ok := snapshot["Name"].(string); ok {
+			snapshot["Name"] = utils.GetK8sFSSnapshotName(snapshotName)
+		}
+	}
+	return snapshots, nil
 }
 
 func (p *FusionStorageNasPlugin) ExpandVolume(ctx context.Context,
 	name string,
 	size int64) (bool, error) {
-	nas := volume.NewNAS(p.cli)
+	nas := volume.NewNAS(p.currentClient())
 	return false, nas.Expand(ctx, name, size)
 }
 
@@ -159,7 +198,7 @@ func (p *FusionStorageNasPlugin) updateNFS4Capability(capabilities map[string]in
 		capabilities = make(map[string]interface{})
 	}
 
-	nfsServiceSetting, err := p.cli.GetNFSServiceSetting(context.Background())
+	nfsServiceSetting, err := p.currentClient().GetNFSServiceSetting(context.Background())
 	if err != nil {
 		return err
 	}
@@ -191,7 +230,8 @@ func (p *FusionStorageNasPlugin) Validate(ctx context.Context, param map[string]
 
 	// Login verification
 	cli := client.NewClient(clientConfig.Url, clientConfig.User, clientConfig.SecretName,
-		clientConfig.SecretNamespace, clientConfig.ParallelNum, clientConfig.BackendID, clientConfig.AccountName)
+		clientConfig.SecretNamespace, clientConfig.ParallelNum, clientConfig.BackendID, clientConfig.AccountName,
+		clientConfig.TLSConfig)
 	err = cli.ValidateLogin(ctx)
 	if err != nil {
 		return err
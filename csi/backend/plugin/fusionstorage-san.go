@@ -146,7 +146,7 @@ func (p *FusionStorageSanPlugin) CreateVolume(ctx context.Context, name string,
 		return nil, err
 	}
 
-	san := volume.NewSAN(p.cli)
+	san := volume.NewSAN(p.currentClient())
 	volObj, err := san.Create(ctx, params)
 	if err != nil {
 		return nil, err
@@ -157,12 +157,24 @@ func (p *FusionStorageSanPlugin) CreateVolume(ctx context.Context, name string,
 
 func (p *FusionStorageSanPlugin) QueryVolume(ctx context.Context, name string, params map[string]interface{}) (
 	utils.Volume, error) {
-	san := volume.NewSAN(p.cli)
+	san := volume.NewSAN(p.currentClient())
 	return san.Query(ctx, name)
 }
 
+// ListVolumes used to list the volumes with the given name prefix in the given pools
+func (p *FusionStorageSanPlugin) ListVolumes(ctx context.Context, prefix string, pools []string) ([]utils.Volume, error) {
+	san := volume.NewSAN(p.currentClient())
+	return san.List(ctx, prefix, pools)
+}
+
+// QueryVolumeCondition used to query the published nodes and the abnormal condition of the volume
+func (p *FusionStorageSanPlugin) QueryVolumeCondition(ctx context.Context, name string) (map[string]interface{}, error) {
+	san := volume.NewSAN(p.currentClient())
+	return san.GetCondition(ctx, name)
+}
+
 func (p *FusionStorageSanPlugin) DeleteVolume(ctx context.Context, name string) error {
-	san := volume.NewSAN(p.cli)
+	san := volume.NewSAN(p.currentClient())
 	return san.Delete(ctx, name)
 }
 
@@ -172,16 +184,22 @@ func (p *FusionStorageSanPlugin) ExpandVolume(ctx context.Context, name string,
 		return false, utils.Errorf(ctx, "Expand Volume: the capacity %d is not an integer multiple of %d.",
 			size, CAPACITY_UNIT)
 	}
-	san := volume.NewSAN(p.cli)
+	san := volume.NewSAN(p.currentClient())
 	newSize := utils.TransVolumeCapacity(size, CAPACITY_UNIT)
 	isAttach, err := san.Expand(ctx, name, newSize)
 	return isAttach, err
 }
 
+// ModifyVolumeQoS used to replace the qos of the volume online
+func (p *FusionStorageSanPlugin) ModifyVolumeQoS(ctx context.Context, name, qos string) error {
+	san := volume.NewSAN(p.currentClient())
+	return san.ModifyQoS(ctx, name, qos)
+}
+
 // AttachVolume attach volume to node and return storage mapping info.
 func (p *FusionStorageSanPlugin) AttachVolume(ctx context.Context, name string,
 	parameters map[string]interface{}) (map[string]interface{}, error) {
-	localAttacher := attacher.NewAttacher(p.cli, p.protocol, "csi", p.portals, p.hosts, p.alua)
+	localAttacher := attacher.NewAttacher(p.currentClient(), p.protocol, "csi", p.portals, p.hosts, p.alua)
 	mappingInfo, err := localAttacher.ControllerAttach(ctx, name, parameters)
 	if err != nil {
 		log.AddContext(ctx).Errorf("attach volume %s error: %v", name, err)
@@ -194,7 +212,7 @@ func (p *FusionStorageSanPlugin) AttachVolume(ctx context.Context, name string,
 func (p *FusionStorageSanPlugin) DetachVolume(ctx context.Context,
 	name string,
 	parameters map[string]interface{}) error {
-	localAttacher := attacher.NewAttacher(p.cli, p.protocol, "csi", p.portals, p.hosts, p.alua)
+	localAttacher := attacher.NewAttacher(p.currentClient(), p.protocol, "csi", p.portals, p.hosts, p.alua)
 	_, err := localAttacher.ControllerDetach(ctx, name, parameters)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Detach volume %s error: %v", name, err)
@@ -208,7 +226,7 @@ func (p *FusionStorageSanPlugin) mutexGetClient(ctx context.Context) (*client.Cl
 	p.clientMutex.Lock()
 	var err error
 	if !p.storageOnline || p.clientCount == 0 {
-		err = p.cli.Login(ctx)
+		err = p.currentClient().Login(ctx)
 		p.storageOnline = err == nil
 		if err == nil {
 			p.clientCount++
@@ -217,7 +235,7 @@ func (p *FusionStorageSanPlugin) mutexGetClient(ctx context.Context) (*client.Cl
 		p.clientCount++
 	}
 	p.clientMutex.Unlock()
-	return p.cli, err
+	return p.currentClient(), err
 }
 
 func (p *FusionStorageSanPlugin) getClient(ctx context.Context) (*client.Client, error) {
@@ -255,7 +273,7 @@ func (p *FusionStorageSanPlugin) UpdateBackendCapabilities() (map[string]interfa
 
 func (p *FusionStorageSanPlugin) CreateSnapshot(ctx context.Context,
 	lunName, snapshotName string) (map[string]interface{}, error) {
-	san := volume.NewSAN(p.cli)
+	san := volume.NewSAN(p.currentClient())
 
 	snapshotName = utils.GetFusionStorageSnapshotName(snapshotName)
 	snapshot, err := san.CreateSnapshot(ctx, lunName, snapshotName)
@@ -268,7 +286,7 @@ func (p *FusionStorageSanPlugin) CreateSnapshot(ctx context.Context,
 
 func (p *FusionStorageSanPlugin) DeleteSnapshot(ctx context.Context,
 	snapshotParentID, snapshotName string) error {
-	san := volume.NewSAN(p.cli)
+	san := volume.NewSAN(p.currentClient())
 
 	snapshotName = utils.GetFusionStorageSnapshotName(snapshotName)
 	err := san.DeleteSnapshot(ctx, snapshotName)
@@ -279,6 +297,19 @@ func (p *FusionStorageSanPlugin) DeleteSnapshot(ctx context.Context,
 	return nil
 }
 
+// QuerySnapshot used to query the snapshot by its parent id and name, return nil if it does not exist
+func (p *FusionStorageSanPlugin) QuerySnapshot(ctx context.Context,
+	snapshotParentID, snapshotName string) (map[string]interface{}, error) {
+	san := volume.NewSAN(p.currentClient())
+	return san.QuerySnapshot(ctx, snapshotParentID, utils.GetFusionStorageSnapshotName(snapshotName))
+}
+
+// ListSnapshots used to list all snapshots of the volume
+func (p *FusionStorageSanPlugin) ListSnapshots(ctx context.Context, name string) ([]map[string]interface{}, error) {
+	san := volume.NewSAN(p.currentClient())
+	return san.ListSnapshots(ctx, name)
+}
+
 func (p *FusionStorageSanPlugin) UpdatePoolCapabilities(poolNames []string) (map[string]interface{}, error) {
 	return p.updatePoolCapabilities(poolNames, FusionStorageSan)
 }
@@ -298,7 +329,8 @@ func (p *FusionStorageSanPlugin) Validate(ctx context.Context, param map[string]
 
 	// Login verification
 	cli := client.NewClient(clientConfig.Url, clientConfig.User, clientConfig.SecretName,
-		clientConfig.SecretNamespace, clientConfig.ParallelNum, clientConfig.BackendID, clientConfig.AccountName)
+		clientConfig.SecretNamespace, clientConfig.ParallelNum, clientConfig.BackendID, clientConfig.AccountName,
+		clientConfig.TLSConfig)
 	err = cli.ValidateLogin(ctx)
 	if err != nil {
 		return err
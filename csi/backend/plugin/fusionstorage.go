@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	pkgUtils "huawei-csi-driver/pkg/utils"
 	"huawei-csi-driver/storage/fusionstorage/client"
@@ -40,7 +41,24 @@ const (
 
 type FusionStoragePlugin struct {
 	basePlugin
-	cli *client.Client
+
+	// cliMutex protects cli, which is replaced by UpdateCredential while the CSI calls are using it
+	cliMutex sync.RWMutex
+	cli      *client.Client
+}
+
+// currentClient returns the client of the storage currently logged in
+func (p *FusionStoragePlugin) currentClient() *client.Client {
+	p.cliMutex.RLock()
+	defer p.cliMutex.RUnlock()
+	return p.cli
+}
+
+// setClient used to replace the client of the storage, the calls started later use the new client
+func (p *FusionStoragePlugin) setClient(cli *client.Client) {
+	p.cliMutex.Lock()
+	defer p.cliMutex.Unlock()
+	p.cli = cli
 }
 
 func (p *FusionStoragePlugin) init(config map[string]interface{}, keepLogin bool) error {
@@ -74,8 +92,13 @@ func (p *FusionStoragePlugin) init(config map[string]interface{}, keepLogin bool
 	accountName, _ := config["accountName"].(string)
 	parallelNum, _ := config["maxClientThreads"].(string)
 
-	cli := client.NewClient(url, user, secretName, secretNamespace, parallelNum, backendID, accountName)
-	err := cli.Login(context.Background())
+	tlsConfig, err := getTLSConfig(context.Background(), config)
+	if err != nil {
+		return err
+	}
+
+	cli := client.NewClient(url, user, secretName, secretNamespace, parallelNum, backendID, accountName, tlsConfig)
+	err = cli.Login(context.Background())
 	if err != nil {
 		return err
 	}
@@ -84,7 +107,7 @@ func (p *FusionStoragePlugin) init(config map[string]interface{}, keepLogin bool
 		cli.Logout(context.Background())
 	}
 
-	p.cli = cli
+	p.setClient(cli)
 	return nil
 }
 
@@ -99,6 +122,9 @@ func (p *FusionStoragePlugin) getParams(name string,
 	paramKeys := []string{
 		"storagepool",
 		"cloneFrom",
+		"sourceSnapshotName",
+		"sourceVolumeName",
+		"snapshotParentId",
 		"authClient",
 		"storageQuota",
 		"accountName",
@@ -130,9 +156,9 @@ func (p *FusionStoragePlugin) UpdateBackendCapabilities() (map[string]interface{
 
 func (p *FusionStoragePlugin) updatePoolCapabilities(poolNames []string, storageType int) (map[string]interface{}, error) {
 	// To keep connection token alive
-	p.cli.KeepAlive(context.Background())
+	p.currentClient().KeepAlive(context.Background())
 
-	pools, err := p.cli.GetAllPools(context.Background())
+	pools, err := p.currentClient().GetAllPools(context.Background())
 	if err != nil {
 		log.Errorf("Get fusionstorage pools error: %v", err)
 		return nil, err
@@ -141,7 +167,7 @@ func (p *FusionStoragePlugin) updatePoolCapabilities(poolNames []string, storage
 
 	var accounts []string
 	if storageType == FusionStorageNas {
-		accounts, err = p.cli.GetAllAccounts(context.Background())
+		accounts, err = p.currentClient().GetAllAccounts(context.Background())
 		if err != nil {
 			log.Errorf("Get accounts error: %v", err)
 			return nil, err
@@ -158,7 +184,10 @@ func (p *FusionStoragePlugin) updatePoolCapabilities(poolNames []string, storage
 			usedCapacity := int64(pool["usedCapacity"].(float64))
 			freeCapacity := (totalCapacity - usedCapacity) * CAPACITY_UNIT
 
-			capability := map[string]interface{}{"FreeCapacity": freeCapacity}
+			capability := map[string]interface{}{
+				"FreeCapacity":  freeCapacity,
+				"TotalCapacity": totalCapacity * CAPACITY_UNIT,
+			}
 			if storageType == FusionStorageNas {
 				capability["Accounts"] = accounts
 			}
@@ -177,11 +206,35 @@ func (p *FusionStoragePlugin) SupportQoSParameters(ctx context.Context, qosConfi
 
 // Logout is to logout the storage session
 func (p *FusionStoragePlugin) Logout(ctx context.Context) {
-	if p.cli != nil {
-		p.cli.Logout(ctx)
+	if cli := p.currentClient(); cli != nil {
+		cli.Logout(ctx)
 	}
 }
 
+// CheckHealth used to probe the management url of the storage
+func (p *FusionStoragePlugin) CheckHealth(ctx context.Context) []utils.URLHealth {
+	cli := p.currentClient()
+	if cli == nil {
+		return nil
+	}
+	return cli.CheckHealth(ctx)
+}
+
+// UpdateCredential used to log in with the credential of the backend config and replace the current client,
+// the current client is kept if the login fails
+func (p *FusionStoragePlugin) UpdateCredential(ctx context.Context, config map[string]interface{}) error {
+	oldClient := p.currentClient()
+	if err := p.init(config, true); err != nil {
+		log.AddContext(ctx).Errorf("Login with the new credential failed, keep the current client, error: %v", err)
+		return err
+	}
+
+	if oldClient != nil {
+		oldClient.Logout(ctx)
+	}
+	return nil
+}
+
 func (p *FusionStoragePlugin) getNewClientConfig(ctx context.Context, config map[string]interface{}) (*client.NewClientConfig, error) {
 	newClientConfig := &client.NewClientConfig{}
 	configUrls, exist := config["urls"].([]interface{})
@@ -229,6 +282,11 @@ func (p *FusionStoragePlugin) getNewClientConfig(ctx context.Context, config map
 
 	newClientConfig.AccountName, _ = config["accountName"].(string)
 	newClientConfig.ParallelNum, _ = config["maxClientThreads"].(string)
+	tlsConfig, err := getTLSConfig(ctx, config)
+	if err != nil {
+		return newClientConfig, err
+	}
+	newClientConfig.TLSConfig = tlsConfig
 
 	return newClientConfig, nil
 }
@@ -0,0 +1,298 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"huawei-csi-driver/storage/oceanstor/volume"
+	"huawei-csi-driver/utils"
+	"huawei-csi-driver/utils/log"
+)
+
+const (
+	// DTreeStorage means the oceanstor-dtree backend, whose volumes are dTrees of one parent filesystem
+	DTreeStorage = "oceanstor-dtree"
+)
+
+// OceanstorDTreePlugin implements the volume operations of the dTrees under the parent filesystem,
+// the nfs protocol and capabilities are the same as the oceanstor-nas backend
+type OceanstorDTreePlugin struct {
+	OceanstorNasPlugin
+	parentName string
+}
+
+func init() {
+	RegPlugin(DTreeStorage, &OceanstorDTreePlugin{})
+}
+
+// NewPlugin used to create a new oceanstor-dtree plugin
+func (p *OceanstorDTreePlugin) NewPlugin() Plugin {
+	return &OceanstorDTreePlugin{}
+}
+
+// Init used to init the plugin with the parent filesystem of the dTrees
+func (p *OceanstorDTreePlugin) Init(config, parameters map[string]interface{}, keepLogin bool) error {
+	parentName, exist := parameters["parentname"].(string)
+	if !exist || parentName == "" {
+		return errors.New("parentname must be provided for oceanstor-dtree backend")
+	}
+
+	err := p.OceanstorNasPlugin.Init(config, parameters, keepLogin)
+	if err != nil {
+		return err
+	}
+
+	p.parentName = parentName
+	return nil
+}
+
+func (p *OceanstorDTreePlugin) getDTreeObj() *volume.DTree {
+	return volume.NewDTree(p.currentClient(), p.product, p.vStoreId)
+}
+
+// checkParentName used to reject the parentname of the StorageClass which differs from the one of the backend,
+// since the dTree is always deleted, expanded and listed under the parent filesystem of the backend
+func (p *OceanstorDTreePlugin) checkParentName(ctx context.Context, parameters map[string]interface{}) error {
+	if parentName, ok := parameters["parentname"].(string); ok && parentName != "" && parentName != p.parentName {
+		return utils.Errorf(ctx, "the parentname %s of the StorageClass differs from the parentname %s of the "+
+			"backend, create a backend for the parent filesystem %s instead", parentName, p.parentName, parentName)
+	}
+	return nil
+}
+
+// CreateVolume used to create the dTree with the hard quota of the requested size
+func (p *OceanstorDTreePlugin) CreateVolume(ctx context.Context, name string, parameters map[string]interface{}) (
+	utils.Volume, error) {
+	size, ok := parameters["size"].(int64)
+	if !ok || size <= 0 {
+		return nil, utils.Errorf(ctx, "Create Volume: the capacity %v of dtree is invalid", parameters["size"])
+	}
+
+	if err := p.checkParentName(ctx, parameters); err != nil {
+		return nil, err
+	}
+
+	params := p.getParams(ctx, name, parameters)
+	params["parentname"] = p.parentName
+	params["spacehardquota"] = size
+	dTree := p.getDTreeObj()
+	return dTree.Create(ctx, params)
+}
+
+// QueryVolume used to query the dTree
+func (p *OceanstorDTreePlugin) QueryVolume(ctx context.Context, name string, parameters map[string]interface{}) (
+	utils.Volume, error) {
+	if err := p.checkParentName(ctx, parameters); err != nil {
+		return nil, err
+	}
+
+	dTree := p.getDTreeObj()
+	return dTree.Query(ctx, p.parentName, name)
+}
+
+// ListVolumes used to list the dTrees with the given name prefix, the pools are ignored since all dTrees
+// belong to the parent filesystem
+func (p *OceanstorDTreePlugin) ListVolumes(ctx context.Context, prefix string, _ []string) ([]utils.Volume, error) {
+	dTree := p.getDTreeObj()
+	return dTree.List(ctx, p.parentName, prefix)
+}
+
+// QueryVolumeCondition used to query the abnormal condition of the dTree
+func (p *OceanstorDTreePlugin) QueryVolumeCondition(ctx context.Context, name string) (
+	map[string]interface{}, error) {
+	dTree := p.getDTreeObj()
+	return dTree.GetCondition(ctx, p.parentName, name)
+}
+
+// DeleteVolume used to delete the dTree under the parent filesystem of the backend
+func (p *OceanstorDTreePlugin) DeleteVolume(ctx context.Context, name string) error {
+	dTree := p.getDTreeObj()
+	return dTree.Delete(ctx, p.parentName, name)
+}
+
+// ExpandVolume used to expand the dTree under the parent filesystem of the backend
+func (p *OceanstorDTreePlugin) ExpandVolume(ctx context.Context, name string, size int64) (bool, error) {
+	dTree := p.getDTreeObj()
+	return false, dTree.Expand(ctx, p.parentName, name, size)
+}
+
+// DeleteDTreeVolume used to delete the dTree with the name under the parent filesystem of the backend
+func (p *OceanstorDTreePlugin) DeleteDTreeVolume(ctx context.Context, params map[string]interface{}) error {
+	name, ok := params["name"].(string)
+	if !ok || name == "" {
+		return errors.New("name must be provided to delete dtree volume")
+	}
+
+	if err := p.checkParentName(ctx, params); err != nil {
+		return err
+	}
+
+	dTree := p.getDTreeObj()
+	return dTree.Delete(ctx, p.parentName, name)
+}
+
+// ExpandDTreeVolume used to expand the dTree with the name under the parent filesystem of the backend to the
+// spacehardquota in bytes
+func (p *OceanstorDTreePlugin) ExpandDTreeVolume(ctx context.Context, params map[string]interface{}) (bool, error) {
+	name, ok := params["name"].(string)
+	if !ok || name == "" {
+		return false, errors.New("name must be provided to expand dtree volume")
+	}
+
+	spaceHardQuota, ok := params["spacehardquota"].(int64)
+	if !ok || spaceHardQuota <= 0 {
+		return false, fmt.Errorf("the space hard quota %v to expand dtree %s is invalid",
+			params["spacehardquota"], name)
+	}
+
+	if err := p.checkParentName(ctx, params); err != nil {
+		return false, err
+	}
+
+	dTree := p.getDTreeObj()
+	return false, dTree.Expand(ctx, p.parentName, name, spaceHardQuota)
+}
+
+// CreateSnapshot is not supported by the dTree
+func (p *OceanstorDTreePlugin) CreateSnapshot(ctx context.Context, _, _ string) (map[string]interface{}, error) {
+	return nil, utils.Errorln(ctx, "oceanstor-dtree backend does not support snapshot")
+}
+
+// DeleteSnapshot is not supported by the dTree
+func (p *OceanstorDTreePlugin) DeleteSnapshot(ctx context.Context, _, _ string) error {
+	return utils.Errorln(ctx, "oceanstor-dtree backend does not support snapshot")
+}
+
+// QuerySnapshot returns nil since the dTree has no snapshot
+func (p *OceanstorDTreePlugin) QuerySnapshot(context.Context, string, string) (map[string]interface{}, error) {
+	return nil, nil
+}
+
+// ListSnapshots returns nil since the dTree has no snapshot
+func (p *OceanstorDTreePlugin) ListSnapshots(context.Context, string) ([]map[string]interface{}, error) {
+	return nil, nil
+}
+
+// CreateGroupSnapshot is not supported by the dTree
+func (p *OceanstorDTreePlugin) CreateGroupSnapshot(ctx context.Context, _, _ []string) (
+	[]map[string]interface{}, error) {
+	return nil, utils.Errorln(ctx, "oceanstor-dtree backend does not support snapshot")
+}
+
+// RevertVolumeToSnapshot is not supported by the dTree
+func (p *OceanstorDTreePlugin) RevertVolumeToSnapshot(ctx context.Context, _, _, _ string) error {
+	return utils.Errorln(ctx, "oceanstor-dtree backend does not support snapshot")
+}
+
+// ModifyVolumeQoS is not supported by the dTree
+func (p *OceanstorDTreePlugin) ModifyVolumeQoS(ctx context.Context, _, _ string) error {
+	return utils.Errorln(ctx, "oceanstor-dtree backend does not support qos")
+}
+
+// QueryVolumePairs returns nil since the dTree has no hyperMetro or replication pair
+func (p *OceanstorDTreePlugin) QueryVolumePairs(context.Context, string) ([]map[string]interface{}, error) {
+	return nil, nil
+}
+
+// SwitchoverVolumeReplication is not supported by the dTree
+func (p *OceanstorDTreePlugin) SwitchoverVolumeReplication(ctx context.Context, _ string,
+	_ map[string]interface{}) error {
+	return utils.Errorln(ctx, "oceanstor-dtree backend does not support replication")
+}
+
+// FailoverVolumeReplication is not supported by the dTree
+func (p *OceanstorDTreePlugin) FailoverVolumeReplication(ctx context.Context, _ string,
+	_ map[string]interface{}) error {
+	return utils.Errorln(ctx, "oceanstor-dtree backend does not support replication")
+}
+
+// ResyncVolumeReplication is not supported by the dTree
+func (p *OceanstorDTreePlugin) ResyncVolumeReplication(ctx context.Context, _ string, _ bool) error {
+	return utils.Errorln(ctx, "oceanstor-dtree backend does not support replication")
+}
+
+// UpdateBackendCapabilities used to update the capabilities, the dTree does not support hyperMetro,
+// replication, clone and snapshot
+func (p *OceanstorDTreePlugin) UpdateBackendCapabilities() (map[string]interface{}, map[string]interface{}, error) {
+	capabilities, specifications, err := p.OceanstorPlugin.UpdateBackendCapabilities()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = p.updateNFS4Capability(capabilities)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	delete(capabilities, "SupportMetroNAS")
+	capabilities["SupportThick"] = false
+	capabilities["SupportMetro"] = false
+	capabilities["SupportReplication"] = false
+	capabilities["SupportClone"] = false
+	capabilities["SupportConsistentSnapshot"] = false
+	return capabilities, specifications, nil
+}
+
+// UpdatePoolCapabilities used to update the capacity of the pools by the free capacity of the parent filesystem,
+// the pool of the oceanstor-dtree backend is named by the backend
+func (p *OceanstorDTreePlugin) UpdatePoolCapabilities(poolNames []string) (map[string]interface{}, error) {
+	fs, err := p.currentClient().GetFileSystemByName(context.Background(), p.parentName)
+	if err != nil {
+		log.Errorf("Get parent filesystem %s error: %v", p.parentName, err)
+		return nil, err
+	}
+	if fs == nil {
+		return nil, fmt.Errorf("parent filesystem %s of oceanstor-dtree backend does not exist", p.parentName)
+	}
+
+	freeCapacity, _ := strconv.ParseInt(utils.ToStringSafe(fs["AVAILABLECAPCITY"]), 10, 64)
+	capabilities := make(map[string]interface{})
+	for _, name := range poolNames {
+		capabilities[name] = map[string]interface{}{
+			"FreeCapacity": freeCapacity * 512,
+		}
+	}
+	return capabilities, nil
+}
+
+// UpdateMetroRemotePlugin does nothing since the dTree does not support hyperMetro
+func (p *OceanstorDTreePlugin) UpdateMetroRemotePlugin(Plugin) {
+}
+
+// UpdateReplicaRemotePlugin does nothing since the dTree does not support replication
+func (p *OceanstorDTreePlugin) UpdateReplicaRemotePlugin(Plugin) {
+}
+
+// Validate used to check the parameters of the oceanstor-dtree backend, include login verification
+func (p *OceanstorDTreePlugin) Validate(ctx context.Context, param map[string]interface{}) error {
+	log.AddContext(ctx).Infoln("Start to validate OceanstorDTreePlugin parameters.")
+
+	parameters, _ := param["parameters"].(map[string]interface{})
+	parentName, exist := parameters["parentname"].(string)
+	if !exist || parentName == "" {
+		msg := fmt.Sprintf("Verify parentname: [%v] failed. \nparentname must be provided for "+
+			"oceanstor-dtree backend\n", parameters["parentname"])
+		log.AddContext(ctx).Errorln(msg)
+		return errors.New(msg)
+	}
+
+	return p.OceanstorNasPlugin.Validate(ctx, param)
+}
@@ -0,0 +1,47 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package plugin
+
+import (
+	"testing"
+)
+
+func TestCheckParentName(t *testing.T) {
+	p := &OceanstorDTreePlugin{parentName: "parent-fs"}
+	tests := []struct {
+		name       string
+		parameters map[string]interface{}
+		wantErr    bool
+	}{
+		{"NotSet", map[string]interface{}{}, false},
+		{"SameAsBackend", map[string]interface{}{"parentname": "parent-fs"}, false},
+		{"DiffersFromBackend", map[string]interface{}{"parentname": "other-fs"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := p.checkParentName(ctx, tt.parameters); (err != nil) != tt.wantErr {
+				t.Errorf("checkParentName error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := p.CreateVolume(ctx, "pvc-1", map[string]interface{}{"size": int64(1024),
+		"parentname": "other-fs"}); err == nil {
+		t.Errorf("CreateVolume under a parentname which differs from the backend should fail")
+	}
+}
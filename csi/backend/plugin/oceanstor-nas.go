@@ -87,13 +87,13 @@ func (p *OceanstorNasPlugin) getNasObj() *volume.NAS {
 	var replicaRemoteCli client.BaseClientInterface
 
 	if p.metroRemotePlugin != nil {
-		metroRemoteCli = p.metroRemotePlugin.cli
+		metroRemoteCli = p.metroRemotePlugin.currentClient()
 	}
 	if p.replicaRemotePlugin != nil {
-		replicaRemoteCli = p.replicaRemotePlugin.cli
+		replicaRemoteCli = p.replicaRemotePlugin.currentClient()
 	}
 
-	return volume.NewNAS(p.cli, metroRemoteCli, replicaRemoteCli, p.product, p.nasHyperMetro)
+	return volume.NewNAS(p.currentClient(), metroRemoteCli, replicaRemoteCli, p.product, p.nasHyperMetro)
 }
 
 func (p *OceanstorNasPlugin) CreateVolume(ctx context.Context, name string, parameters map[string]interface{}) (
@@ -120,9 +120,9 @@ func (p *OceanstorNasPlugin) CreateVolume(ctx context.Context, name string, para
 func (p *OceanstorNasPlugin) getClient() (client.BaseClientInterface, client.BaseClientInterface) {
 	var replicaRemoteCli client.BaseClientInterface
 	if p.replicaRemotePlugin != nil {
-		replicaRemoteCli = p.replicaRemotePlugin.cli
+		replicaRemoteCli = p.replicaRemotePlugin.currentClient()
 	}
-	return p.cli, replicaRemoteCli
+	return p.currentClient(), replicaRemoteCli
 }
 
 func (p *OceanstorNasPlugin) QueryVolume(ctx context.Context, name string, parameters map[string]interface{}) (
@@ -132,6 +132,48 @@ func (p *OceanstorNasPlugin) QueryVolume(ctx context.Context, name string, param
 	return nas.Query(ctx, name, params)
 }
 
+// ListVolumes used to list the volumes with the given name prefix in the given pools
+func (p *OceanstorNasPlugin) ListVolumes(ctx context.Context, prefix string, pools []string) ([]utils.Volume, error) {
+	nas := p.getNasObj()
+	return nas.List(ctx, prefix, pools)
+}
+
+// QueryVolumeCondition used to query the published nodes and the abnormal condition of the volume
+func (p *OceanstorNasPlugin) QueryVolumeCondition(ctx context.Context, name string) (map[string]interface{}, error) {
+	nas := p.getNasObj()
+	return nas.GetCondition(ctx, name)
+}
+
+// QueryVolumePairs used to query the status of the hyperMetro and the replication pairs of the volume
+func (p *OceanstorNasPlugin) QueryVolumePairs(ctx context.Context, name string) ([]map[string]interface{}, error) {
+	nas := p.getNasObj()
+	return nas.GetPairsStatus(ctx, name)
+}
+
+// SwitchoverVolumeReplication used to make the local filesystem the primary of its replication pairs, the
+// filesystem is exported to the authClient of the parameters
+func (p *OceanstorNasPlugin) SwitchoverVolumeReplication(ctx context.Context, name string,
+	parameters map[string]interface{}) error {
+	params := p.getParams(ctx, name, parameters)
+	nas := p.getNasObj()
+	return nas.SwitchoverReplication(ctx, name, params)
+}
+
+// FailoverVolumeReplication used to make the local filesystem writable when the primary site is lost, the
+// filesystem is exported to the authClient of the parameters
+func (p *OceanstorNasPlugin) FailoverVolumeReplication(ctx context.Context, name string,
+	parameters map[string]interface{}) error {
+	params := p.getParams(ctx, name, parameters)
+	nas := p.getNasObj()
+	return nas.FailoverReplication(ctx, name, params)
+}
+
+// ResyncVolumeReplication used to resume the replication pairs of the filesystem
+func (p *OceanstorNasPlugin) ResyncVolumeReplication(ctx context.Context, name string, reverse bool) error {
+	nas := p.getNasObj()
+	return nas.ResyncReplication(ctx, name, reverse)
+}
+
 func (p *OceanstorNasPlugin) DeleteVolume(ctx context.Context, name string) error {
 	nas := p.getNasObj()
 	return nas.Delete(ctx, name)
@@ -164,8 +206,7 @@ func (p *OceanstorNasPlugin) CreateSnapshot(ctx context.Context,
 	fsName, snapshotName string) (map[string]interface{}, error) {
 	nas := p.getNasObj()
 
-	snapshotName = utils.GetFSSnapshotName(snapshotName)
-	snapshot, err := nas.CreateSnapshot(ctx, fsName, snapshotName)
+	snapshot, err := nas.CreateSnapshot(ctx, fsName, utils.GetFSSnapshotName(snapshotName), snapshotName)
 	if err != nil {
 		return nil, err
 	}
@@ -185,6 +226,58 @@ func (p *OceanstorNasPlugin) DeleteSnapshot(ctx context.Context, snapshotParentI
 	return nil
 }
 
+// QuerySnapshot used to query the snapshot by its parent id and name, return nil if it does not exist
+func (p *OceanstorNasPlugin) QuerySnapshot(ctx context.Context,
+	snapshotParentID, snapshotName string) (map[string]interface{}, error) {
+	nas := p.getNasObj()
+	return nas.QuerySnapshot(ctx, snapshotParentID, utils.GetFSSnapshotName(snapshotName))
+}
+
+// ListSnapshots used to list all snapshots of the volume, the names of the snapshots are the names in kubernetes
+func (p *OceanstorNasPlugin) ListSnapshots(ctx context.Context, name string) ([]map[string]interface{}, error) {
+	nas := p.getNasObj()
+	snapshots, err := nas.ListSnapshots(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, snapshot := range snapshots {
+		snapshot["Name"] = getK8sSnapshotName(snapshot, utils.GetFSSnapshotName, utils.GetK8sFSSnapshotName)
+	}
+	return snapshots, nil
+}
+
+// CreateGroupSnapshot used to create the consistent snapshots of the filesystems, which is supported by the
+// storage of supportConsistentSnapshotsVersions
+func (p *OceanstorNasPlugin) CreateGroupSnapshot(ctx context.Context,
+	names, snapshotNames []string) ([]map[string]interface{}, error) {
+	if !utils.StringContain(p.currentClient().GetStorageVersion(), supportConsistentSnapshotsVersions) {
+		return nil, utils.Errorf(ctx, "storage version %s does not support consistent snapshots",
+			p.currentClient().GetStorageVersion())
+	}
+
+	fsSnapshotNames := make([]string, 0, len(snapshotNames))
+	for _, snapshotName := range snapshotNames {
+		fsSnapshotNames = append(fsSnapshotNames, utils.GetFSSnapshotName(snapshotName))
+	}
+
+	nas := p.getNasObj()
+	return nas.CreateGroupSnapshot(ctx, names, fsSnapshotNames)
+}
+
+// ModifyVolumeQoS used to change the qos of the filesystem and its hyperMetro remote filesystem
+func (p *OceanstorNasPlugin) ModifyVolumeQoS(ctx context.Context, name, qos string) error {
+	nas := p.getNasObj()
+	return nas.ModifyQoS(ctx, name, qos)
+}
+
+// RevertVolumeToSnapshot used to roll the filesystem back to its snapshot, the filesystem must not be mounted
+func (p *OceanstorNasPlugin) RevertVolumeToSnapshot(ctx context.Context,
+	name, snapshotParentID, snapshotName string) error {
+	nas := p.getNasObj()
+	return nas.RevertSnapshot(ctx, name, snapshotParentID, utils.GetFSSnapshotName(snapshotName))
+}
+
 func (p *OceanstorNasPlugin) UpdateBackendCapabilities() (map[string]interface{}, map[string]interface{}, error) {
 	capabilities, specifications, err := p.OceanstorPlugin.UpdateBackendCapabilities()
 	if err != nil {
@@ -230,13 +323,13 @@ func (p *OceanstorNasPlugin) updateHyperMetroCapability(capabilities map[string]
 		return nil
 	}
 
-	vStorePair, err := p.cli.GetvStorePairByID(context.Background(), p.vStorePairID)
+	vStorePair, err := p.currentClient().GetvStorePairByID(context.Background(), p.vStorePairID)
 	if err != nil {
 		return err
 	}
 
 	if p.product == "DoradoV6" && vStorePair != nil {
-		fsHyperMetroDomain, err := p.cli.GetFSHyperMetroDomain(context.Background(),
+		fsHyperMetroDomain, err := p.currentClient().GetFSHyperMetroDomain(context.Background(),
 			vStorePair["DOMAINNAME"].(string))
 		if err != nil {
 			return err
@@ -258,7 +351,7 @@ func (p *OceanstorNasPlugin) updateHyperMetroCapability(capabilities map[string]
 		if vStorePair == nil ||
 			vStorePair["ACTIVEORPASSIVE"] != HYPER_METRO_VSTORE_PAIR_ACTIVE ||
 			vStorePair["LINKSTATUS"] != HYPER_METRO_VSTORE_PAIR_LINK_STATUS_CONNECTED ||
-			vStorePair["LOCALVSTORENAME"] != p.cli.GetvStoreName() {
+			vStorePair["LOCALVSTORENAME"] != p.currentClient().GetvStoreName() {
 			capabilities["SupportMetro"] = false
 		}
 	}
@@ -269,7 +362,7 @@ func (p *OceanstorNasPlugin) updateHyperMetroCapability(capabilities map[string]
 func (p *OceanstorNasPlugin) updateConsistentSnapshotCapability(
 	capabilities, specifications map[string]interface{}) error {
 	var supportConsistentSnapshot bool
-	if utils.StringContain(p.cli.GetStorageVersion(), supportConsistentSnapshotsVersions) {
+	if utils.StringContain(p.currentClient().GetStorageVersion(), supportConsistentSnapshotsVersions) {
 		supportConsistentSnapshot = true
 		specifications["ConsistentSnapshotLimits"] = ConsistentSnapshotsSpecification
 	}
@@ -289,7 +382,7 @@ func (p *OceanstorNasPlugin) updateNFS4Capability(capabilities map[string]interf
 		capabilities = make(map[string]interface{})
 	}
 
-	nfsServiceSetting, err := p.cli.GetNFSServiceSetting(context.Background())
+	nfsServiceSetting, err := p.currentClient().GetNFSServiceSetting(context.Background())
 	if err != nil {
 		return err
 	}
@@ -316,12 +409,12 @@ func (p *OceanstorNasPlugin) updateNFS4Capability(capabilities map[string]interf
 
 func (p *OceanstorNasPlugin) UpdateRemoteCapabilities(capabilities map[string]interface{}) {
 	// update the hyperMetro remote backend capabilities
-	if p.metroRemotePlugin == nil || p.metroRemotePlugin.cli == nil {
+	if p.metroRemotePlugin == nil || p.metroRemotePlugin.currentClient() == nil {
 		capabilities["SupportMetro"] = false
 		return
 	}
 
-	features, err := p.metroRemotePlugin.cli.GetLicenseFeature(context.Background())
+	features, err := p.metroRemotePlugin.currentClient().GetLicenseFeature(context.Background())
 	if err != nil {
 		log.Warningf("Get license feature error: %v", err)
 		capabilities["SupportMetro"] = false
@@ -26,6 +26,7 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 
 	"huawei-csi-driver/storage/oceanstor/client"
+	"huawei-csi-driver/storage/oceanstor/volume"
 )
 
 func TestInit(t *testing.T) {
@@ -105,3 +106,76 @@ func TestValidate(t *testing.T) {
 		So(err, ShouldBeNil)
 	})
 }
+
+func TestListSnapshotsWithK8sName(t *testing.T) {
+	p := &OceanstorNasPlugin{}
+	var nas *volume.NAS
+	guard := monkey.PatchInstanceMethod(reflect.TypeOf(nas), "ListSnapshots",
+		func(*volume.NAS, context.Context, string) ([]map[string]interface{}, error) {
+			return []map[string]interface{}{
+				{"Name": "snapshot_1", "Description": "snapshot-1"},
+				{"Name": "snapshot_2", "Description": "Created from huawei-csi for Kubernetes"},
+				{"Name": "gs1_2", "Description": "gs1_2"},
+			}, nil
+		})
+	defer guard.Unpatch()
+
+	Convey("List snapshots with the names in kubernetes", t, func() {
+		snapshots, err := p.ListSnapshots(context.TODO(), "pvc_1")
+		So(err, ShouldBeNil)
+		So(snapshots[0]["Name"], ShouldEqual, "snapshot-1")
+		So(snapshots[1]["Name"], ShouldEqual, "snapshot-2")
+		So(snapshots[2]["Name"], ShouldEqual, "gs1_2")
+	})
+}
+
+func TestUpdateCredentialSwapsClient(t *testing.T) {
+	var loggedOut []*client.BaseClient
+	var cli *client.BaseClient
+	monkey.PatchInstanceMethod(reflect.TypeOf(cli), "Logout", func(c *client.BaseClient, _ context.Context) {
+		loggedOut = append(loggedOut, c)
+	})
+	monkey.PatchInstanceMethod(reflect.TypeOf(cli), "Login", func(*client.BaseClient, context.Context) error {
+		return nil
+	})
+	monkey.PatchInstanceMethod(reflect.TypeOf(cli), "GetSystem", func(*client.BaseClient, context.Context) (
+		map[string]interface{}, error) {
+		return map[string]interface{}{"PRODUCTVERSION": "Test"}, nil
+	})
+	defer monkey.UnpatchAll()
+
+	oldClient := &client.BaseClient{}
+	p := &OceanstorNasPlugin{}
+	p.setClient(oldClient)
+
+	// the CSI calls keep reading the client while the credential is updated
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				_ = p.currentClient()
+			}
+		}
+	}()
+
+	err := p.UpdateCredential(ctx, map[string]interface{}{"urls": []interface{}{"*.*.*.*"},
+		"backendID": "mock-backendID", "user": "testUser", "secretName": "mock-secretname",
+		"secretNamespace": "mock-namespace"})
+	close(done)
+	<-stopped
+	if err != nil {
+		t.Fatalf("UpdateCredential failed, error: %v", err)
+	}
+
+	if p.currentClient() == oldClient {
+		t.Errorf("UpdateCredential should replace the client")
+	}
+	if len(loggedOut) != 1 || loggedOut[0] != oldClient {
+		t.Errorf("UpdateCredential should log out the old client only, logged out: %v", loggedOut)
+	}
+}
@@ -112,13 +112,13 @@ func (p *OceanstorSanPlugin) getSanObj() *volume.SAN {
 	var replicaRemoteCli client.BaseClientInterface
 
 	if p.metroRemotePlugin != nil {
-		metroRemoteCli = p.metroRemotePlugin.cli
+		metroRemoteCli = p.metroRemotePlugin.currentClient()
 	}
 	if p.replicaRemotePlugin != nil {
-		replicaRemoteCli = p.replicaRemotePlugin.cli
+		replicaRemoteCli = p.replicaRemotePlugin.currentClient()
 	}
 
-	return volume.NewSAN(p.cli, metroRemoteCli, replicaRemoteCli, p.product)
+	return volume.NewSAN(p.currentClient(), metroRemoteCli, replicaRemoteCli, p.product)
 }
 
 func (p *OceanstorSanPlugin) CreateVolume(ctx context.Context,
@@ -147,6 +147,44 @@ func (p *OceanstorSanPlugin) QueryVolume(ctx context.Context, name string, param
 	return san.Query(ctx, name)
 }
 
+// ListVolumes used to list the volumes with the given name prefix in the given pools
+func (p *OceanstorSanPlugin) ListVolumes(ctx context.Context, prefix string, pools []string) ([]utils.Volume, error) {
+	san := p.getSanObj()
+	return san.List(ctx, prefix, pools)
+}
+
+// QueryVolumeCondition used to query the published nodes and the abnormal condition of the volume
+func (p *OceanstorSanPlugin) QueryVolumeCondition(ctx context.Context, name string) (map[string]interface{}, error) {
+	san := p.getSanObj()
+	return san.GetCondition(ctx, name)
+}
+
+// QueryVolumePairs used to query the status of the hyperMetro and the replication pairs of the volume
+func (p *OceanstorSanPlugin) QueryVolumePairs(ctx context.Context, name string) ([]map[string]interface{}, error) {
+	san := p.getSanObj()
+	return san.GetPairsStatus(ctx, name)
+}
+
+// SwitchoverVolumeReplication used to make the local lun the primary of its replication pairs
+func (p *OceanstorSanPlugin) SwitchoverVolumeReplication(ctx context.Context, name string,
+	parameters map[string]interface{}) error {
+	san := p.getSanObj()
+	return san.SwitchoverReplication(ctx, name)
+}
+
+// FailoverVolumeReplication used to make the local lun writable when the primary site is lost
+func (p *OceanstorSanPlugin) FailoverVolumeReplication(ctx context.Context, name string,
+	parameters map[string]interface{}) error {
+	san := p.getSanObj()
+	return san.FailoverReplication(ctx, name)
+}
+
+// ResyncVolumeReplication used to resume the replication pairs of the lun
+func (p *OceanstorSanPlugin) ResyncVolumeReplication(ctx context.Context, name string, reverse bool) error {
+	san := p.getSanObj()
+	return san.ResyncReplication(ctx, name, reverse)
+}
+
 func (p *OceanstorSanPlugin) DeleteVolume(ctx context.Context, name string) error {
 	san := p.getSanObj()
 	return san.Delete(ctx, name)
@@ -219,7 +257,7 @@ func (p *OceanstorSanPlugin) metroHandler(ctx context.Context, req handlerReques
 func (p *OceanstorSanPlugin) commonHandler(ctx context.Context,
 	plugin *OceanstorSanPlugin, lun, parameters map[string]interface{},
 	method string) ([]reflect.Value, error) {
-	commonAttacher := attacher.NewAttacher(plugin.product, plugin.cli, plugin.protocol, "csi",
+	commonAttacher := attacher.NewAttacher(plugin.product, plugin.currentClient(), plugin.protocol, "csi",
 		plugin.portals, plugin.alua)
 
 	lunName, ok := lun["NAME"].(string)
@@ -258,14 +296,14 @@ func (p *OceanstorSanPlugin) AttachVolume(ctx context.Context, name string,
 	parameters map[string]interface{}) (map[string]interface{}, error) {
 	var localCli, metroCli client.BaseClientInterface
 	if p.storageOnline {
-		localCli = p.cli
+		localCli = p.currentClient()
 	}
 
 	if p.metroRemotePlugin != nil && p.metroRemotePlugin.storageOnline {
-		metroCli = p.metroRemotePlugin.cli
+		metroCli = p.metroRemotePlugin.currentClient()
 	}
 
-	lunName := p.cli.MakeLunName(name)
+	lunName := p.currentClient().MakeLunName(name)
 	lun, err := p.getLunInfo(ctx, localCli, metroCli, lunName)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Get lun %s error: %v", lunName, err)
@@ -301,14 +339,14 @@ func (p *OceanstorSanPlugin) AttachVolume(ctx context.Context, name string,
 func (p *OceanstorSanPlugin) DetachVolume(ctx context.Context, name string, parameters map[string]interface{}) error {
 	var localCli, metroCli client.BaseClientInterface
 	if p.storageOnline {
-		localCli = p.cli
+		localCli = p.currentClient()
 	}
 
 	if p.metroRemotePlugin != nil && p.metroRemotePlugin.storageOnline {
-		metroCli = p.metroRemotePlugin.cli
+		metroCli = p.metroRemotePlugin.currentClient()
 	}
 
-	lunName := p.cli.MakeLunName(name)
+	lunName := p.currentClient().MakeLunName(name)
 	lun, err := p.getLunInfo(ctx, localCli, metroCli, lunName)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Get lun %s error: %v", lunName, err)
@@ -375,8 +413,7 @@ func (p *OceanstorSanPlugin) CreateSnapshot(ctx context.Context,
 	lunName, snapshotName string) (map[string]interface{}, error) {
 	san := p.getSanObj()
 
-	snapshotName = utils.GetSnapshotName(snapshotName)
-	snapshot, err := san.CreateSnapshot(ctx, lunName, snapshotName)
+	snapshot, err := san.CreateSnapshot(ctx, lunName, utils.GetSnapshotName(snapshotName), snapshotName)
 	if err != nil {
 		return nil, err
 	}
@@ -397,12 +434,58 @@ func (p *OceanstorSanPlugin) DeleteSnapshot(ctx context.Context,
 	return nil
 }
 
+// QuerySnapshot used to query the snapshot by its parent id and name, return nil if it does not exist
+func (p *OceanstorSanPlugin) QuerySnapshot(ctx context.Context,
+	snapshotParentID, snapshotName string) (map[string]interface{}, error) {
+	san := p.getSanObj()
+	return san.QuerySnapshot(ctx, snapshotParentID, utils.GetSnapshotName(snapshotName))
+}
+
+// ListSnapshots used to list all snapshots of the volume, the names of the snapshots are the names in kubernetes
+func (p *OceanstorSanPlugin) ListSnapshots(ctx context.Context, name string) ([]map[string]interface{}, error) {
+	san := p.getSanObj()
+	snapshots, err := san.ListSnapshots(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, snapshot := range snapshots {
+		snapshot["Name"] = getK8sSnapshotName(snapshot, utils.GetSnapshotName, nil)
+	}
+	return snapshots, nil
+}
+
+// RevertVolumeToSnapshot used to roll the lun back to its snapshot, the lun must not be mapped to any host
+func (p *OceanstorSanPlugin) RevertVolumeToSnapshot(ctx context.Context,
+	name, snapshotParentID, snapshotName string) error {
+	san := p.getSanObj()
+	return san.RevertSnapshot(ctx, name, snapshotParentID, utils.GetSnapshotName(snapshotName))
+}
+
+// ModifyVolumeQoS used to change the qos of the lun and its hyperMetro remote lun
+func (p *OceanstorSanPlugin) ModifyVolumeQoS(ctx context.Context, name, qos string) error {
+	san := p.getSanObj()
+	return san.ModifyQoS(ctx, name, qos)
+}
+
+// CreateGroupSnapshot used to create the snapshots of the luns which are activated at the same point in time
+func (p *OceanstorSanPlugin) CreateGroupSnapshot(ctx context.Context,
+	names, snapshotNames []string) ([]map[string]interface{}, error) {
+	lunSnapshotNames := make([]string, 0, len(snapshotNames))
+	for _, snapshotName := range snapshotNames {
+		lunSnapshotNames = append(lunSnapshotNames, utils.GetSnapshotName(snapshotName))
+	}
+
+	san := p.getSanObj()
+	return san.CreateGroupSnapshot(ctx, names, lunSnapshotNames)
+}
+
 func (p *OceanstorSanPlugin) mutexGetClient(ctx context.Context) (client.BaseClientInterface, error) {
 	p.clientMutex.Lock()
 	defer p.clientMutex.Unlock()
 	var err error
 	if !p.storageOnline || p.clientCount == 0 {
-		err = p.cli.Login(ctx)
+		err = p.currentClient().Login(ctx)
 		p.storageOnline = err == nil
 		if err == nil {
 			p.clientCount++
@@ -411,7 +494,7 @@ func (p *OceanstorSanPlugin) mutexGetClient(ctx context.Context) (client.BaseCli
 		p.clientCount++
 	}
 
-	return p.cli, err
+	return p.currentClient(), err
 }
 
 func (p *OceanstorSanPlugin) getClient(ctx context.Context) (client.BaseClientInterface, client.BaseClientInterface, error) {
@@ -18,10 +18,12 @@ package plugin
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 
 	pkgUtils "huawei-csi-driver/pkg/utils"
 	"huawei-csi-driver/storage/oceanstor/client"
@@ -40,11 +42,27 @@ type OceanstorPlugin struct {
 
 	vStoreId string
 
+	// cliMutex protects cli, which is replaced by UpdateCredential while the CSI calls are using it
+	cliMutex     sync.RWMutex
 	cli          client.BaseClientInterface
 	product      string
 	capabilities map[string]interface{}
 }
 
+// currentClient returns the client of the storage currently logged in
+func (p *OceanstorPlugin) currentClient() client.BaseClientInterface {
+	p.cliMutex.RLock()
+	defer p.cliMutex.RUnlock()
+	return p.cli
+}
+
+// setClient used to replace the client of the storage, the calls started later use the new client
+func (p *OceanstorPlugin) setClient(cli client.BaseClientInterface) {
+	p.cliMutex.Lock()
+	defer p.cliMutex.Unlock()
+	p.cli = cli
+}
+
 func (p *OceanstorPlugin) init(config map[string]interface{}, keepLogin bool) error {
 	backendClientConfig, err := p.formatInitParam(config)
 	if err != nil {
@@ -74,6 +92,7 @@ func (p *OceanstorPlugin) init(config map[string]interface{}, keepLogin bool) er
 	}
 
 	if p.product == utils.OceanStorDoradoV6 {
+		log.Infoln("Using OceanStor V6 or Dorado V6 BaseClient.")
 		clientV6 := clientv6.NewClientV6(backendClientConfig)
 		cli.Logout(context.Background())
 		err := p.switchClient(clientV6)
@@ -81,7 +100,7 @@ func (p *OceanstorPlugin) init(config map[string]interface{}, keepLogin bool) er
 			return err
 		}
 	} else {
-		p.cli = cli
+		p.setClient(cli)
 	}
 	p.vStoreId = cli.VStoreID
 	return nil
@@ -120,11 +139,12 @@ func (p *OceanstorPlugin) formatInitParam(config map[string]interface{}) (res *c
 	}
 	res.VstoreName, _ = config["vstoreName"].(string)
 	res.ParallelNum, _ = config["maxClientThreads"].(string)
+	res.TLSConfig, err = getTLSConfig(context.Background(), config)
 	return
 }
 
 func (p *OceanstorPlugin) updateBackendCapabilities() (map[string]interface{}, error) {
-	features, err := p.cli.GetLicenseFeature(context.Background())
+	features, err := p.currentClient().GetLicenseFeature(context.Background())
 	if err != nil {
 		log.Errorf("Get license feature error: %v", err)
 		return nil, err
@@ -156,7 +176,7 @@ func (p *OceanstorPlugin) updateBackendCapabilities() (map[string]interface{}, e
 }
 
 func (p *OceanstorPlugin) getRemoteDevices() (string, error) {
-	devices, err := p.cli.GetAllRemoteDevices(context.Background())
+	devices, err := p.currentClient().GetAllRemoteDevices(context.Background())
 	if err != nil {
 		log.Errorf("Get remote devices error: %v", err)
 		return "", err
@@ -178,7 +198,7 @@ func (p *OceanstorPlugin) updateBackendSpecifications() (map[string]interface{},
 	}
 
 	specifications := map[string]interface{}{
-		"LocalDeviceSN":   p.cli.GetDeviceSN(),
+		"LocalDeviceSN":   p.currentClient().GetDeviceSN(),
 		"RemoteDevicesSN": devicesSN,
 	}
 	return specifications, nil
@@ -201,10 +221,13 @@ func (p *OceanstorPlugin) UpdateBackendCapabilities() (map[string]interface{}, m
 
 func (p *OceanstorPlugin) getParams(ctx context.Context, name string,
 	parameters map[string]interface{}) map[string]interface{} {
+	// the description and size may be absent when querying an existing volume
+	description, _ := parameters["description"].(string)
+	size, _ := parameters["size"].(int64)
 	params := map[string]interface{}{
 		"name":        name,
-		"description": parameters["description"].(string),
-		"capacity":    utils.RoundUpSize(parameters["size"].(int64), 512),
+		"description": description,
+		"capacity":    utils.RoundUpSize(size, 512),
 		"vstoreId":    "0",
 	}
 	for _, key := range []string{
@@ -259,7 +282,7 @@ func (p *OceanstorPlugin) getParams(ctx context.Context, name string,
 
 func (p *OceanstorPlugin) updatePoolCapabilities(poolNames []string,
 	usageType string) (map[string]interface{}, error) {
-	pools, err := p.cli.GetAllPools(context.Background())
+	pools, err := p.currentClient().GetAllPools(context.Background())
 	if err != nil {
 		log.Errorf("Get all pools error: %v", err)
 		return nil, err
@@ -292,9 +315,11 @@ func (p *OceanstorPlugin) analyzePoolsCapacity(pools []map[string]interface{}) m
 	for _, pool := range pools {
 		name := pool["NAME"].(string)
 		freeCapacity, _ := strconv.ParseInt(pool["USERFREECAPACITY"].(string), 10, 64)
+		totalCapacity, _ := strconv.ParseInt(utils.ToStringSafe(pool["USERTOTALCAPACITY"]), 10, 64)
 
 		capabilities[name] = map[string]interface{}{
-			"FreeCapacity": freeCapacity * 512,
+			"FreeCapacity":  freeCapacity * 512,
+			"TotalCapacity": totalCapacity * 512,
 		}
 	}
 
@@ -302,12 +327,13 @@ func (p *OceanstorPlugin) analyzePoolsCapacity(pools []map[string]interface{}) m
 }
 
 func (p *OceanstorPlugin) duplicateClient(ctx context.Context) (client.BaseClientInterface, error) {
-	err := p.cli.Login(ctx)
+	cli := p.currentClient()
+	err := cli.Login(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return p.cli, nil
+	return cli, nil
 }
 
 // SupportQoSParameters checks requested QoS parameters support by Oceanstor plugin
@@ -317,22 +343,69 @@ func (p *OceanstorPlugin) SupportQoSParameters(ctx context.Context, qosConfig st
 
 // Logout is to logout the storage session
 func (p *OceanstorPlugin) Logout(ctx context.Context) {
-	if p.cli != nil {
-		p.cli.Logout(ctx)
+	if cli := p.currentClient(); cli != nil {
+		cli.Logout(ctx)
+	}
+}
+
+// CheckHealth used to probe the management urls of the storage
+func (p *OceanstorPlugin) CheckHealth(ctx context.Context) []utils.URLHealth {
+	cli := p.currentClient()
+	if cli == nil {
+		return nil
+	}
+	return cli.CheckHealth(ctx)
+}
+
+// UpdateCredential used to log in with the credential of the backend config and replace the current client,
+// the current client is kept if the login fails
+func (p *OceanstorPlugin) UpdateCredential(ctx context.Context, config map[string]interface{}) error {
+	oldClient := p.currentClient()
+	if err := p.init(config, true); err != nil {
+		log.AddContext(ctx).Errorf("Login with the new credential failed, keep the current client, error: %v", err)
+		return err
+	}
+
+	if oldClient != nil {
+		oldClient.Logout(ctx)
+	}
+	return nil
+}
+
+// ChangePassword used to change the password of the account logged in by the plugin
+func (p *OceanstorPlugin) ChangePassword(ctx context.Context, oldPassword, newPassword string) error {
+	cli := p.currentClient()
+	if cli == nil {
+		return errors.New("the client of the storage is not initialized")
 	}
+
+	return cli.ChangeUserPassword(ctx, oldPassword, newPassword)
+}
+
+// VerifyPassword used to check whether the password of the account is valid by a new session, the current session
+// is not changed
+func (p *OceanstorPlugin) VerifyPassword(ctx context.Context, password string) error {
+	cli := p.currentClient()
+	if cli == nil {
+		return errors.New("the client of the storage is not initialized")
+	}
+
+	return cli.VerifyUserPassword(ctx, password)
 }
+
+// switchClient used to replace the current client with the new client after it logs in successfully
 func (p *OceanstorPlugin) switchClient(newClient client.BaseClientInterface) error {
-	log.Infoln("Using OceanStor V6 or Dorado V6 BaseClient.")
-	p.cli = newClient
-	if err := p.cli.Login(context.Background()); err != nil {
+	if err := newClient.Login(context.Background()); err != nil {
 		return err
 	}
 
-	_, err := p.cli.GetSystem(context.Background())
+	_, err := newClient.GetSystem(context.Background())
 	if err != nil {
 		log.Errorf("Get system info error: %v", err)
 		return err
 	}
+
+	p.setClient(newClient)
 	return nil
 }
 
@@ -391,5 +464,36 @@ func (p *OceanstorPlugin) getNewClientConfig(ctx context.Context, param map[stri
 	data.VstoreName, _ = param["vstoreName"].(string)
 	data.ParallelNum, _ = param["maxClientThreads"].(string)
 
+	// the password and the tls config are given by the caller which can't read the secrets by the driver, e.g.
+	// the login validation of oceanctl
+	data.Password, _ = param["password"].(string)
+	if tlsConfig, ok := param["tlsConfig"].(*tls.Config); ok {
+		data.TLSConfig = tlsConfig
+		return data, nil
+	}
+
+	tlsConfig, err := getTLSConfig(ctx, param)
+	if err != nil {
+		return data, err
+	}
+	data.TLSConfig = tlsConfig
+
 	return data, nil
 }
+
+// getK8sSnapshotName used to get the name of the snapshot in kubernetes, which is saved as the description of the
+// snapshot when the snapshot is created. The snapshot created before doesn't have it, and its name on the storage
+// is converted back by fromStorageName, or returned as is if fromStorageName is nil.
+func getK8sSnapshotName(snapshot map[string]interface{},
+	toStorageName, fromStorageName func(string) string) string {
+	name, _ := snapshot["Name"].(string)
+	description, _ := snapshot["Description"].(string)
+	if description != "" && toStorageName(description) == name {
+		return description
+	}
+
+	if fromStorageName == nil {
+		return name
+	}
+	return fromStorageName(name)
+}
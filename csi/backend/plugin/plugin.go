@@ -18,9 +18,11 @@ package plugin
 
 import (
 	"context"
+	"crypto/tls"
 
 	// init the nfs connector
 	_ "huawei-csi-driver/connector/nfs"
+	pkgUtils "huawei-csi-driver/pkg/utils"
 	"huawei-csi-driver/utils"
 )
 
@@ -30,6 +32,11 @@ type Plugin interface {
 	CreateVolume(context.Context, string, map[string]interface{}) (utils.Volume, error)
 	QueryVolume(context.Context, string, map[string]interface{}) (utils.Volume, error)
 	DeleteVolume(context.Context, string) error
+	// ListVolumes used to list the volumes with the given name prefix in the given pools
+	ListVolumes(context.Context, string, []string) ([]utils.Volume, error)
+	// QueryVolumeCondition used to query the published nodes and the abnormal condition of the volume,
+	// return nil if the volume does not exist
+	QueryVolumeCondition(context.Context, string) (map[string]interface{}, error)
 	ExpandVolume(context.Context, string, int64) (bool, error)
 	AttachVolume(context.Context, string, map[string]interface{}) (map[string]interface{}, error)
 	DetachVolume(context.Context, string, map[string]interface{}) error
@@ -39,8 +46,17 @@ type Plugin interface {
 	UpdateReplicaRemotePlugin(Plugin)
 	CreateSnapshot(context.Context, string, string) (map[string]interface{}, error)
 	DeleteSnapshot(context.Context, string, string) error
+	// QuerySnapshot used to query the snapshot by its parent id and name, return nil if it does not exist
+	QuerySnapshot(context.Context, string, string) (map[string]interface{}, error)
+	// ListSnapshots used to list all snapshots of the volume
+	ListSnapshots(context.Context, string) ([]map[string]interface{}, error)
 	SmartXQoSQuery
 	Logout(context.Context)
+	// CheckHealth used to probe the management urls of the storage, return nil if the plugin is not initialized
+	CheckHealth(context.Context) []utils.URLHealth
+	// UpdateCredential used to log in with the credential of the backend config and replace the current client,
+	// the current client is kept if the login fails
+	UpdateCredential(context.Context, map[string]interface{}) error
 	// Validate used to check parameters, include login verification
 	Validate(context.Context, map[string]interface{}) error
 
@@ -48,6 +64,55 @@ type Plugin interface {
 	ExpandDTreeVolume(context.Context, map[string]interface{}) (bool, error)
 }
 
+// PasswordRotator is implemented by the plugins which can change the password of the storage account
+type PasswordRotator interface {
+	// ChangePassword used to change the password of the account logged in by the plugin
+	ChangePassword(ctx context.Context, oldPassword, newPassword string) error
+	// VerifyPassword used to check whether the password of the account is valid by a new session
+	VerifyPassword(ctx context.Context, password string) error
+}
+
+// PairsQuerier is implemented by the plugins whose volumes can be protected by hyperMetro or replication pairs
+type PairsQuerier interface {
+	// QueryVolumePairs used to query the status of the hyperMetro and the replication pairs of the volume,
+	// return nil if the volume does not exist
+	QueryVolumePairs(ctx context.Context, name string) ([]map[string]interface{}, error)
+}
+
+// ReplicationOperator is implemented by the plugins whose volumes can be promoted on the disaster recovery site
+type ReplicationOperator interface {
+	// SwitchoverVolumeReplication used to make the local volume the primary of its replication pairs in a
+	// planned way, the remote volume becomes the read-only secondary
+	SwitchoverVolumeReplication(ctx context.Context, name string, parameters map[string]interface{}) error
+	// FailoverVolumeReplication used to split the replication pairs and make the local secondary volume
+	// writable when the primary site is lost
+	FailoverVolumeReplication(ctx context.Context, name string, parameters map[string]interface{}) error
+	// ResyncVolumeReplication used to resume the replication pairs after the failed site recovers, the local
+	// volume becomes the primary if reverse
+	ResyncVolumeReplication(ctx context.Context, name string, reverse bool) error
+}
+
+// SnapshotReverter is implemented by the plugins whose volumes can be rolled back to their snapshots in place
+type SnapshotReverter interface {
+	// RevertVolumeToSnapshot used to roll the volume back to its snapshot, the data written to the volume after
+	// the snapshot is taken is lost
+	RevertVolumeToSnapshot(ctx context.Context, name, snapshotParentID, snapshotName string) error
+}
+
+// GroupSnapshotter is implemented by the plugins which can take crash-consistent snapshots of several volumes
+type GroupSnapshotter interface {
+	// CreateGroupSnapshot used to create the snapshots of the volumes at the same point in time, the snapshot of
+	// names[i] is named as snapshotNames[i] and the returned snapshots are in the same order
+	CreateGroupSnapshot(ctx context.Context, names, snapshotNames []string) ([]map[string]interface{}, error)
+}
+
+// QoSModifier is implemented by the plugins which can change the QoS of the volumes online
+type QoSModifier interface {
+	// ModifyVolumeQoS used to change the QoS of the volume to the qos config, which is in the format of the
+	// "qos" parameter of the StorageClass. The QoS of the volume is removed if the qos config is empty.
+	ModifyVolumeQoS(ctx context.Context, name, qos string) error
+}
+
 // SmartXQoSQuery provides Quality of Service(QoS) Query operations
 type SmartXQoSQuery interface {
 	// SupportQoSParameters checks requested QoS parameters support by Plugin
@@ -91,3 +156,13 @@ func (p *basePlugin) UpdateMetroRemotePlugin(Plugin) {
 
 func (p *basePlugin) UpdateReplicaRemotePlugin(Plugin) {
 }
+
+// getTLSConfig used to build the tls config of the storage client by the tls settings of the backend config
+func getTLSConfig(ctx context.Context, config map[string]interface{}) (*tls.Config, error) {
+	backendTLSConfig, err := pkgUtils.ParseBackendTLSConfig(config)
+	if err != nil {
+		return nil, pkgUtils.Errorf(ctx, "parse tls config of backend %v failed, error: %v", config["backendID"], err)
+	}
+
+	return pkgUtils.NewTLSConfig(ctx, backendTLSConfig)
+}
@@ -0,0 +1,46 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package backend
+
+import (
+	"fmt"
+
+	"huawei-csi-driver/csi/backend/plugin"
+	"huawei-csi-driver/utils"
+)
+
+// GetReplicationOperator returns the replication operator of the backend of the volume and the volume name
+// in the backend
+func GetReplicationOperator(volumeID string) (plugin.ReplicationOperator, string, error) {
+	backendName, volName := utils.SplitVolumeId(volumeID)
+	mutex.Lock()
+	bk, exist := csiBackends[backendName]
+	mutex.Unlock()
+	if !exist {
+		return nil, "", fmt.Errorf("backend %s is not registered", backendName)
+	}
+	if !bk.Available {
+		return nil, "", fmt.Errorf("backend %s is unavailable", backendName)
+	}
+
+	operator, ok := bk.Plugin.(plugin.ReplicationOperator)
+	if !ok {
+		return nil, "", fmt.Errorf("the storage %s of backend %s does not support replication operations",
+			bk.Storage, backendName)
+	}
+	return operator, volName, nil
+}
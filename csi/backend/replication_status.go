@@ -0,0 +1,192 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	coreV1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xuanwuV1 "huawei-csi-driver/client/apis/xuanwu/v1"
+	"huawei-csi-driver/csi/app"
+	"huawei-csi-driver/csi/backend/plugin"
+	"huawei-csi-driver/utils"
+	"huawei-csi-driver/utils/log"
+)
+
+// SyncVolumeReplicationStatuses used to report the hyperMetro and replication pairs of the PersistentVolumes as
+// VolumeReplicationStatus in the namespace of the driver. A VolumeReplicationStatus is created once the volume has
+// any pair, and is deleted once the PersistentVolume is deleted or the volume has no pair.
+func SyncVolumeReplicationStatuses(ctx context.Context) {
+	cfg := app.GetGlobalConfig()
+	pvs, err := cfg.K8sUtils.ListVolumesByDriver(ctx, cfg.DriverName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("List volumes of driver %s failed, error: %v", cfg.DriverName, err)
+		return
+	}
+
+	statusList, err := cfg.BackendUtils.XuanwuV1().VolumeReplicationStatuses(cfg.Namespace).List(ctx,
+		metaV1.ListOptions{})
+	if err != nil {
+		log.AddContext(ctx).Errorf("List volume replication statuses failed, error: %v", err)
+		return
+	}
+
+	existStatuses := make(map[string]*xuanwuV1.VolumeReplicationStatus, len(statusList.Items))
+	for i := range statusList.Items {
+		existStatuses[statusList.Items[i].Name] = &statusList.Items[i]
+	}
+
+	for _, pv := range pvs {
+		status, exist := existStatuses[pv.Name]
+		delete(existStatuses, pv.Name)
+
+		pairs, err := queryVolumePairs(ctx, pv.Spec.CSI.VolumeHandle)
+		if err != nil {
+			// keep the status of the pairs, the message tells why they are not refreshed
+			log.AddContext(ctx).Warningf("Query pairs of volume %s failed, error: %v", pv.Name, err)
+			if exist {
+				updateVolumeReplicationStatus(ctx, status, nil, err.Error())
+			}
+			continue
+		}
+
+		if len(pairs) == 0 {
+			if exist {
+				deleteVolumeReplicationStatus(ctx, status)
+			}
+			continue
+		}
+
+		if !exist {
+			if status, err = createVolumeReplicationStatus(ctx, pv); err != nil {
+				log.AddContext(ctx).Errorf("Create volume replication status of %s failed, error: %v", pv.Name, err)
+				continue
+			}
+		}
+		updateVolumeReplicationStatus(ctx, status, pairs, "")
+	}
+
+	// the PersistentVolumes of the rest are deleted
+	for _, status := range existStatuses {
+		deleteVolumeReplicationStatus(ctx, status)
+	}
+}
+
+// queryVolumePairs returns the status of the pairs of the volume, it is empty if the storage does not support
+// pairs or the volume does not exist
+func queryVolumePairs(ctx context.Context, volumeHandle string) ([]xuanwuV1.ReplicationPairStatus, error) {
+	backendName, volName := utils.SplitVolumeId(volumeHandle)
+	mutex.Lock()
+	bk, exist := csiBackends[backendName]
+	mutex.Unlock()
+	if !exist {
+		return nil, fmt.Errorf("backend %s is not registered", backendName)
+	}
+	if !bk.Available {
+		return nil, fmt.Errorf("backend %s is unavailable", backendName)
+	}
+
+	querier, ok := bk.Plugin.(plugin.PairsQuerier)
+	if !ok {
+		return nil, nil
+	}
+
+	pairs, err := querier.QueryVolumePairs(ctx, volName)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]xuanwuV1.ReplicationPairStatus, 0, len(pairs))
+	for _, pair := range pairs {
+		statuses = append(statuses, toReplicationPairStatus(pair))
+	}
+	return statuses, nil
+}
+
+func toReplicationPairStatus(pair map[string]interface{}) xuanwuV1.ReplicationPairStatus {
+	pairType, _ := pair["Type"].(string)
+	status := xuanwuV1.ReplicationPairStatus{Type: xuanwuV1.ReplicationPairType(pairType)}
+	status.PairID, _ = pair["ID"].(string)
+	status.RunningStatus, _ = pair["RunningStatus"].(string)
+	status.HealthStatus, _ = pair["HealthStatus"].(string)
+	status.SyncProgress, _ = pair["SyncProgress"].(string)
+	status.RemoteDeviceSN, _ = pair["RemoteDeviceSN"].(string)
+
+	// the storage returns 0 or the max uint32 if the pair is never synchronized
+	lastSyncTime, _ := pair["LastSyncTime"].(string)
+	if seconds, err := strconv.ParseInt(lastSyncTime, 10, 64); err == nil && seconds > 0 && seconds < math.MaxUint32 {
+		syncTime := metaV1.Unix(seconds, 0)
+		status.LastSyncTime = &syncTime
+	}
+	return status
+}
+
+func createVolumeReplicationStatus(ctx context.Context,
+	pv coreV1.PersistentVolume) (*xuanwuV1.VolumeReplicationStatus, error) {
+	status := &xuanwuV1.VolumeReplicationStatus{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      pv.Name,
+			Namespace: app.GetGlobalConfig().Namespace,
+		},
+		Spec: xuanwuV1.VolumeReplicationStatusSpec{
+			VolumeName:   pv.Name,
+			VolumeHandle: pv.Spec.CSI.VolumeHandle,
+		},
+	}
+
+	created, err := app.GetGlobalConfig().BackendUtils.XuanwuV1().VolumeReplicationStatuses(status.Namespace).
+		Create(ctx, status, metaV1.CreateOptions{})
+	if apiErrors.IsAlreadyExists(err) {
+		return nil, errors.New("it is created by others")
+	}
+	return created, err
+}
+
+func updateVolumeReplicationStatus(ctx context.Context, status *xuanwuV1.VolumeReplicationStatus,
+	pairs []xuanwuV1.ReplicationPairStatus, message string) {
+	newStatus := status.DeepCopy()
+	if newStatus.Status == nil {
+		newStatus.Status = &xuanwuV1.VolumeReplicationStatusStatus{}
+	}
+	if pairs != nil {
+		now := metaV1.Now()
+		newStatus.Status.Pairs = pairs
+		newStatus.Status.LastUpdateTime = &now
+	}
+	newStatus.Status.Message = message
+
+	_, err := app.GetGlobalConfig().BackendUtils.XuanwuV1().VolumeReplicationStatuses(status.Namespace).
+		UpdateStatus(ctx, newStatus, metaV1.UpdateOptions{})
+	if err != nil {
+		log.AddContext(ctx).Errorf("Update volume replication status %s failed, error: %v", status.Name, err)
+	}
+}
+
+func deleteVolumeReplicationStatus(ctx context.Context, status *xuanwuV1.VolumeReplicationStatus) {
+	err := app.GetGlobalConfig().BackendUtils.XuanwuV1().VolumeReplicationStatuses(status.Namespace).
+		Delete(ctx, status.Name, metaV1.DeleteOptions{})
+	if err != nil && !apiErrors.IsNotFound(err) {
+		log.AddContext(ctx).Errorf("Delete volume replication status %s failed, error: %v", status.Name, err)
+	}
+}
@@ -0,0 +1,226 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+
+	"huawei-csi-driver/cli/helper"
+	"huawei-csi-driver/csi/app"
+	"huawei-csi-driver/csi/backend/plugin"
+	"huawei-csi-driver/utils"
+	"huawei-csi-driver/utils/log"
+)
+
+const (
+	// RevertToSnapshotAnnotation is set on the PVC to roll its volume back to the VolumeSnapshot of the value in
+	// place, the VolumeSnapshot must be in the namespace of the PVC. It is removed once the revert is finished.
+	RevertToSnapshotAnnotation = "xuanwu.huawei.io/revertToSnapshot"
+	// RevertStatusAnnotation records the status of the last revert of the PVC
+	RevertStatusAnnotation = "xuanwu.huawei.io/revertStatus"
+	// RevertMessageAnnotation records the message of the last revert of the PVC
+	RevertMessageAnnotation = "xuanwu.huawei.io/revertMessage"
+
+	revertStatusReverting = "Reverting"
+	revertStatusSucceeded = "Succeeded"
+	revertStatusFailed    = "Failed"
+)
+
+// revertingClaims holds the keys of the PVCs being reverted, so the resync of the informer does not start
+// another revert of the same PVC
+var revertingClaims sync.Map
+
+// NewVolumeClaimEventHandler returns the handler which reverts the volumes of the PVCs annotated with
+// RevertToSnapshotAnnotation and changes the QoS of the volumes of the PVCs annotated with QoSAnnotation.
+// No task is started once the ctx is done, e.g. the controller is no longer the leader.
+func NewVolumeClaimEventHandler(ctx context.Context) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			onVolumeClaimChanged(ctx, obj)
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			onVolumeClaimChanged(ctx, newObj)
+		},
+	}
+}
+
+func onVolumeClaimChanged(ctx context.Context, obj interface{}) {
+	pvc, ok := obj.(*coreV1.PersistentVolumeClaim)
+	if !ok || ctx.Err() != nil {
+		return
+	}
+
+	if pvc.Annotations[RevertToSnapshotAnnotation] != "" {
+		startVolumeClaimTask(&revertingClaims, pvc, revertVolumeClaim)
+	}
+	if isVolumeClaimQoSChanged(pvc) {
+		startVolumeClaimTask(&modifyingQoSClaims, pvc, modifyVolumeClaimQoS)
+	}
+}
+
+// startVolumeClaimTask used to run the task of the PVC in background if the PVC is not in the running claims
+func startVolumeClaimTask(runningClaims *sync.Map, pvc *coreV1.PersistentVolumeClaim,
+	task func(context.Context, *coreV1.PersistentVolumeClaim)) {
+	key := pvc.Namespace + "/" + pvc.Name
+	if _, running := runningClaims.LoadOrStore(key, struct{}{}); running {
+		return
+	}
+
+	go func() {
+		defer runningClaims.Delete(key)
+		task(context.Background(), pvc)
+	}()
+}
+
+// getVolumeHandleOfClaim used to get the volume ID of the bound PVC, false is returned if the PVC is not bound
+// or belongs to another driver
+func getVolumeHandleOfClaim(ctx context.Context, pvc *coreV1.PersistentVolumeClaim) (string, bool) {
+	cfg := app.GetGlobalConfig()
+	if pvc.Spec.VolumeName == "" {
+		log.AddContext(ctx).Warningf("PVC %s/%s is not bound", pvc.Namespace, pvc.Name)
+		return "", false
+	}
+
+	pv, err := cfg.K8sUtils.GetVolumeByName(ctx, pvc.Spec.VolumeName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get volume %s of PVC %s/%s failed, error: %v",
+			pvc.Spec.VolumeName, pvc.Namespace, pvc.Name, err)
+		return "", false
+	}
+	if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != cfg.DriverName {
+		return "", false
+	}
+
+	return pv.Spec.CSI.VolumeHandle, true
+}
+
+// revertVolumeClaim used to revert the volume of the PVC to the snapshot of the annotation and record the
+// result in the annotations of the PVC. The PVCs of the other drivers are skipped.
+func revertVolumeClaim(ctx context.Context, pvc *coreV1.PersistentVolumeClaim) {
+	volumeID, ok := getVolumeHandleOfClaim(ctx, pvc)
+	if !ok {
+		return
+	}
+
+	snapshotName := pvc.Annotations[RevertToSnapshotAnnotation]
+	log.AddContext(ctx).Infof("Start to revert PVC %s/%s to snapshot %s", pvc.Namespace, pvc.Name, snapshotName)
+	err := updateRevertStatus(ctx, pvc, revertStatusReverting,
+		fmt.Sprintf("reverting to snapshot %s", snapshotName), false)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Update revert status of PVC %s/%s failed, error: %v",
+			pvc.Namespace, pvc.Name, err)
+		return
+	}
+
+	status, message := revertStatusSucceeded, fmt.Sprintf("reverted to snapshot %s", snapshotName)
+	if err = revertVolumeClaimToSnapshot(ctx, pvc, volumeID, snapshotName); err != nil {
+		log.AddContext(ctx).Errorf("Revert PVC %s/%s to snapshot %s failed, error: %v",
+			pvc.Namespace, pvc.Name, snapshotName, err)
+		status, message = revertStatusFailed, fmt.Sprintf("revert to snapshot %s failed: %v", snapshotName, err)
+	}
+
+	if err = updateRevertStatus(ctx, pvc, status, message, true); err != nil {
+		log.AddContext(ctx).Errorf("Update revert status of PVC %s/%s to %s failed, error: %v",
+			pvc.Namespace, pvc.Name, status, err)
+		return
+	}
+	log.AddContext(ctx).Infof("Finish to revert PVC %s/%s, %s", pvc.Namespace, pvc.Name, message)
+}
+
+// revertVolumeClaimToSnapshot used to revert the volume after making sure it is not used by any pod or node
+func revertVolumeClaimToSnapshot(ctx context.Context, pvc *coreV1.PersistentVolumeClaim,
+	volumeID, snapshotName string) error {
+	cfg := app.GetGlobalConfig()
+	users, err := cfg.K8sUtils.ListVolumeClaimUsers(ctx, pvc)
+	if err != nil {
+		return fmt.Errorf("list users of the volume failed, error: %v", err)
+	}
+	if len(users) > 0 {
+		return fmt.Errorf("the volume is still used by %s", strings.Join(users, ", "))
+	}
+
+	snapshotID, err := cfg.K8sUtils.GetVolumeSnapshotHandle(ctx, pvc.Namespace, snapshotName)
+	if err != nil {
+		return err
+	}
+
+	return RevertVolumeToSnapshot(ctx, volumeID, snapshotID)
+}
+
+// updateRevertStatus used to set the revert status annotations of the PVC, and remove the revert annotation if
+// the revert is finished
+func updateRevertStatus(ctx context.Context, pvc *coreV1.PersistentVolumeClaim,
+	status, message string, finished bool) error {
+	snapshotName := pvc.Annotations[RevertToSnapshotAnnotation]
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := app.GetGlobalConfig().K8sUtils.GetVolumeClaim(ctx, pvc.Namespace, pvc.Name)
+		if err != nil {
+			return err
+		}
+
+		if latest.Annotations == nil {
+			latest.Annotations = map[string]string{}
+		}
+		// keep the annotation if it is changed to another snapshot during the revert
+		if finished && latest.Annotations[RevertToSnapshotAnnotation] == snapshotName {
+			delete(latest.Annotations, RevertToSnapshotAnnotation)
+		}
+		latest.Annotations[RevertStatusAnnotation] = status
+		latest.Annotations[RevertMessageAnnotation] = message
+
+		_, err = app.GetGlobalConfig().K8sUtils.UpdateVolumeClaim(ctx, latest)
+		return err
+	})
+}
+
+// RevertVolumeToSnapshot used to roll the volume back to the snapshot in place, the snapshot must be taken from
+// the volume
+func RevertVolumeToSnapshot(ctx context.Context, volumeID, snapshotID string) error {
+	backendName, volName := utils.SplitVolumeId(volumeID)
+	snapshotBackend, snapshotParentID, snapshotName := utils.SplitSnapshotId(snapshotID)
+	if snapshotName == "" {
+		return fmt.Errorf("snapshot id %s is invalid", snapshotID)
+	}
+	if helper.GetBackendName(snapshotBackend) != backendName {
+		return errors.New("the snapshot is not in the backend of the volume")
+	}
+
+	mutex.Lock()
+	bk, exist := csiBackends[backendName]
+	mutex.Unlock()
+	if !exist {
+		return fmt.Errorf("backend %s is not registered", backendName)
+	}
+	if !bk.Available {
+		return fmt.Errorf("backend %s is unavailable", backendName)
+	}
+
+	reverter, ok := bk.Plugin.(plugin.SnapshotReverter)
+	if !ok {
+		return fmt.Errorf("the storage %s of backend %s does not support reverting to snapshot",
+			bk.Storage, backendName)
+	}
+	return reverter.RevertVolumeToSnapshot(ctx, volName, snapshotParentID, snapshotName)
+}
@@ -0,0 +1,124 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package backend
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/retry"
+
+	"huawei-csi-driver/csi/app"
+	"huawei-csi-driver/csi/backend/plugin"
+	"huawei-csi-driver/utils"
+	"huawei-csi-driver/utils/log"
+)
+
+const (
+	// QoSAnnotation is set on the PVC to change the QoS of its volume online, the value is in the format of the
+	// "qos" parameter of the StorageClass, e.g. {"MAXIOPS":1000}. An empty value removes the QoS of the volume.
+	QoSAnnotation = "xuanwu.huawei.io/qos"
+	// ObservedQoSAnnotation records the value of QoSAnnotation which is applied last time
+	ObservedQoSAnnotation = "xuanwu.huawei.io/observedQoS"
+	// QoSStatusAnnotation records the status of the last QoS modification of the PVC
+	QoSStatusAnnotation = "xuanwu.huawei.io/qosStatus"
+	// QoSMessageAnnotation records the message of the last QoS modification of the PVC
+	QoSMessageAnnotation = "xuanwu.huawei.io/qosMessage"
+
+	qosStatusSucceeded = "Succeeded"
+	qosStatusFailed    = "Failed"
+)
+
+// modifyingQoSClaims holds the keys of the PVCs whose QoS is being modified
+var modifyingQoSClaims sync.Map
+
+// isVolumeClaimQoSChanged used to check whether the QoS annotation of the PVC is not applied yet. A failed
+// modification is not retried until the annotation is changed again.
+func isVolumeClaimQoSChanged(pvc *coreV1.PersistentVolumeClaim) bool {
+	qos, exist := pvc.Annotations[QoSAnnotation]
+	if !exist {
+		return false
+	}
+
+	observed, observedExist := pvc.Annotations[ObservedQoSAnnotation]
+	return !observedExist || observed != qos
+}
+
+// modifyVolumeClaimQoS used to change the QoS of the volume of the PVC to the value of the annotation and record
+// the result in the annotations of the PVC. The PVCs of the other drivers are skipped. Like the revert, it is
+// started by the handler of NewVolumeClaimEventHandler only while the controller is the leader.
+func modifyVolumeClaimQoS(ctx context.Context, pvc *coreV1.PersistentVolumeClaim) {
+	volumeID, ok := getVolumeHandleOfClaim(ctx, pvc)
+	if !ok {
+		return
+	}
+
+	qos := pvc.Annotations[QoSAnnotation]
+	log.AddContext(ctx).Infof("Start to modify qos of PVC %s/%s to %s", pvc.Namespace, pvc.Name, qos)
+	status, message := qosStatusSucceeded, fmt.Sprintf("qos is modified to %s", qos)
+	if err := ModifyVolumeQoS(ctx, volumeID, qos); err != nil {
+		log.AddContext(ctx).Errorf("Modify qos of PVC %s/%s to %s failed, error: %v",
+			pvc.Namespace, pvc.Name, qos, err)
+		status, message = qosStatusFailed, fmt.Sprintf("modify qos to %s failed: %v", qos, err)
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := app.GetGlobalConfig().K8sUtils.GetVolumeClaim(ctx, pvc.Namespace, pvc.Name)
+		if err != nil {
+			return err
+		}
+
+		if latest.Annotations == nil {
+			latest.Annotations = map[string]string{}
+		}
+		latest.Annotations[ObservedQoSAnnotation] = qos
+		latest.Annotations[QoSStatusAnnotation] = status
+		latest.Annotations[QoSMessageAnnotation] = message
+
+		_, err = app.GetGlobalConfig().K8sUtils.UpdateVolumeClaim(ctx, latest)
+		return err
+	})
+	if err != nil {
+		log.AddContext(ctx).Errorf("Update qos status of PVC %s/%s to %s failed, error: %v",
+			pvc.Namespace, pvc.Name, status, err)
+		return
+	}
+	log.AddContext(ctx).Infof("Finish to modify qos of PVC %s/%s, %s", pvc.Namespace, pvc.Name, message)
+}
+
+// ModifyVolumeQoS used to change the QoS of the volume online, the QoS is removed if the qos is empty
+func ModifyVolumeQoS(ctx context.Context, volumeID, qos string) error {
+	backendName, volName := utils.SplitVolumeId(volumeID)
+
+	mutex.Lock()
+	bk, exist := csiBackends[backendName]
+	mutex.Unlock()
+	if !exist {
+		return fmt.Errorf("backend %s is not registered", backendName)
+	}
+	if !bk.Available {
+		return fmt.Errorf("backend %s is unavailable", backendName)
+	}
+
+	modifier, ok := bk.Plugin.(plugin.QoSModifier)
+	if !ok {
+		return fmt.Errorf("the storage %s of backend %s does not support modifying qos", bk.Storage, backendName)
+	}
+	return modifier.ModifyVolumeQoS(ctx, volName, qos)
+}
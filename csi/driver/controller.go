@@ -24,9 +24,11 @@ import (
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
 	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"huawei-csi-driver/cli/helper"
 	"huawei-csi-driver/csi/app"
 	"huawei-csi-driver/csi/backend"
 	"huawei-csi-driver/csi/backend/plugin"
@@ -231,15 +233,94 @@ func (d *Driver) ControllerUnpublishVolume(ctx context.Context, req *csi.Control
 func (d *Driver) ValidateVolumeCapabilities(ctx context.Context, req *csi.ValidateVolumeCapabilitiesRequest) (
 	*csi.ValidateVolumeCapabilitiesResponse, error) {
 
-	return nil, status.Error(codes.Unimplemented, "Not implemented")
+	volumeId := req.GetVolumeId()
+	if volumeId == "" {
+		return nil, status.Error(codes.InvalidArgument, "no volume ID provided")
+	}
+
+	volumeCapabilities := req.GetVolumeCapabilities()
+	if len(volumeCapabilities) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Volume Capabilities missing in request")
+	}
+	log.AddContext(ctx).Infof("Start to validate volume capabilities of volume %s", volumeId)
+
+	// the backend is not registered if it's offline, so whether the volume exists is unknown then
+	backendName, volName := utils.SplitVolumeId(volumeId)
+	backend := backend.GetBackendWithFresh(ctx, backendName, true)
+	if backend == nil {
+		msg := fmt.Sprintf("Backend %s doesn't exist or is not available", backendName)
+		log.AddContext(ctx).Errorln(msg)
+		return nil, status.Error(codes.Unavailable, msg)
+	}
+
+	// the condition is nil only if the volume doesn't exist, other errors may be transient
+	condition, err := backend.Plugin.QueryVolumeCondition(ctx, volName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Query volume %s error: %v", volumeId, err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if condition == nil {
+		msg := fmt.Sprintf("Volume %s doesn't exist", volumeId)
+		log.AddContext(ctx).Errorln(msg)
+		return nil, status.Error(codes.NotFound, msg)
+	}
+
+	msg := validateCapabilitiesAndType(volumeCapabilities, getVolumeTypeOfStorage(backend.Storage))
+	if msg != "" {
+		log.AddContext(ctx).Warningf("Validate volume capabilities of volume %s failed: %s", volumeId, msg)
+		return &csi.ValidateVolumeCapabilitiesResponse{Message: msg}, nil
+	}
+
+	log.AddContext(ctx).Infof("Finish to validate volume capabilities of volume %s", volumeId)
+	return &csi.ValidateVolumeCapabilitiesResponse{
+		Confirmed: &csi.ValidateVolumeCapabilitiesResponse_Confirmed{
+			VolumeContext:      req.GetVolumeContext(),
+			VolumeCapabilities: volumeCapabilities,
+			Parameters:         req.GetParameters(),
+		},
+	}, nil
 }
 
 func (d *Driver) ListVolumes(ctx context.Context, req *csi.ListVolumesRequest) (*csi.ListVolumesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "Not implemented")
+	log.AddContext(ctx).Infof("Start to list volumes, max entries %d, starting token %s",
+		req.GetMaxEntries(), req.GetStartingToken())
+	if req.GetMaxEntries() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max entries can not be negative")
+	}
+
+	entries, err := listVolumeEntries(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	page, nextToken, ok := paginateEntries(entries, func(entry *csi.ListVolumesResponse_Entry) string {
+		return entry.GetVolume().GetVolumeId()
+	}, req.GetStartingToken(), req.GetMaxEntries())
+	if !ok {
+		return nil, status.Errorf(codes.Aborted, "starting token %s is unknown", req.GetStartingToken())
+	}
+	log.AddContext(ctx).Infof("Finish to list volumes, return %d entries, next token %s", len(page), nextToken)
+	return &csi.ListVolumesResponse{Entries: page, NextToken: nextToken}, nil
 }
 
 func (d *Driver) GetCapacity(ctx context.Context, req *csi.GetCapacityRequest) (*csi.GetCapacityResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "Not implemented")
+	parameters := utils.CopyMap(req.GetParameters())
+	backendName, exist := parameters["backend"].(string)
+	if exist {
+		parameters["backend"] = helper.GetBackendName(backendName)
+	}
+
+	availableCapacity, maximumVolumeSize, err := backend.GetPoolsCapacity(ctx, parameters,
+		req.GetAccessibleTopology().GetSegments())
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get capacity with parameters %v error: %v", parameters, err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &csi.GetCapacityResponse{
+		AvailableCapacity: availableCapacity,
+		MaximumVolumeSize: &wrappers.Int64Value{Value: maximumVolumeSize},
+	}, nil
 }
 
 func (d *Driver) ControllerGetCapabilities(ctx context.Context, req *csi.ControllerGetCapabilitiesRequest) (
@@ -282,6 +363,48 @@ func (d *Driver) ControllerGetCapabilities(ctx context.Context, req *csi.Control
 					},
 				},
 			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{
+						Type: csi.ControllerServiceCapability_RPC_LIST_VOLUMES,
+					},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{
+						Type: csi.ControllerServiceCapability_RPC_GET_CAPACITY,
+					},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{
+						Type: csi.ControllerServiceCapability_RPC_LIST_SNAPSHOTS,
+					},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{
+						Type: csi.ControllerServiceCapability_RPC_GET_VOLUME,
+					},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{
+						Type: csi.ControllerServiceCapability_RPC_VOLUME_CONDITION,
+					},
+				},
+			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{
+						Type: csi.ControllerServiceCapability_RPC_MODIFY_VOLUME,
+					},
+				},
+			},
 		},
 	}, nil
 }
@@ -353,12 +476,120 @@ func (d *Driver) DeleteSnapshot(ctx context.Context, req *csi.DeleteSnapshotRequ
 	return &csi.DeleteSnapshotResponse{}, nil
 }
 
-func (d *Driver) ListSnapshots(ctx context.Context, req *csi.ListSnapshotsRequest) (*csi.ListSnapshotsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "")
+func (d *Driver) ListSnapshots(ctx context.Context, req *csi.ListSnapshotsRequest) (
+	*csi.ListSnapshotsResponse, error) {
+	log.AddContext(ctx).Infof("Start to list snapshots, snapshot %s, source volume %s, max entries %d, "+
+		"starting token %s", req.GetSnapshotId(), req.GetSourceVolumeId(), req.GetMaxEntries(),
+		req.GetStartingToken())
+	if req.GetMaxEntries() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max entries can not be negative")
+	}
+
+	entries, err := listSnapshotEntries(ctx, req)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	page, nextToken, ok := paginateEntries(entries, func(entry *csi.ListSnapshotsResponse_Entry) string {
+		return entry.GetSnapshot().GetSnapshotId()
+	}, req.GetStartingToken(), req.GetMaxEntries())
+	if !ok {
+		return nil, status.Errorf(codes.Aborted, "starting token %s is unknown", req.GetStartingToken())
+	}
+	log.AddContext(ctx).Infof("Finish to list snapshots, return %d entries, next token %s", len(page), nextToken)
+	return &csi.ListSnapshotsResponse{Entries: page, NextToken: nextToken}, nil
 }
 
-// ControllerGetVolume is to get volume info, but unimplemented
+// ControllerGetVolume is to get volume info with the published nodes and the volume condition
 func (d *Driver) ControllerGetVolume(ctx context.Context, req *csi.ControllerGetVolumeRequest) (
 	*csi.ControllerGetVolumeResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "")
+	volumeId := req.GetVolumeId()
+	if volumeId == "" {
+		return nil, status.Error(codes.InvalidArgument, "no volume ID provided")
+	}
+	log.AddContext(ctx).Infof("Start to controller get volume %s", volumeId)
+
+	backendName, volName := utils.SplitVolumeId(volumeId)
+	backend := backend.GetBackendWithFresh(ctx, backendName, true)
+	if backend == nil {
+		msg := fmt.Sprintf("Backend %s doesn't exist", backendName)
+		log.AddContext(ctx).Errorln(msg)
+		return nil, status.Error(codes.NotFound, msg)
+	}
+
+	condition, err := backend.Plugin.QueryVolumeCondition(ctx, volName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Query condition of volume %s error: %v", volumeId, err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if condition == nil {
+		msg := fmt.Sprintf("Volume %s doesn't exist", volumeId)
+		log.AddContext(ctx).Errorln(msg)
+		return nil, status.Error(codes.NotFound, msg)
+	}
+
+	vol, err := backend.Plugin.QueryVolume(ctx, volName, map[string]interface{}{})
+	if err != nil {
+		log.AddContext(ctx).Errorf("Query volume %s error: %v", volumeId, err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// the capacity is optional in the response, so ignore the error of the empty size
+	capacity, _ := vol.GetSize()
+	volumeStatus, err := makeVolumeStatus(ctx, condition)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	log.AddContext(ctx).Infof("Finish to controller get volume %s, status: %v", volumeId, volumeStatus)
+	return &csi.ControllerGetVolumeResponse{
+		Volume: &csi.Volume{
+			VolumeId:      volumeId,
+			CapacityBytes: capacity,
+		},
+		Status: volumeStatus,
+	}, nil
+}
+
+// ControllerModifyVolume used to change the QoS of the volume online by the "qos" parameter of the
+// VolumeAttributesClass, which is in the same format as the "qos" parameter of the StorageClass
+func (d *Driver) ControllerModifyVolume(ctx context.Context, req *csi.ControllerModifyVolumeRequest) (
+	*csi.ControllerModifyVolumeResponse, error) {
+	volumeId := req.GetVolumeId()
+	if volumeId == "" {
+		return nil, status.Error(codes.InvalidArgument, "no volume ID provided")
+	}
+
+	log.AddContext(ctx).Infof("Start to controller modify volume %s", volumeId)
+	for key := range req.GetMutableParameters() {
+		if key != "qos" {
+			return nil, status.Errorf(codes.InvalidArgument, "mutable parameter %s is not supported", key)
+		}
+	}
+	qos, exist := req.GetMutableParameters()["qos"]
+	if !exist {
+		return &csi.ControllerModifyVolumeResponse{}, nil
+	}
+
+	backendName, volName := utils.SplitVolumeId(volumeId)
+	backend := backend.GetBackendWithFresh(ctx, backendName, true)
+	if backend == nil {
+		msg := fmt.Sprintf("Backend %s doesn't exist", backendName)
+		log.AddContext(ctx).Errorln(msg)
+		return nil, status.Error(codes.Internal, msg)
+	}
+
+	modifier, ok := backend.Plugin.(plugin.QoSModifier)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "the storage %s of backend %s does not support modifying qos",
+			backend.Storage, backendName)
+	}
+
+	if err := modifier.ModifyVolumeQoS(ctx, volName, qos); err != nil {
+		log.AddContext(ctx).Errorf("Modify qos of volume %s to %s error: %v", volumeId, qos, err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	log.AddContext(ctx).Infof("Finish to controller modify volume %s", volumeId)
+	return &csi.ControllerModifyVolumeResponse{}, nil
 }
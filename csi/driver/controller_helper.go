@@ -18,18 +18,23 @@ package driver
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"huawei-csi-driver/cli/helper"
+	"huawei-csi-driver/csi/app"
 	"huawei-csi-driver/csi/backend"
+	"huawei-csi-driver/csi/backend/plugin"
 	"huawei-csi-driver/pkg/constants"
 	"huawei-csi-driver/utils"
 	"huawei-csi-driver/utils/log"
@@ -132,6 +137,23 @@ func validateModeAndType(req *csi.CreateVolumeRequest, parameters map[string]int
 		return "Volume Capabilities missing in request"
 	}
 
+	if msg := validateCapabilitiesAndType(volumeCapabilities, parameters["volumeType"]); msg != "" {
+		return msg
+	}
+
+	fsType := utils.ToStringSafe(parameters["fsType"])
+	if fsType != "" && !utils.IsContain(constants.FileType(fsType), []constants.FileType{constants.Ext2,
+		constants.Ext3, constants.Ext4, constants.Xfs}) {
+		return fmt.Sprintf("fsType %v is not correct, [%v, %v, %v, %v] are support."+
+			" Please check the storage class ", fsType, constants.Ext2, constants.Ext3, constants.Ext4, constants.Xfs)
+	}
+
+	return ""
+}
+
+// validateCapabilitiesAndType used to check whether the access mode and the volume mode are supported by the
+// volume type, return the reason if not supported
+func validateCapabilitiesAndType(volumeCapabilities []*csi.VolumeCapability, volumeType interface{}) string {
 	var volumeMode string
 	var accessMode string
 	for _, mode := range volumeCapabilities {
@@ -145,27 +167,31 @@ func validateModeAndType(req *csi.CreateVolumeRequest, parameters map[string]int
 		}
 	}
 
-	if volumeMode == Block &&
-		(parameters["volumeType"] == volumeTypeFileSystem || parameters["volumeType"] == volumeTypeDTree) {
+	if volumeMode == Block && (volumeType == volumeTypeFileSystem || volumeType == volumeTypeDTree) {
 		return fmt.Sprintf("VolumeMode is block but volumeType is %s. Please check the storage class",
-			parameters["volumeType"])
+			volumeType)
 	}
 
-	if accessMode == RWX && volumeMode == FileSystem && parameters["volumeType"] == volumeTypeLun {
+	if accessMode == RWX && volumeMode == FileSystem && volumeType == volumeTypeLun {
 		return "If volumeType in the sc.yaml file is set to \"lun\" and volumeMode in the pvc.yaml file is " +
 			"set to \"Filesystem\", accessModes in the pvc.yaml file cannot be set to \"ReadWriteMany\"."
 	}
 
-	fsType := utils.ToStringSafe(parameters["fsType"])
-	if fsType != "" && !utils.IsContain(constants.FileType(fsType), []constants.FileType{constants.Ext2,
-		constants.Ext3, constants.Ext4, constants.Xfs}) {
-		return fmt.Sprintf("fsType %v is not correct, [%v, %v, %v, %v] are support."+
-			" Please check the storage class ", fsType, constants.Ext2, constants.Ext3, constants.Ext4, constants.Xfs)
-	}
-
 	return ""
 }
 
+// getVolumeTypeOfStorage used to get the volume type provided by the storage type of the backend
+func getVolumeTypeOfStorage(storage string) string {
+	switch storage {
+	case "oceanstor-san", "fusionstorage-san":
+		return volumeTypeLun
+	case plugin.DTreeStorage:
+		return volumeTypeDTree
+	default:
+		return volumeTypeFileSystem
+	}
+}
+
 func processAccessibilityRequirements(ctx context.Context, req *csi.CreateVolumeRequest,
 	parameters map[string]interface{}) {
 
@@ -522,3 +548,194 @@ func validateCapacity(ctx context.Context, req *csi.CreateVolumeRequest, vol uti
 	}
 	return nil
 }
+
+func getPoolNames(b *backend.Backend) []string {
+	var pools []string
+	for _, pool := range b.Pools {
+		pools = append(pools, pool.Name)
+	}
+	return pools
+}
+
+// listVolumeEntries used to list the volumes of all available backends, the entries are sorted by volume ID
+func listVolumeEntries(ctx context.Context) ([]*csi.ListVolumesResponse_Entry, error) {
+	var entries []*csi.ListVolumesResponse_Entry
+	for _, b := range backend.GetAvailableBackends() {
+		volumes, err := b.Plugin.ListVolumes(ctx, app.GetGlobalConfig().VolumeNamePrefix, getPoolNames(b))
+		if err != nil {
+			return nil, utils.Errorf(ctx, "list volumes of backend %s error: %v", b.Name, err)
+		}
+
+		for _, vol := range volumes {
+			capacity, err := vol.GetSize()
+			if err != nil {
+				log.AddContext(ctx).Warningf("Get size of volume %s failed, error: %v", vol.GetVolumeName(), err)
+			}
+			entries = append(entries, &csi.ListVolumesResponse_Entry{
+				Volume: &csi.Volume{
+					VolumeId:      b.Name + "." + vol.GetVolumeName(),
+					CapacityBytes: capacity,
+				},
+			})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].GetVolume().GetVolumeId() < entries[j].GetVolume().GetVolumeId()
+	})
+	return entries, nil
+}
+
+// listSnapshotEntries used to list the snapshots filtered by the snapshot ID or the source volume ID in the
+// request, all snapshots of the volumes in the available backends are listed if neither is specified.
+func listSnapshotEntries(ctx context.Context, req *csi.ListSnapshotsRequest) (
+	[]*csi.ListSnapshotsResponse_Entry, error) {
+	if req.GetSnapshotId() != "" {
+		return querySnapshotEntry(ctx, req.GetSnapshotId(), req.GetSourceVolumeId())
+	}
+
+	if req.GetSourceVolumeId() != "" {
+		backendName, volName := utils.SplitVolumeId(req.GetSourceVolumeId())
+		b := backend.GetBackendWithFresh(ctx, backendName, true)
+		if b == nil {
+			log.AddContext(ctx).Infof("Backend %s of volume %s doesn't exist", backendName, req.GetSourceVolumeId())
+			return nil, nil
+		}
+		return listSnapshotEntriesOfVolume(ctx, b, volName)
+	}
+
+	var entries []*csi.ListSnapshotsResponse_Entry
+	for _, b := range backend.GetAvailableBackends() {
+		volumes, err := b.Plugin.ListVolumes(ctx, app.GetGlobalConfig().VolumeNamePrefix, getPoolNames(b))
+		if err != nil {
+			return nil, utils.Errorf(ctx, "list volumes of backend %s error: %v", b.Name, err)
+		}
+
+		for _, vol := range volumes {
+			snapshotEntries, err := listSnapshotEntriesOfVolume(ctx, b, vol.GetVolumeName())
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, snapshotEntries...)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].GetSnapshot().GetSnapshotId() < entries[j].GetSnapshot().GetSnapshotId()
+	})
+	return entries, nil
+}
+
+func querySnapshotEntry(ctx context.Context, snapshotId, sourceVolumeId string) (
+	[]*csi.ListSnapshotsResponse_Entry, error) {
+	backendName, snapshotParentId, snapshotName := utils.SplitSnapshotId(snapshotId)
+	b := backend.GetBackendWithFresh(ctx, backendName, true)
+	if b == nil {
+		log.AddContext(ctx).Infof("Backend %s of snapshot %s doesn't exist", backendName, snapshotId)
+		return nil, nil
+	}
+
+	snapshot, err := b.Plugin.QuerySnapshot(ctx, snapshotParentId, snapshotName)
+	if err != nil {
+		return nil, utils.Errorf(ctx, "query snapshot %s error: %v", snapshotId, err)
+	}
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	entry := makeSnapshotEntry(backendName, snapshot)
+	if sourceVolumeId != "" && sourceVolumeId != entry.GetSnapshot().GetSourceVolumeId() {
+		return nil, nil
+	}
+
+	entry.Snapshot.SnapshotId = snapshotId
+	return []*csi.ListSnapshotsResponse_Entry{entry}, nil
+}
+
+func listSnapshotEntriesOfVolume(ctx context.Context, b *backend.Backend, volName string) (
+	[]*csi.ListSnapshotsResponse_Entry, error) {
+	snapshots, err := b.Plugin.ListSnapshots(ctx, volName)
+	if err != nil {
+		return nil, utils.Errorf(ctx, "list snapshots of volume %s.%s error: %v", b.Name, volName, err)
+	}
+
+	var entries []*csi.ListSnapshotsResponse_Entry
+	for _, snapshot := range snapshots {
+		entries = append(entries, makeSnapshotEntry(b.Name, snapshot))
+	}
+	return entries, nil
+}
+
+func makeSnapshotEntry(backendName string, snapshot map[string]interface{}) *csi.ListSnapshotsResponse_Entry {
+	name, _ := snapshot["Name"].(string)
+	parentID, _ := snapshot["ParentID"].(string)
+	parentName, _ := snapshot["ParentName"].(string)
+	sizeBytes, _ := snapshot["SizeBytes"].(int64)
+	creationTime, _ := snapshot["CreationTime"].(int64)
+	return &csi.ListSnapshotsResponse_Entry{
+		Snapshot: &csi.Snapshot{
+			SizeBytes:      sizeBytes,
+			SnapshotId:     backendName + "." + parentID + "." + name,
+			SourceVolumeId: backendName + "." + parentName,
+			CreationTime:   &timestamp.Timestamp{Seconds: creationTime},
+			ReadyToUse:     true,
+		},
+	}
+}
+
+// makeVolumeStatus used to convert the volume condition queried from the backend to the csi volume status,
+// the published node ids are in the same format as the node id reported by NodeGetInfo
+func makeVolumeStatus(ctx context.Context, condition map[string]interface{}) (
+	*csi.ControllerGetVolumeResponse_VolumeStatus, error) {
+	hostNames, _ := condition["PublishedNodes"].([]string)
+	var publishedNodeIds []string
+	for _, hostName := range hostNames {
+		nodeBytes, err := json.Marshal(map[string]interface{}{"HostName": hostName})
+		if err != nil {
+			return nil, utils.Errorf(ctx, "marshal node info of %s error: %v", hostName, err)
+		}
+		publishedNodeIds = append(publishedNodeIds, string(nodeBytes))
+	}
+
+	abnormal, _ := condition["Abnormal"].(bool)
+	message, _ := condition["Message"].(string)
+	if !abnormal {
+		message = "volume is normal"
+	}
+
+	return &csi.ControllerGetVolumeResponse_VolumeStatus{
+		PublishedNodeIds: publishedNodeIds,
+		VolumeCondition: &csi.VolumeCondition{
+			Abnormal: abnormal,
+			Message:  message,
+		},
+	}, nil
+}
+
+// paginateEntries used to get one page of the entries sorted by key, the starting token is the key of the last
+// entry in the previous page, so the page is stable even if entries are created in between. The starting token
+// is unknown if its entry no longer exists, and false is returned then so that the caller restarts the listing.
+func paginateEntries[T any](entries []T, key func(T) string, startingToken string, maxEntries int32) (
+	[]T, string, bool) {
+	start := 0
+	if startingToken != "" {
+		index := sort.Search(len(entries), func(i int) bool {
+			return key(entries[i]) >= startingToken
+		})
+		if index == len(entries) || key(entries[index]) != startingToken {
+			return nil, "", false
+		}
+		start = index + 1
+	}
+
+	end := len(entries)
+	if maxEntries > 0 && start+int(maxEntries) < end {
+		end = start + int(maxEntries)
+	}
+
+	var nextToken string
+	if end < len(entries) {
+		nextToken = key(entries[end-1])
+	}
+	return entries[start:end], nextToken, true
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/container-storage-interface/spec/lib/go/csi"
 	"github.com/prashantv/gostub"
 	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	xuanwuv1 "huawei-csi-driver/client/apis/xuanwu/v1"
 	"huawei-csi-driver/csi/app"
@@ -189,3 +191,307 @@ func TestImportVolume(t *testing.T) {
 		t.Errorf("test import with storage failed, error %v", err)
 	}
 }
+
+func TestListVolumesWithPagination(t *testing.T) {
+	plg := plugin.GetPlugin("oceanstor-nas")
+	s := gostub.StubFunc(&backend.GetAvailableBackends, []*backend.Backend{{
+		Name:   "fake-backend",
+		Plugin: plg,
+		Pools:  []*backend.StoragePool{initPool("local-pool")},
+	}})
+	defer s.Reset()
+
+	listPatch := gomonkey.ApplyMethod(reflect.TypeOf(plg), "ListVolumes",
+		func(*plugin.OceanstorNasPlugin, context.Context, string, []string) ([]utils.Volume, error) {
+			var volumes []utils.Volume
+			for _, name := range []string{"pvc_c", "pvc_a", "pvc_b"} {
+				vol := utils.NewVolume(name)
+				vol.SetSize(1024 * 1024 * 1024)
+				volumes = append(volumes, vol)
+			}
+			return volumes, nil
+		})
+	defer listPatch.Reset()
+
+	driver := initDriver()
+	Convey("List volumes page by page", t, func() {
+		resp, err := driver.ListVolumes(context.TODO(), &csi.ListVolumesRequest{MaxEntries: 2})
+		So(err, ShouldBeNil)
+		So(len(resp.GetEntries()), ShouldEqual, 2)
+		So(resp.GetEntries()[0].GetVolume().GetVolumeId(), ShouldEqual, "fake-backend.pvc_a")
+		So(resp.GetNextToken(), ShouldEqual, "fake-backend.pvc_b")
+
+		resp, err = driver.ListVolumes(context.TODO(), &csi.ListVolumesRequest{
+			MaxEntries:    2,
+			StartingToken: resp.GetNextToken(),
+		})
+		So(err, ShouldBeNil)
+		So(len(resp.GetEntries()), ShouldEqual, 1)
+		So(resp.GetEntries()[0].GetVolume().GetVolumeId(), ShouldEqual, "fake-backend.pvc_c")
+		So(resp.GetNextToken(), ShouldEqual, "")
+	})
+
+	Convey("List volumes with unknown starting token", t, func() {
+		_, err := driver.ListVolumes(context.TODO(), &csi.ListVolumesRequest{
+			MaxEntries:    2,
+			StartingToken: "fake-backend.pvc_d",
+		})
+		So(status.Code(err), ShouldEqual, codes.Aborted)
+	})
+
+	Convey("List volumes with negative max entries", t, func() {
+		_, err := driver.ListVolumes(context.TODO(), &csi.ListVolumesRequest{MaxEntries: -1})
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestListSnapshotsBySnapshotId(t *testing.T) {
+	plg := plugin.GetPlugin("oceanstor-san")
+	s := gostub.StubFunc(&backend.GetBackendWithFresh, &backend.Backend{Name: "fake-backend", Plugin: plg})
+	defer s.Reset()
+
+	queryPatch := gomonkey.ApplyMethod(reflect.TypeOf(plg), "QuerySnapshot",
+		func(_ *plugin.OceanstorSanPlugin, _ context.Context, parentID, snapshotName string) (
+			map[string]interface{}, error) {
+			if snapshotName != "snapshot-1" {
+				return nil, nil
+			}
+			return map[string]interface{}{
+				"Name":         snapshotName,
+				"ParentID":     parentID,
+				"ParentName":   "pvc-1",
+				"SizeBytes":    int64(1024),
+				"CreationTime": int64(1),
+			}, nil
+		})
+	defer queryPatch.Reset()
+
+	driver := initDriver()
+	Convey("List the existing snapshot", t, func() {
+		resp, err := driver.ListSnapshots(context.TODO(), &csi.ListSnapshotsRequest{
+			SnapshotId: "fake-backend.1.snapshot-1",
+		})
+		So(err, ShouldBeNil)
+		So(len(resp.GetEntries()), ShouldEqual, 1)
+		So(resp.GetEntries()[0].GetSnapshot().GetSourceVolumeId(), ShouldEqual, "fake-backend.pvc-1")
+	})
+
+	Convey("List the snapshot with mismatched source volume", t, func() {
+		resp, err := driver.ListSnapshots(context.TODO(), &csi.ListSnapshotsRequest{
+			SnapshotId:     "fake-backend.1.snapshot-1",
+			SourceVolumeId: "fake-backend.pvc-2",
+		})
+		So(err, ShouldBeNil)
+		So(len(resp.GetEntries()), ShouldEqual, 0)
+	})
+
+	Convey("List the nonexistent snapshot", t, func() {
+		resp, err := driver.ListSnapshots(context.TODO(), &csi.ListSnapshotsRequest{
+			SnapshotId: "fake-backend.1.snapshot-2",
+		})
+		So(err, ShouldBeNil)
+		So(len(resp.GetEntries()), ShouldEqual, 0)
+	})
+
+	Convey("List the snapshot with unknown starting token", t, func() {
+		_, err := driver.ListSnapshots(context.TODO(), &csi.ListSnapshotsRequest{
+			SnapshotId:    "fake-backend.1.snapshot-1",
+			StartingToken: "fake-backend.1.snapshot-2",
+		})
+		So(status.Code(err), ShouldEqual, codes.Aborted)
+	})
+}
+
+func TestControllerGetVolume(t *testing.T) {
+	plg := plugin.GetPlugin("oceanstor-san")
+	s := gostub.StubFunc(&backend.GetBackendWithFresh, &backend.Backend{Name: "fake-backend", Plugin: plg})
+	defer s.Reset()
+
+	conditionPatch := gomonkey.ApplyMethod(reflect.TypeOf(plg), "QueryVolumeCondition",
+		func(_ *plugin.OceanstorSanPlugin, _ context.Context, name string) (map[string]interface{}, error) {
+			if name != "pvc-1" {
+				return nil, nil
+			}
+			return map[string]interface{}{
+				"PublishedNodes": []string{"node-1"},
+				"Abnormal":       true,
+				"Message":        "lun pvc-1 is abnormal",
+			}, nil
+		})
+	defer conditionPatch.Reset()
+
+	queryPatch := gomonkey.ApplyMethod(reflect.TypeOf(plg), "QueryVolume",
+		func(_ *plugin.OceanstorSanPlugin, _ context.Context, name string, _ map[string]interface{}) (
+			utils.Volume, error) {
+			vol := utils.NewVolume(name)
+			vol.SetSize(1024)
+			return vol, nil
+		})
+	defer queryPatch.Reset()
+
+	driver := initDriver()
+	Convey("Get the abnormal volume", t, func() {
+		resp, err := driver.ControllerGetVolume(context.TODO(), &csi.ControllerGetVolumeRequest{
+			VolumeId: "fake-backend.pvc-1",
+		})
+		So(err, ShouldBeNil)
+		So(resp.GetVolume().GetCapacityBytes(), ShouldEqual, 1024)
+		So(resp.GetStatus().GetPublishedNodeIds(), ShouldResemble, []string{`{"HostName":"node-1"}`})
+		So(resp.GetStatus().GetVolumeCondition().GetAbnormal(), ShouldBeTrue)
+	})
+
+	Convey("Get the nonexistent volume", t, func() {
+		_, err := driver.ControllerGetVolume(context.TODO(), &csi.ControllerGetVolumeRequest{
+			VolumeId: "fake-backend.pvc-2",
+		})
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestValidateVolumeCapabilities(t *testing.T) {
+	plg := plugin.GetPlugin("oceanstor-san")
+	s := gostub.StubFunc(&backend.GetBackendWithFresh,
+		&backend.Backend{Name: "fake-backend", Storage: "oceanstor-san", Plugin: plg})
+	defer s.Reset()
+
+	conditionPatch := gomonkey.ApplyMethod(reflect.TypeOf(plg), "QueryVolumeCondition",
+		func(_ *plugin.OceanstorSanPlugin, _ context.Context, name string) (map[string]interface{}, error) {
+			switch name {
+			case "pvc-1":
+				return map[string]interface{}{"Abnormal": false}, nil
+			case "pvc-3":
+				return nil, errors.New("connection refused")
+			default:
+				return nil, nil
+			}
+		})
+	defer conditionPatch.Reset()
+
+	blockCapability := &csi.VolumeCapability{
+		AccessType: &csi.VolumeCapability_Block{Block: &csi.VolumeCapability_BlockVolume{}},
+		AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER},
+	}
+	rwxFsCapability := &csi.VolumeCapability{
+		AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{}},
+		AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER},
+	}
+
+	driver := initDriver()
+	Convey("Validate the RWX block volume on the san backend", t, func() {
+		resp, err := driver.ValidateVolumeCapabilities(context.TODO(), &csi.ValidateVolumeCapabilitiesRequest{
+			VolumeId:           "fake-backend.pvc-1",
+			VolumeCapabilities: []*csi.VolumeCapability{blockCapability},
+		})
+		So(err, ShouldBeNil)
+		So(resp.GetConfirmed(), ShouldNotBeNil)
+	})
+
+	Convey("Validate the RWX filesystem volume on the san backend", t, func() {
+		resp, err := driver.ValidateVolumeCapabilities(context.TODO(), &csi.ValidateVolumeCapabilitiesRequest{
+			VolumeId:           "fake-backend.pvc-1",
+			VolumeCapabilities: []*csi.VolumeCapability{rwxFsCapability},
+		})
+		So(err, ShouldBeNil)
+		So(resp.GetConfirmed(), ShouldBeNil)
+		So(resp.GetMessage(), ShouldNotBeEmpty)
+	})
+
+	Convey("Validate the nonexistent volume", t, func() {
+		_, err := driver.ValidateVolumeCapabilities(context.TODO(), &csi.ValidateVolumeCapabilitiesRequest{
+			VolumeId:           "fake-backend.pvc-2",
+			VolumeCapabilities: []*csi.VolumeCapability{blockCapability},
+		})
+		So(status.Code(err), ShouldEqual, codes.NotFound)
+	})
+
+	Convey("Validate the volume when the storage is unreachable", t, func() {
+		_, err := driver.ValidateVolumeCapabilities(context.TODO(), &csi.ValidateVolumeCapabilitiesRequest{
+			VolumeId:           "fake-backend.pvc-3",
+			VolumeCapabilities: []*csi.VolumeCapability{blockCapability},
+		})
+		So(status.Code(err), ShouldEqual, codes.Internal)
+	})
+}
+
+func TestCreateVolumeGroupSnapshot(t *testing.T) {
+	plg := plugin.GetPlugin("oceanstor-san")
+	s := gostub.StubFunc(&backend.GetBackendWithFresh, &backend.Backend{Name: "fake-backend", Plugin: plg})
+	defer s.Reset()
+
+	var groupVolumes, groupSnapshotNames []string
+	createPatch := gomonkey.ApplyMethod(reflect.TypeOf(plg), "CreateGroupSnapshot",
+		func(_ *plugin.OceanstorSanPlugin, _ context.Context, names, snapshotNames []string) (
+			[]map[string]interface{}, error) {
+			groupVolumes, groupSnapshotNames = names, snapshotNames
+			var snapshots []map[string]interface{}
+			for i := range names {
+				snapshots = append(snapshots, map[string]interface{}{
+					"ParentID":     string(rune('1' + i)),
+					"SizeBytes":    int64(1024),
+					"CreationTime": int64(10 - i),
+				})
+			}
+			return snapshots, nil
+		})
+	defer createPatch.Reset()
+
+	driver := initDriver()
+	Convey("Create the group snapshot of the volumes in the same backend", t, func() {
+		resp, err := driver.CreateVolumeGroupSnapshot(context.TODO(), &csi.CreateVolumeGroupSnapshotRequest{
+			Name:            "groupsnapshot-1",
+			SourceVolumeIds: []string{"fake-backend.pvc-1", "fake-backend.pvc-2"},
+		})
+		So(err, ShouldBeNil)
+		So(groupVolumes, ShouldResemble, []string{"pvc-1", "pvc-2"})
+		So(groupSnapshotNames[0], ShouldNotEqual, groupSnapshotNames[1])
+		So(len(groupSnapshotNames[0]), ShouldBeLessThanOrEqualTo, 31)
+
+		group := resp.GetGroupSnapshot()
+		So(group.GetGroupSnapshotId(), ShouldEqual, "fake-backend.groupsnapshot-1")
+		So(group.GetCreationTime().GetSeconds(), ShouldEqual, 9)
+		So(group.GetSnapshots(), ShouldHaveLength, 2)
+		So(group.GetSnapshots()[1].GetSnapshotId(), ShouldEqual, "fake-backend.2."+groupSnapshotNames[1])
+		So(group.GetSnapshots()[1].GetGroupSnapshotId(), ShouldEqual, group.GetGroupSnapshotId())
+	})
+
+	Convey("Create the group snapshot of the volumes in different backends", t, func() {
+		_, err := driver.CreateVolumeGroupSnapshot(context.TODO(), &csi.CreateVolumeGroupSnapshotRequest{
+			Name:            "groupsnapshot-1",
+			SourceVolumeIds: []string{"fake-backend.pvc-1", "another-backend.pvc-2"},
+		})
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestControllerModifyVolume(t *testing.T) {
+	plg := plugin.GetPlugin("oceanstor-san")
+	s := gostub.StubFunc(&backend.GetBackendWithFresh, &backend.Backend{Name: "fake-backend", Plugin: plg})
+	defer s.Reset()
+
+	var volName, qos string
+	modifyPatch := gomonkey.ApplyMethod(reflect.TypeOf(plg), "ModifyVolumeQoS",
+		func(_ *plugin.OceanstorSanPlugin, _ context.Context, name, qosConfig string) error {
+			volName, qos = name, qosConfig
+			return nil
+		})
+	defer modifyPatch.Reset()
+
+	driver := initDriver()
+	Convey("Modify the qos of the volume", t, func() {
+		_, err := driver.ControllerModifyVolume(context.TODO(), &csi.ControllerModifyVolumeRequest{
+			VolumeId:          "fake-backend.pvc-1",
+			MutableParameters: map[string]string{"qos": `{"MAXIOPS":1000}`},
+		})
+		So(err, ShouldBeNil)
+		So(volName, ShouldEqual, "pvc-1")
+		So(qos, ShouldEqual, `{"MAXIOPS":1000}`)
+	})
+
+	Convey("Modify the unsupported parameter of the volume", t, func() {
+		_, err := driver.ControllerModifyVolume(context.TODO(), &csi.ControllerModifyVolumeRequest{
+			VolumeId:          "fake-backend.pvc-1",
+			MutableParameters: map[string]string{"allocType": "thick"},
+		})
+		So(err, ShouldNotBeNil)
+	})
+}
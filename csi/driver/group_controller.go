@@ -0,0 +1,210 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package driver
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"huawei-csi-driver/cli/helper"
+	"huawei-csi-driver/csi/backend"
+	"huawei-csi-driver/csi/backend/plugin"
+	"huawei-csi-driver/utils"
+	"huawei-csi-driver/utils/log"
+)
+
+// GroupControllerGetCapabilities used to report the group snapshot capability
+func (d *Driver) GroupControllerGetCapabilities(ctx context.Context,
+	req *csi.GroupControllerGetCapabilitiesRequest) (*csi.GroupControllerGetCapabilitiesResponse, error) {
+	return &csi.GroupControllerGetCapabilitiesResponse{
+		Capabilities: []*csi.GroupControllerServiceCapability{
+			{
+				Type: &csi.GroupControllerServiceCapability_Rpc{
+					Rpc: &csi.GroupControllerServiceCapability_RPC{
+						Type: csi.GroupControllerServiceCapability_RPC_CREATE_DELETE_GET_VOLUME_GROUP_SNAPSHOT,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// CreateVolumeGroupSnapshot used to create the crash-consistent snapshots of the volumes, the volumes must be
+// in the same backend. The group snapshot id is <backend>.<group name>, and the snapshots in the group are
+// the same as the ones created by CreateSnapshot.
+func (d *Driver) CreateVolumeGroupSnapshot(ctx context.Context, req *csi.CreateVolumeGroupSnapshotRequest) (
+	*csi.CreateVolumeGroupSnapshotResponse, error) {
+	groupName := req.GetName()
+	if groupName == "" {
+		return nil, status.Error(codes.InvalidArgument, "Group snapshot name missing in request")
+	}
+
+	volumeIds := req.GetSourceVolumeIds()
+	if len(volumeIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Source volume IDs missing in request")
+	}
+	log.AddContext(ctx).Infof("Start to create group snapshot %s for volumes %v", groupName, volumeIds)
+
+	backendName, _ := utils.SplitVolumeId(volumeIds[0])
+	volNames := make([]string, 0, len(volumeIds))
+	snapshotNames := make([]string, 0, len(volumeIds))
+	for _, volumeId := range volumeIds {
+		volBackendName, volName := utils.SplitVolumeId(volumeId)
+		if volBackendName != backendName {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"volumes of group snapshot %s must be in the same backend", groupName)
+		}
+		volNames = append(volNames, volName)
+		snapshotNames = append(snapshotNames, makeGroupMemberSnapshotName(groupName, volumeId))
+	}
+
+	b := backend.GetBackendWithFresh(ctx, backendName, true)
+	if b == nil {
+		msg := fmt.Sprintf("Backend %s doesn't exist", backendName)
+		log.AddContext(ctx).Errorln(msg)
+		return nil, status.Error(codes.Internal, msg)
+	}
+
+	snapshotter, ok := b.Plugin.(plugin.GroupSnapshotter)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "the storage %s of backend %s does not support "+
+			"group snapshots", b.Storage, backendName)
+	}
+
+	snapshots, err := snapshotter.CreateGroupSnapshot(ctx, volNames, snapshotNames)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Create group snapshot %s error: %v", groupName, err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	groupSnapshotId := backendName + "." + groupName
+	groupSnapshot := &csi.VolumeGroupSnapshot{GroupSnapshotId: groupSnapshotId, ReadyToUse: true}
+	for i, snapshot := range snapshots {
+		creationTime := &timestamp.Timestamp{Seconds: snapshot["CreationTime"].(int64)}
+		groupSnapshot.Snapshots = append(groupSnapshot.Snapshots, &csi.Snapshot{
+			SizeBytes:       snapshot["SizeBytes"].(int64),
+			SnapshotId:      backendName + "." + snapshot["ParentID"].(string) + "." + snapshotNames[i],
+			SourceVolumeId:  volumeIds[i],
+			CreationTime:    creationTime,
+			ReadyToUse:      true,
+			GroupSnapshotId: groupSnapshotId,
+		})
+		if groupSnapshot.CreationTime == nil || creationTime.Seconds < groupSnapshot.CreationTime.Seconds {
+			groupSnapshot.CreationTime = creationTime
+		}
+	}
+
+	log.AddContext(ctx).Infof("Finish to create group snapshot %s for volumes %v", groupName, volumeIds)
+	return &csi.CreateVolumeGroupSnapshotResponse{GroupSnapshot: groupSnapshot}, nil
+}
+
+// DeleteVolumeGroupSnapshot used to delete the snapshots in the group one by one
+func (d *Driver) DeleteVolumeGroupSnapshot(ctx context.Context, req *csi.DeleteVolumeGroupSnapshotRequest) (
+	*csi.DeleteVolumeGroupSnapshotResponse, error) {
+	groupSnapshotId := req.GetGroupSnapshotId()
+	if groupSnapshotId == "" {
+		return nil, status.Error(codes.InvalidArgument, "Group snapshot ID missing in request")
+	}
+	log.AddContext(ctx).Infof("Start to delete group snapshot %s, snapshots: %v", groupSnapshotId,
+		req.GetSnapshotIds())
+
+	backendName, _ := utils.SplitVolumeId(groupSnapshotId)
+	if err := checkGroupMemberSnapshotIds(backendName, req.GetSnapshotIds()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	b := backend.GetBackendWithFresh(ctx, backendName, true)
+	if b == nil {
+		log.AddContext(ctx).Warningf("Backend %s doesn't exist. Ignore this request and return success. "+
+			"CAUTION: snapshots need to manually delete from array.", backendName)
+		return &csi.DeleteVolumeGroupSnapshotResponse{}, nil
+	}
+
+	for _, snapshotId := range req.GetSnapshotIds() {
+		_, snapshotParentId, snapshotName := utils.SplitSnapshotId(snapshotId)
+		if err := b.Plugin.DeleteSnapshot(ctx, snapshotParentId, snapshotName); err != nil {
+			log.AddContext(ctx).Errorf("Delete snapshot %s of group %s error: %v", snapshotId, groupSnapshotId, err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	log.AddContext(ctx).Infof("Finish to delete group snapshot %s", groupSnapshotId)
+	return &csi.DeleteVolumeGroupSnapshotResponse{}, nil
+}
+
+// GetVolumeGroupSnapshot used to get the snapshots in the group, return NotFound if any of them does not exist
+func (d *Driver) GetVolumeGroupSnapshot(ctx context.Context, req *csi.GetVolumeGroupSnapshotRequest) (
+	*csi.GetVolumeGroupSnapshotResponse, error) {
+	groupSnapshotId := req.GetGroupSnapshotId()
+	if groupSnapshotId == "" {
+		return nil, status.Error(codes.InvalidArgument, "Group snapshot ID missing in request")
+	}
+
+	backendName, _ := utils.SplitVolumeId(groupSnapshotId)
+	if err := checkGroupMemberSnapshotIds(backendName, req.GetSnapshotIds()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	groupSnapshot := &csi.VolumeGroupSnapshot{GroupSnapshotId: groupSnapshotId, ReadyToUse: true}
+	for _, snapshotId := range req.GetSnapshotIds() {
+		entries, err := querySnapshotEntry(ctx, snapshotId, "")
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if len(entries) == 0 {
+			return nil, status.Errorf(codes.NotFound, "snapshot %s of group %s doesn't exist",
+				snapshotId, groupSnapshotId)
+		}
+
+		snapshot := entries[0].GetSnapshot()
+		snapshot.GroupSnapshotId = groupSnapshotId
+		groupSnapshot.Snapshots = append(groupSnapshot.Snapshots, snapshot)
+		if groupSnapshot.CreationTime == nil || snapshot.CreationTime.Seconds < groupSnapshot.CreationTime.Seconds {
+			groupSnapshot.CreationTime = snapshot.CreationTime
+		}
+	}
+
+	return &csi.GetVolumeGroupSnapshotResponse{GroupSnapshot: groupSnapshot}, nil
+}
+
+// makeGroupMemberSnapshotName used to make the name of the snapshot of the volume in the group, which is short
+// enough for the storage and the same for the retries of the group
+func makeGroupMemberSnapshotName(groupName, volumeId string) string {
+	groupHash := sha256.Sum256([]byte(groupName))
+	volumeHash := sha256.Sum256([]byte(volumeId))
+	return fmt.Sprintf("gs%x_%x", groupHash[:4], volumeHash[:4])
+}
+
+func checkGroupMemberSnapshotIds(backendName string, snapshotIds []string) error {
+	if len(snapshotIds) == 0 {
+		return fmt.Errorf("snapshot IDs missing in request")
+	}
+
+	for _, snapshotId := range snapshotIds {
+		snapshotBackendName, _, snapshotName := utils.SplitSnapshotId(snapshotId)
+		if snapshotName == "" || helper.GetBackendName(snapshotBackendName) != backendName {
+			return fmt.Errorf("snapshot %s is not in backend %s of the group", snapshotId, backendName)
+		}
+	}
+	return nil
+}
@@ -19,9 +19,12 @@ package driver
 import (
 	"context"
 
+	"huawei-csi-driver/csi/app"
+	"huawei-csi-driver/csi/backend"
 	"huawei-csi-driver/utils/log"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/golang/protobuf/ptypes/wrappers"
 )
 
 func (d *Driver) GetPluginInfo(ctx context.Context, req *csi.GetPluginInfoRequest) (*csi.GetPluginInfoResponse, error) {
@@ -51,11 +54,23 @@ func (d *Driver) GetPluginCapabilities(ctx context.Context, req *csi.GetPluginCa
 					},
 				},
 			},
+			{
+				Type: &csi.PluginCapability_Service_{
+					Service: &csi.PluginCapability_Service{
+						Type: csi.PluginCapability_Service_GROUP_CONTROLLER_SERVICE,
+					},
+				},
+			},
 		},
 	}, nil
 }
 
+// Probe is used to probe the plugin, the controller is not ready if none of the registered backends is available
 func (d *Driver) Probe(ctx context.Context, req *csi.ProbeRequest) (*csi.ProbeResponse, error) {
 	log.AddContext(ctx).Debugf("Probe plugin %v", *d)
-	return &csi.ProbeResponse{}, nil
+	if !app.GetGlobalConfig().Controller {
+		return &csi.ProbeResponse{}, nil
+	}
+
+	return &csi.ProbeResponse{Ready: &wrappers.BoolValue{Value: backend.IsAnyBackendAvailable()}}, nil
 }
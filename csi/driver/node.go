@@ -215,6 +215,13 @@ func (d *Driver) NodeGetCapabilities(ctx context.Context, req *csi.NodeGetCapabi
 					},
 				},
 			},
+			{
+				Type: &csi.NodeServiceCapability_Rpc{
+					Rpc: &csi.NodeServiceCapability_RPC{
+						Type: csi.NodeServiceCapability_RPC_VOLUME_CONDITION,
+					},
+				},
+			},
 		},
 	}, nil
 }
@@ -299,6 +306,15 @@ func (d *Driver) NodeGetVolumeStats(ctx context.Context, req *csi.NodeGetVolumeS
 			},
 		},
 	}
+
+	abnormal, message := connector.GetVolumeCondition(ctx, volumePath)
+	if !abnormal {
+		message = "volume is normal"
+	}
+	response.VolumeCondition = &csi.VolumeCondition{
+		Abnormal: abnormal,
+		Message:  message,
+	}
 	return response, nil
 }
 
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -42,7 +43,9 @@ import (
 	"huawei-csi-driver/csi/provider"
 	"huawei-csi-driver/lib/drcsi"
 	"huawei-csi-driver/utils"
+	"huawei-csi-driver/utils/k8sutils"
 	"huawei-csi-driver/utils/log"
+	"huawei-csi-driver/utils/metrics"
 	"huawei-csi-driver/utils/notify"
 	"huawei-csi-driver/utils/version"
 )
@@ -50,12 +53,17 @@ import (
 const (
 	configFile        = "/etc/huawei/csi.json"
 	secretFile        = "/etc/huawei/secret/secret.json"
-	versionFile       = "/csi/version"
+	versionFile       = version.DefaultVersionFile
 	controllerLogFile = "huawei-csi-controller"
 	nodeLogFile       = "huawei-csi-node"
 
 	csiVersion      = "4.1.0"
 	endpointDirPerm = 0755
+
+	passwordRotationCheckInterval = time.Hour
+	leaderTasksLeaseName          = "huawei-csi-controller-tasks"
+
+	livenessPath = "/livez"
 )
 
 var (
@@ -155,6 +163,98 @@ func updateBackendCapabilities(ctx context.Context) {
 	}
 }
 
+func checkBackendsHealth() {
+	interval := app.GetGlobalConfig().BackendHealthCheckInterval
+	if interval <= 0 {
+		log.Infoln("Backend health check is disabled.")
+		return
+	}
+
+	ticker := time.NewTicker(time.Second * time.Duration(interval))
+	for range ticker.C {
+		backend.CheckBackendsHealth()
+	}
+}
+
+func watchBackendSecrets(ctx context.Context) {
+	err := app.GetGlobalConfig().K8sUtils.WatchSecrets(ctx, app.GetGlobalConfig().Namespace,
+		backend.NewSecretEventHandler())
+	if err != nil {
+		log.AddContext(ctx).Errorf("Watch backend secrets failed, error: %v", err)
+	}
+}
+
+func watchVolumeClaims(ctx context.Context) {
+	err := app.GetGlobalConfig().K8sUtils.WatchVolumeClaims(ctx, backend.NewVolumeClaimEventHandler(ctx))
+	if err != nil {
+		log.AddContext(ctx).Errorf("Watch persistent volume claims failed, error: %v", err)
+	}
+}
+
+func rotateBackendPasswords(ctx context.Context) {
+	ticker := time.NewTicker(passwordRotationCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			backend.RotateBackendPasswords(ctx)
+		}
+	}
+}
+
+func syncVolumeReplicationStatuses(ctx context.Context) {
+	interval := app.GetGlobalConfig().ReplicationSyncInterval
+	if interval <= 0 {
+		log.AddContext(ctx).Infoln("Volume replication status sync is disabled.")
+		return
+	}
+
+	ticker := time.NewTicker(time.Second * time.Duration(interval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			backend.SyncVolumeReplicationStatuses(ctx)
+		}
+	}
+}
+
+// runLeaderTasks used to run the tasks which change the storage or the kubernetes resources on their own, so only
+// one of the controller replicas runs them when the leader election is enabled
+func runLeaderTasks(ctx context.Context) {
+	run := func(ctx context.Context) {
+		// Rotate the passwords of backends whose passwordRotationDays is configured
+		go rotateBackendPasswords(ctx)
+
+		// Revert the volumes and modify the QoS of volumes of PVCs annotated with the snapshot and QoS
+		go watchVolumeClaims(ctx)
+
+		// Report the hyperMetro and replication pairs of volumes as VolumeReplicationStatus
+		go syncVolumeReplicationStatuses(ctx)
+	}
+
+	cfg := app.GetGlobalConfig()
+	if !cfg.EnableLeaderElection {
+		run(ctx)
+		return
+	}
+
+	err := cfg.K8sUtils.RunAsLeader(ctx, k8sutils.LeaseConf{
+		Namespace:     cfg.Namespace,
+		Name:          leaderTasksLeaseName,
+		LeaseDuration: cfg.LeaderLeaseDuration,
+		RenewDeadline: cfg.LeaderRenewDeadline,
+		RetryPeriod:   cfg.LeaderRetryPeriod,
+	}, run)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Run the leader tasks failed, error: %v", err)
+	}
+}
+
 func getLogFileName() string {
 	if app.GetGlobalConfig().Controller {
 		return controllerLogFile
@@ -185,6 +285,21 @@ func runCSIController(ctx context.Context) {
 	// Refresh backend and pool
 	go updateBackendCapabilities(ctx)
 
+	// Probe the management urls of backends
+	go checkBackendsHealth()
+
+	// Update the credential of backends once their secrets are changed
+	go watchBackendSecrets(ctx)
+
+	// Run the tasks which must not run in more than one replica
+	go runLeaderTasks(ctx)
+
+	// Expose the prometheus metrics if configured
+	go serveMetrics()
+
+	// Expose the liveness endpoint if configured, it is healthy even if none of the backends is available
+	go serveLiveness()
+
 	// register the kahu community DRCSI service
 	go registerDRCSIServer()
 
@@ -211,6 +326,9 @@ func runCSINode(ctx context.Context) {
 
 	triggerGarbageCollector()
 
+	// Expose the prometheus metrics if configured
+	go serveMetrics()
+
 	// Save host info to secret, such as: hostname, initiator
 	go func() {
 		if err := host.SaveNodeHostInfoToSecret(context.Background()); err != nil {
@@ -250,15 +368,44 @@ func main() {
 	}
 }
 
+func serveMetrics() {
+	if app.GetGlobalConfig().MetricsAddress == "" {
+		return
+	}
+
+	metrics.Serve(app.GetGlobalConfig().MetricsAddress, app.GetGlobalConfig().MetricsPath)
+}
+
+// serveLiveness used to serve the liveness endpoint, which only reports that the process is running. The Probe of
+// the controller is not ready while none of the backends is available, so it is not used as the liveness probe,
+// otherwise the controller is restarted repeatedly during the outage of the storage.
+func serveLiveness() {
+	address := app.GetGlobalConfig().LivenessAddress
+	if address == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(livenessPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	log.Infof("Starting liveness server, listening on %s%s", address, livenessPath)
+	if err := http.ListenAndServe(address, mux); err != nil {
+		log.Errorf("Liveness server listening on %s stopped, error: %v", address, err)
+	}
+}
+
 func registerDRCSIServer() {
 	p := provider.NewProvider(app.GetGlobalConfig().DriverName, csiVersion)
 	drListener := listenEndpoint(app.GetGlobalConfig().DrEndpoint)
 	opts := []grpc.ServerOption{
-		grpc.UnaryInterceptor(log.EnsureGRPCContext),
+		grpc.ChainUnaryInterceptor(log.EnsureGRPCContext, metrics.UnaryServerInterceptor),
 	}
 	grpcServer := grpc.NewServer(opts...)
 	drcsi.RegisterIdentityServer(grpcServer, p)
 	drcsi.RegisterStorageBackendServer(grpcServer, p)
+	drcsi.RegisterReplicationServer(grpcServer, p)
 
 	if err := grpcServer.Serve(drListener); err != nil {
 		notify.Stop("Start Huawei CSI driver error: %v", err)
@@ -301,12 +448,13 @@ func listenEndpoint(endpoint string) net.Listener {
 
 func registerServer(listener net.Listener, d *driver.Driver) {
 	opts := []grpc.ServerOption{
-		grpc.UnaryInterceptor(log.EnsureGRPCContext),
+		grpc.ChainUnaryInterceptor(log.EnsureGRPCContext, metrics.UnaryServerInterceptor),
 	}
 	server := grpc.NewServer(opts...)
 
 	csi.RegisterIdentityServer(server, d)
 	csi.RegisterControllerServer(server, d)
+	csi.RegisterGroupControllerServer(server, d)
 	csi.RegisterNodeServer(server, d)
 
 	log.Infof("Starting Huawei CSI driver, listening on %s", app.GetGlobalConfig().Endpoint)
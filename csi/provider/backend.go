@@ -27,6 +27,7 @@ import (
 	"huawei-csi-driver/lib/drcsi"
 	"huawei-csi-driver/pkg/constants"
 	pkgUtils "huawei-csi-driver/pkg/utils"
+	"huawei-csi-driver/utils"
 	"huawei-csi-driver/utils/log"
 )
 
@@ -41,7 +42,8 @@ func (p *Provider) AddStorageBackend(ctx context.Context, req *drcsi.AddStorageB
 	backendId, err := backend.RegisterOneBackend(ctx, req.Name, req.ConfigmapMeta, req.SecretMeta)
 	if err != nil {
 		msg := fmt.Sprintf("RegisterBackend %s failed, error %v", req.Name, err)
-		return nil, pkgUtils.Errorln(ctx, msg)
+		log.AddContext(ctx).Errorln(msg)
+		return nil, pkgUtils.BackendGRPCError(err, msg)
 	}
 
 	log.AddContext(ctx).Infof("Add storage backend: [%s] success.", backendId)
@@ -63,6 +65,7 @@ func (p *Provider) RemoveStorageBackend(ctx context.Context, req *drcsi.RemoveSt
 	}
 
 	backend.RemoveOneBackend(ctx, backendName)
+	utils.RemoveBackendSemaphore(req.BackendId)
 
 	return &drcsi.RemoveStorageBackendResponse{}, nil
 }
@@ -72,7 +75,7 @@ func (p *Provider) UpdateStorageBackend(ctx context.Context, req *drcsi.UpdateSt
 	*drcsi.UpdateStorageBackendResponse, error) {
 
 	// In the current version, the CSI supports only password change, which is verified through webhook.
-	// No other operation is required for the CSI driver.
+	// The registered backend logs in with the new password without re-registering.
 	log.AddContext(ctx).Infof("Start to update storage backend %s.", req.BackendId)
 	defer log.AddContext(ctx).Infof("Finish to update storage backend %s.", req.BackendId)
 
@@ -83,11 +86,6 @@ func (p *Provider) UpdateStorageBackend(ctx context.Context, req *drcsi.UpdateSt
 		return nil, errors.New(msg)
 	}
 
-	bk := backend.GetBackendWithFresh(ctx, backendName, false)
-	if bk != nil {
-		backend.RemoveOneBackend(ctx, bk.Name)
-	}
-
 	err = pkgUtils.SetStorageBackendContentOnlineStatus(ctx, req.BackendId, true)
 	if err != nil {
 		msg := fmt.Sprintf("SetStorageBackendContentOnlineStatus [%s] to online=true failed. error: %v",
@@ -96,10 +94,15 @@ func (p *Provider) UpdateStorageBackend(ctx context.Context, req *drcsi.UpdateSt
 	}
 
 	// backendId: <namespace>/<backend-name> eg:huawei-csi/nfs-180
-	_, err = backend.RegisterOneBackend(ctx, req.BackendId, req.ConfigmapMeta, req.SecretMeta)
+	if backend.IsBackendRegistered(backendName) {
+		err = backend.UpdateBackendCredential(ctx, req.BackendId, req.ConfigmapMeta, req.SecretMeta)
+	} else {
+		_, err = backend.RegisterOneBackend(ctx, req.BackendId, req.ConfigmapMeta, req.SecretMeta)
+	}
 	if err != nil {
-		msg := fmt.Sprintf("RegisterBackend %s failed, error %v", req.Name, err)
-		return nil, pkgUtils.Errorln(ctx, msg)
+		msg := fmt.Sprintf("Update backend %s failed, error %v", req.Name, err)
+		log.AddContext(ctx).Errorln(msg)
+		return nil, pkgUtils.BackendGRPCError(err, msg)
 	}
 
 	return &drcsi.UpdateStorageBackendResponse{}, nil
@@ -137,15 +140,35 @@ func (p *Provider) GetBackendStats(ctx context.Context, req *drcsi.GetBackendSta
 	if err != nil {
 		msg := fmt.Sprintf("GetBackendCapabilities backend:[%s] failed, error: [%v]", backendName, err)
 		log.AddContext(ctx).Errorln(msg)
+		return nil, pkgUtils.BackendGRPCError(err, msg)
+	}
+
+	err = backend.CheckBackendPools(ctx, backendName)
+	if err != nil {
+		msg := fmt.Sprintf("CheckBackendPools backend:[%s] failed, error: [%v]", backendName, err)
+		log.AddContext(ctx).Errorln(msg)
+		return nil, pkgUtils.BackendGRPCError(err, msg)
+	}
+
+	pools, err := backend.GetBackendPools(ctx, backendName)
+	if err != nil {
+		msg := fmt.Sprintf("GetBackendPools backend:[%s] failed, error: [%v]", backendName, err)
+		log.AddContext(ctx).Errorln(msg)
 		return nil, errors.New(msg)
 	}
 
+	var storageBackendPools []*drcsi.StorageBackendPool
+	for _, pool := range pools {
+		storageBackendPools = append(storageBackendPools, &drcsi.StorageBackendPool{Pool: pool})
+	}
+
 	return &drcsi.GetBackendStatsResponse{
-		VendorName:      constants.ProviderVendorName,
-		ProviderName:    app.GetGlobalConfig().DriverName,
-		ProviderVersion: constants.ProviderVersion,
-		Capabilities:    capabilities,
-		Specifications:  specifications,
-		Online:          true,
+		VendorName:          constants.ProviderVendorName,
+		ProviderName:        app.GetGlobalConfig().DriverName,
+		ProviderVersion:     constants.ProviderVersion,
+		StorageBackendPools: storageBackendPools,
+		Capabilities:        capabilities,
+		Specifications:      specifications,
+		Online:              true,
 	}, nil
 }
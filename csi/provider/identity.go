@@ -20,6 +20,9 @@ package provider
 import (
 	"context"
 
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"huawei-csi-driver/csi/backend"
 	"huawei-csi-driver/lib/drcsi"
 	"huawei-csi-driver/utils/log"
 )
@@ -53,8 +56,8 @@ func (p *Provider) GetProviderCapabilities(ctx context.Context, req *drcsi.GetPr
 	}, nil
 }
 
-// Probe is used to probe provider
+// Probe is used to probe provider, the provider is not ready if none of the registered backends is available
 func (p *Provider) Probe(ctx context.Context, in *drcsi.ProbeRequest) (*drcsi.ProbeResponse, error) {
 	log.AddContext(ctx).Infof("Probe invoked of %v, request: %v", *p, in)
-	return &drcsi.ProbeResponse{}, nil
+	return &drcsi.ProbeResponse{Ready: &wrappers.BoolValue{Value: backend.IsAnyBackendAvailable()}}, nil
 }
@@ -0,0 +1,109 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"huawei-csi-driver/csi/backend"
+	"huawei-csi-driver/csi/backend/plugin"
+	"huawei-csi-driver/lib/drcsi"
+	"huawei-csi-driver/utils/log"
+)
+
+// SwitchoverReplication used to make the volume the primary of its replication pairs in a planned way
+func (p *Provider) SwitchoverReplication(ctx context.Context, req *drcsi.SwitchoverReplicationRequest) (
+	*drcsi.SwitchoverReplicationResponse, error) {
+
+	log.AddContext(ctx).Infof("Start to switch over replication of volume %s.", req.VolumeId)
+	defer log.AddContext(ctx).Infof("Finished to switch over replication of volume %s.", req.VolumeId)
+
+	operator, volName, err := getReplicationOperator(req.VolumeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = operator.SwitchoverVolumeReplication(ctx, volName, toParameters(req.Parameters)); err != nil {
+		log.AddContext(ctx).Errorf("Switch over replication of volume %s failed, error: %v", req.VolumeId, err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &drcsi.SwitchoverReplicationResponse{}, nil
+}
+
+// FailoverReplication used to promote the secondary volume when the primary site is lost
+func (p *Provider) FailoverReplication(ctx context.Context, req *drcsi.FailoverReplicationRequest) (
+	*drcsi.FailoverReplicationResponse, error) {
+
+	log.AddContext(ctx).Infof("Start to fail over replication of volume %s.", req.VolumeId)
+	defer log.AddContext(ctx).Infof("Finished to fail over replication of volume %s.", req.VolumeId)
+
+	operator, volName, err := getReplicationOperator(req.VolumeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = operator.FailoverVolumeReplication(ctx, volName, toParameters(req.Parameters)); err != nil {
+		log.AddContext(ctx).Errorf("Fail over replication of volume %s failed, error: %v", req.VolumeId, err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &drcsi.FailoverReplicationResponse{}, nil
+}
+
+// ResyncReplication used to resume the replication pairs of the volume after the failed site recovers
+func (p *Provider) ResyncReplication(ctx context.Context, req *drcsi.ResyncReplicationRequest) (
+	*drcsi.ResyncReplicationResponse, error) {
+
+	log.AddContext(ctx).Infof("Start to resync replication of volume %s, reverse: %v.", req.VolumeId, req.Reverse)
+	defer log.AddContext(ctx).Infof("Finished to resync replication of volume %s.", req.VolumeId)
+
+	operator, volName, err := getReplicationOperator(req.VolumeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = operator.ResyncVolumeReplication(ctx, volName, req.Reverse); err != nil {
+		log.AddContext(ctx).Errorf("Resync replication of volume %s failed, error: %v", req.VolumeId, err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &drcsi.ResyncReplicationResponse{}, nil
+}
+
+func getReplicationOperator(volumeID string) (plugin.ReplicationOperator, string, error) {
+	if volumeID == "" {
+		return nil, "", status.Error(codes.InvalidArgument, "volume id can not be empty")
+	}
+
+	operator, volName, err := backend.GetReplicationOperator(volumeID)
+	if err != nil {
+		return nil, "", status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return operator, volName, nil
+}
+
+func toParameters(parameters map[string]string) map[string]interface{} {
+	params := make(map[string]interface{}, len(parameters))
+	for key, value := range parameters {
+		params[key] = value
+	}
+	return params
+}
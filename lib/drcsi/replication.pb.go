@@ -0,0 +1,682 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.9.1
+// source: spec/replication.proto
+
+package drcsi
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SwitchoverReplicationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the ID of the volume to be the primary, <backend>.<volume>. This field is REQUIRED.
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	// Provider specific parameters passed in as opaque key-value pairs.
+	// This field is OPTIONAL.
+	Parameters map[string]string `protobuf:"bytes,2,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *SwitchoverReplicationRequest) Reset() {
+	*x = SwitchoverReplicationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spec_replication_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwitchoverReplicationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwitchoverReplicationRequest) ProtoMessage() {}
+
+func (x *SwitchoverReplicationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_replication_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwitchoverReplicationRequest.ProtoReflect.Descriptor instead.
+func (*SwitchoverReplicationRequest) Descriptor() ([]byte, []int) {
+	return file_spec_replication_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SwitchoverReplicationRequest) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
+func (x *SwitchoverReplicationRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+type SwitchoverReplicationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SwitchoverReplicationResponse) Reset() {
+	*x = SwitchoverReplicationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spec_replication_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwitchoverReplicationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwitchoverReplicationResponse) ProtoMessage() {}
+
+func (x *SwitchoverReplicationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_replication_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwitchoverReplicationResponse.ProtoReflect.Descriptor instead.
+func (*SwitchoverReplicationResponse) Descriptor() ([]byte, []int) {
+	return file_spec_replication_proto_rawDescGZIP(), []int{1}
+}
+
+type FailoverReplicationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the ID of the secondary volume to be promoted, <backend>.<volume>. This field is REQUIRED.
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	// Provider specific parameters passed in as opaque key-value pairs.
+	// This field is OPTIONAL.
+	Parameters map[string]string `protobuf:"bytes,2,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *FailoverReplicationRequest) Reset() {
+	*x = FailoverReplicationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spec_replication_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FailoverReplicationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailoverReplicationRequest) ProtoMessage() {}
+
+func (x *FailoverReplicationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_replication_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailoverReplicationRequest.ProtoReflect.Descriptor instead.
+func (*FailoverReplicationRequest) Descriptor() ([]byte, []int) {
+	return file_spec_replication_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FailoverReplicationRequest) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
+func (x *FailoverReplicationRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+type FailoverReplicationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *FailoverReplicationResponse) Reset() {
+	*x = FailoverReplicationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spec_replication_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FailoverReplicationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailoverReplicationResponse) ProtoMessage() {}
+
+func (x *FailoverReplicationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_replication_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailoverReplicationResponse.ProtoReflect.Descriptor instead.
+func (*FailoverReplicationResponse) Descriptor() ([]byte, []int) {
+	return file_spec_replication_proto_rawDescGZIP(), []int{3}
+}
+
+type ResyncReplicationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the ID of the volume, <backend>.<volume>. This field is REQUIRED.
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	// the volume becomes the primary if reverse, otherwise it is overwritten by the primary.
+	// This field is OPTIONAL.
+	Reverse bool `protobuf:"varint,2,opt,name=reverse,proto3" json:"reverse,omitempty"`
+}
+
+func (x *ResyncReplicationRequest) Reset() {
+	*x = ResyncReplicationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spec_replication_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResyncReplicationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResyncReplicationRequest) ProtoMessage() {}
+
+func (x *ResyncReplicationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_replication_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResyncReplicationRequest.ProtoReflect.Descriptor instead.
+func (*ResyncReplicationRequest) Descriptor() ([]byte, []int) {
+	return file_spec_replication_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ResyncReplicationRequest) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
+func (x *ResyncReplicationRequest) GetReverse() bool {
+	if x != nil {
+		return x.Reverse
+	}
+	return false
+}
+
+type ResyncReplicationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ResyncReplicationResponse) Reset() {
+	*x = ResyncReplicationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spec_replication_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResyncReplicationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResyncReplicationResponse) ProtoMessage() {}
+
+func (x *ResyncReplicationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_replication_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResyncReplicationResponse.ProtoReflect.Descriptor instead.
+func (*ResyncReplicationResponse) Descriptor() ([]byte, []int) {
+	return file_spec_replication_proto_rawDescGZIP(), []int{5}
+}
+
+var File_spec_replication_proto protoreflect.FileDescriptor
+
+var file_spec_replication_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x73, 0x70, 0x65, 0x63, 0x2f, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x64, 0x72, 0x63, 0x73, 0x69, 0x2e,
+	0x76, 0x31, 0x22, 0xd2, 0x01, 0x0a, 0x1c, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x6f, 0x76, 0x65,
+	0x72, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49, 0x64,
+	0x12, 0x56, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x64, 0x72, 0x63, 0x73, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x70, 0x61,
+	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x1f, 0x0a, 0x1d, 0x53, 0x77, 0x69, 0x74, 0x63,
+	0x68, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xce, 0x01, 0x0a, 0x1a, 0x46, 0x61, 0x69,
+	0x6c, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x76, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x76, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x49, 0x64, 0x12, 0x54, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x64, 0x72, 0x63, 0x73, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50,
+	0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a,
+	0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x1d, 0x0a, 0x1b, 0x46, 0x61, 0x69,
+	0x6c, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x51, 0x0a, 0x18, 0x52, 0x65, 0x73, 0x79,
+	0x6e, 0x63, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x22, 0x1b, 0x0a, 0x19, 0x52,
+	0x65, 0x73, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xbf, 0x02, 0x0a, 0x0b, 0x52, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x6a, 0x0a, 0x15, 0x53, 0x77, 0x69, 0x74,
+	0x63, 0x68, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x26, 0x2e, 0x64, 0x72, 0x63, 0x73, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x77, 0x69,
+	0x74, 0x63, 0x68, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x64, 0x72, 0x63, 0x73,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x6f, 0x76, 0x65, 0x72, 0x52,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x64, 0x0a, 0x13, 0x46, 0x61, 0x69, 0x6c, 0x6f, 0x76, 0x65, 0x72,
+	0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x2e, 0x64, 0x72,
+	0x63, 0x73, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x6f, 0x76, 0x65, 0x72, 0x52,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x25, 0x2e, 0x64, 0x72, 0x63, 0x73, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x69,
+	0x6c, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5e, 0x0a, 0x11, 0x52, 0x65,
+	0x73, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x22, 0x2e, 0x64, 0x72, 0x63, 0x73, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x79, 0x6e,
+	0x63, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x64, 0x72, 0x63, 0x73, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x73, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x0e, 0x5a, 0x0c, 0x6c, 0x69,
+	0x62, 0x2f, 0x67, 0x6f, 0x2f, 0x64, 0x72, 0x63, 0x73, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_spec_replication_proto_rawDescOnce sync.Once
+	file_spec_replication_proto_rawDescData = file_spec_replication_proto_rawDesc
+)
+
+func file_spec_replication_proto_rawDescGZIP() []byte {
+	file_spec_replication_proto_rawDescOnce.Do(func() {
+		file_spec_replication_proto_rawDescData = protoimpl.X.CompressGZIP(file_spec_replication_proto_rawDescData)
+	})
+	return file_spec_replication_proto_rawDescData
+}
+
+var file_spec_replication_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_spec_replication_proto_goTypes = []interface{}{
+	(*SwitchoverReplicationRequest)(nil),  // 0: drcsi.v1.SwitchoverReplicationRequest
+	(*SwitchoverReplicationResponse)(nil), // 1: drcsi.v1.SwitchoverReplicationResponse
+	(*FailoverReplicationRequest)(nil),    // 2: drcsi.v1.FailoverReplicationRequest
+	(*FailoverReplicationResponse)(nil),   // 3: drcsi.v1.FailoverReplicationResponse
+	(*ResyncReplicationRequest)(nil),      // 4: drcsi.v1.ResyncReplicationRequest
+	(*ResyncReplicationResponse)(nil),     // 5: drcsi.v1.ResyncReplicationResponse
+	nil,                                   // 6: drcsi.v1.SwitchoverReplicationRequest.ParametersEntry
+	nil,                                   // 7: drcsi.v1.FailoverReplicationRequest.ParametersEntry
+}
+var file_spec_replication_proto_depIdxs = []int32{
+	6, // 0: drcsi.v1.SwitchoverReplicationRequest.parameters:type_name -> drcsi.v1.SwitchoverReplicationRequest.ParametersEntry
+	7, // 1: drcsi.v1.FailoverReplicationRequest.parameters:type_name -> drcsi.v1.FailoverReplicationRequest.ParametersEntry
+	0, // 2: drcsi.v1.Replication.SwitchoverReplication:input_type -> drcsi.v1.SwitchoverReplicationRequest
+	2, // 3: drcsi.v1.Replication.FailoverReplication:input_type -> drcsi.v1.FailoverReplicationRequest
+	4, // 4: drcsi.v1.Replication.ResyncReplication:input_type -> drcsi.v1.ResyncReplicationRequest
+	1, // 5: drcsi.v1.Replication.SwitchoverReplication:output_type -> drcsi.v1.SwitchoverReplicationResponse
+	3, // 6: drcsi.v1.Replication.FailoverReplication:output_type -> drcsi.v1.FailoverReplicationResponse
+	5, // 7: drcsi.v1.Replication.ResyncReplication:output_type -> drcsi.v1.ResyncReplicationResponse
+	5, // [5:8] is the sub-list for method output_type
+	2, // [2:5] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_spec_replication_proto_init() }
+func file_spec_replication_proto_init() {
+	if File_spec_replication_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_spec_replication_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwitchoverReplicationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spec_replication_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwitchoverReplicationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spec_replication_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FailoverReplicationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spec_replication_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FailoverReplicationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spec_replication_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResyncReplicationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spec_replication_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResyncReplicationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_spec_replication_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_spec_replication_proto_goTypes,
+		DependencyIndexes: file_spec_replication_proto_depIdxs,
+		MessageInfos:      file_spec_replication_proto_msgTypes,
+	}.Build()
+	File_spec_replication_proto = out.File
+	file_spec_replication_proto_rawDesc = nil
+	file_spec_replication_proto_goTypes = nil
+	file_spec_replication_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ReplicationClient is the client API for Replication service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ReplicationClient interface {
+	SwitchoverReplication(ctx context.Context, in *SwitchoverReplicationRequest, opts ...grpc.CallOption) (*SwitchoverReplicationResponse, error)
+	FailoverReplication(ctx context.Context, in *FailoverReplicationRequest, opts ...grpc.CallOption) (*FailoverReplicationResponse, error)
+	ResyncReplication(ctx context.Context, in *ResyncReplicationRequest, opts ...grpc.CallOption) (*ResyncReplicationResponse, error)
+}
+
+type replicationClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReplicationClient(cc grpc.ClientConnInterface) ReplicationClient {
+	return &replicationClient{cc}
+}
+
+func (c *replicationClient) SwitchoverReplication(ctx context.Context, in *SwitchoverReplicationRequest, opts ...grpc.CallOption) (*SwitchoverReplicationResponse, error) {
+	out := new(SwitchoverReplicationResponse)
+	err := c.cc.Invoke(ctx, "/drcsi.v1.Replication/SwitchoverReplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replicationClient) FailoverReplication(ctx context.Context, in *FailoverReplicationRequest, opts ...grpc.CallOption) (*FailoverReplicationResponse, error) {
+	out := new(FailoverReplicationResponse)
+	err := c.cc.Invoke(ctx, "/drcsi.v1.Replication/FailoverReplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replicationClient) ResyncReplication(ctx context.Context, in *ResyncReplicationRequest, opts ...grpc.CallOption) (*ResyncReplicationResponse, error) {
+	out := new(ResyncReplicationResponse)
+	err := c.cc.Invoke(ctx, "/drcsi.v1.Replication/ResyncReplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReplicationServer is the server API for Replication service.
+type ReplicationServer interface {
+	SwitchoverReplication(context.Context, *SwitchoverReplicationRequest) (*SwitchoverReplicationResponse, error)
+	FailoverReplication(context.Context, *FailoverReplicationRequest) (*FailoverReplicationResponse, error)
+	ResyncReplication(context.Context, *ResyncReplicationRequest) (*ResyncReplicationResponse, error)
+}
+
+// UnimplementedReplicationServer can be embedded to have forward compatible implementations.
+type UnimplementedReplicationServer struct {
+}
+
+func (*UnimplementedReplicationServer) SwitchoverReplication(context.Context, *SwitchoverReplicationRequest) (*SwitchoverReplicationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SwitchoverReplication not implemented")
+}
+func (*UnimplementedReplicationServer) FailoverReplication(context.Context, *FailoverReplicationRequest) (*FailoverReplicationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FailoverReplication not implemented")
+}
+func (*UnimplementedReplicationServer) ResyncReplication(context.Context, *ResyncReplicationRequest) (*ResyncReplicationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResyncReplication not implemented")
+}
+
+func RegisterReplicationServer(s *grpc.Server, srv ReplicationServer) {
+	s.RegisterService(&_Replication_serviceDesc, srv)
+}
+
+func _Replication_SwitchoverReplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwitchoverReplicationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplicationServer).SwitchoverReplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drcsi.v1.Replication/SwitchoverReplication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplicationServer).SwitchoverReplication(ctx, req.(*SwitchoverReplicationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Replication_FailoverReplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FailoverReplicationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplicationServer).FailoverReplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drcsi.v1.Replication/FailoverReplication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplicationServer).FailoverReplication(ctx, req.(*FailoverReplicationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Replication_ResyncReplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResyncReplicationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplicationServer).ResyncReplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drcsi.v1.Replication/ResyncReplication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplicationServer).ResyncReplication(ctx, req.(*ResyncReplicationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Replication_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "drcsi.v1.Replication",
+	HandlerType: (*ReplicationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SwitchoverReplication",
+			Handler:    _Replication_SwitchoverReplication_Handler,
+		},
+		{
+			MethodName: "FailoverReplication",
+			Handler:    _Replication_FailoverReplication_Handler,
+		},
+		{
+			MethodName: "ResyncReplication",
+			Handler:    _Replication_ResyncReplication_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spec/replication.proto",
+}
@@ -33,6 +33,14 @@ func (c *FakeXuanwuV1) StorageBackendContents() v1.StorageBackendContentInterfac
 	return &FakeStorageBackendContents{c}
 }
 
+func (c *FakeXuanwuV1) VolumeReplicationActions(namespace string) v1.VolumeReplicationActionInterface {
+	return &FakeVolumeReplicationActions{c, namespace}
+}
+
+func (c *FakeXuanwuV1) VolumeReplicationStatuses(namespace string) v1.VolumeReplicationStatusInterface {
+	return &FakeVolumeReplicationStatuses{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeXuanwuV1) RESTClient() rest.Interface {
@@ -18,3 +18,7 @@ package v1
 type StorageBackendClaimExpansion interface{}
 
 type StorageBackendContentExpansion interface{}
+
+type VolumeReplicationActionExpansion interface{}
+
+type VolumeReplicationStatusExpansion interface{}
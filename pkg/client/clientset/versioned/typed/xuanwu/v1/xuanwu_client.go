@@ -25,6 +25,8 @@ type XuanwuV1Interface interface {
 	RESTClient() rest.Interface
 	StorageBackendClaimsGetter
 	StorageBackendContentsGetter
+	VolumeReplicationActionsGetter
+	VolumeReplicationStatusesGetter
 }
 
 // XuanwuV1Client is used to interact with features provided by the xuanwu.huawei.io group.
@@ -40,6 +42,14 @@ func (c *XuanwuV1Client) StorageBackendContents() StorageBackendContentInterface
 	return newStorageBackendContents(c)
 }
 
+func (c *XuanwuV1Client) VolumeReplicationActions(namespace string) VolumeReplicationActionInterface {
+	return newVolumeReplicationActions(c, namespace)
+}
+
+func (c *XuanwuV1Client) VolumeReplicationStatuses(namespace string) VolumeReplicationStatusInterface {
+	return newVolumeReplicationStatuses(c, namespace)
+}
+
 // NewForConfig creates a new XuanwuV1Client for the given config.
 func NewForConfig(c *rest.Config) (*XuanwuV1Client, error) {
 	config := *c
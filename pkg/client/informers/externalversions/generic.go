@@ -54,6 +54,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Xuanwu().V1().StorageBackendClaims().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("storagebackendcontents"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Xuanwu().V1().StorageBackendContents().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("volumereplicationactions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Xuanwu().V1().VolumeReplicationActions().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("volumereplicationstatuses"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Xuanwu().V1().VolumeReplicationStatuses().Informer()}, nil
 
 	}
 
@@ -25,6 +25,10 @@ type Interface interface {
 	StorageBackendClaims() StorageBackendClaimInformer
 	// StorageBackendContents returns a StorageBackendContentInformer.
 	StorageBackendContents() StorageBackendContentInformer
+	// VolumeReplicationActions returns a VolumeReplicationActionInformer.
+	VolumeReplicationActions() VolumeReplicationActionInformer
+	// VolumeReplicationStatuses returns a VolumeReplicationStatusInformer.
+	VolumeReplicationStatuses() VolumeReplicationStatusInformer
 }
 
 type version struct {
@@ -47,3 +51,13 @@ func (v *version) StorageBackendClaims() StorageBackendClaimInformer {
 func (v *version) StorageBackendContents() StorageBackendContentInformer {
 	return &storageBackendContentInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// VolumeReplicationActions returns a VolumeReplicationActionInformer.
+func (v *version) VolumeReplicationActions() VolumeReplicationActionInformer {
+	return &volumeReplicationActionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// VolumeReplicationStatuses returns a VolumeReplicationStatusInformer.
+func (v *version) VolumeReplicationStatuses() VolumeReplicationStatusInformer {
+	return &volumeReplicationStatusInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
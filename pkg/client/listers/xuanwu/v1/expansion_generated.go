@@ -26,3 +26,19 @@ type StorageBackendClaimNamespaceListerExpansion interface{}
 // StorageBackendContentListerExpansion allows custom methods to be added to
 // StorageBackendContentLister.
 type StorageBackendContentListerExpansion interface{}
+
+// VolumeReplicationActionListerExpansion allows custom methods to be added to
+// VolumeReplicationActionLister.
+type VolumeReplicationActionListerExpansion interface{}
+
+// VolumeReplicationActionNamespaceListerExpansion allows custom methods to be added to
+// VolumeReplicationActionNamespaceLister.
+type VolumeReplicationActionNamespaceListerExpansion interface{}
+
+// VolumeReplicationStatusListerExpansion allows custom methods to be added to
+// VolumeReplicationStatusLister.
+type VolumeReplicationStatusListerExpansion interface{}
+
+// VolumeReplicationStatusNamespaceListerExpansion allows custom methods to be added to
+// VolumeReplicationStatusNamespaceLister.
+type VolumeReplicationStatusNamespaceListerExpansion interface{}
@@ -40,6 +40,24 @@ const (
 	Ext3 FileType = "ext3"
 	Ext4 FileType = "ext4"
 	Xfs  FileType = "xfs"
+
+	// PoolName is the key of the pool name in the pool stats of the backend
+	PoolName = "Name"
+	// PoolType is the key of the pool type in the pool stats of the backend, the value is block or file
+	PoolType = "Type"
+	// PoolTotalCapacity is the key of the total capacity in bytes in the pool stats of the backend
+	PoolTotalCapacity = "TotalCapacity"
+	// PoolFreeCapacity is the key of the free capacity in bytes in the pool stats of the backend
+	PoolFreeCapacity = "FreeCapacity"
+	// PoolSupportThin is the key of the thin provisioning support in the pool stats of the backend
+	PoolSupportThin = "SupportThin"
+	// PoolSupportThick is the key of the thick provisioning support in the pool stats of the backend
+	PoolSupportThick = "SupportThick"
+
+	// BlockPoolType means the pool provides block volumes
+	BlockPoolType = "block"
+	// FilePoolType means the pool provides file system volumes
+	FilePoolType = "file"
 )
 
 // DRCSIConfig contains storage normal configuration
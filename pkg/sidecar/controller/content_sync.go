@@ -18,14 +18,22 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strconv"
+
 	coreV1 "k8s.io/api/core/v1"
+	apiEquality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	xuanwuv1 "huawei-csi-driver/client/apis/xuanwu/v1"
 	"huawei-csi-driver/lib/drcsi"
+	"huawei-csi-driver/pkg/constants"
 	"huawei-csi-driver/pkg/utils"
 	"huawei-csi-driver/utils/log"
 )
 
+const mebibyte int64 = 1024 * 1024
+
 func (ctrl *backendController) initContentStatus(ctx context.Context, content *xuanwuv1.StorageBackendContent) (
 	*xuanwuv1.StorageBackendContent, error) {
 
@@ -63,6 +71,7 @@ func (ctrl *backendController) createContent(ctx context.Context, content *xuanw
 	if err != nil {
 		log.AddContext(ctx).Errorf("createContent for content [%s]: error occurred in createContentWrapper: %v",
 			content.Name, err)
+		ctrl.updateContentConditions(ctx, content, err)
 		return nil, err
 	}
 
@@ -101,6 +110,13 @@ func (ctrl *backendController) shouldUpdateContent(ctx context.Context, content
 	defer log.AddContext(ctx).Infof("Update content status %s", content.Status)
 
 	var needUpdate bool
+	if content.Status.ObservedGeneration != content.Generation ||
+		!meta.IsStatusConditionTrue(content.Status.Conditions, xuanwuv1.ConditionSynced) {
+		content.Status.ObservedGeneration = content.Generation
+		needUpdate = true
+	}
+	utils.SetBackendConditions(&content.Status.Conditions, content.Generation, nil)
+
 	if backendId != "" && content.Status.ContentName != backendId {
 		content.Status.ContentName = backendId
 		needUpdate = true
@@ -148,9 +164,53 @@ func (ctrl *backendController) shouldUpdateContent(ctx context.Context, content
 		content.Status.Specification = status.Specifications
 	}
 
+	if status.StorageBackendPools != nil {
+		updateContentPools(content, status.StorageBackendPools)
+	}
+
 	return true
 }
 
+// updateContentPools used to update the pools of the content status and aggregate their capacity
+func updateContentPools(content *xuanwuv1.StorageBackendContent, backendPools []*drcsi.StorageBackendPool) {
+	var totalCapacity, freeCapacity int64
+	pools := make([]xuanwuv1.StoragePool, 0, len(backendPools))
+	for _, backendPool := range backendPools {
+		pool := backendPool.GetPool()
+		poolTotalCapacity, _ := strconv.ParseInt(pool[constants.PoolTotalCapacity], 10, 64)
+		poolFreeCapacity, _ := strconv.ParseInt(pool[constants.PoolFreeCapacity], 10, 64)
+		supportThin, _ := strconv.ParseBool(pool[constants.PoolSupportThin])
+		supportThick, _ := strconv.ParseBool(pool[constants.PoolSupportThick])
+
+		totalCapacity += poolTotalCapacity
+		freeCapacity += poolFreeCapacity
+		pools = append(pools, xuanwuv1.StoragePool{
+			Name:          pool[constants.PoolName],
+			Type:          pool[constants.PoolType],
+			TotalCapacity: formatCapacity(poolTotalCapacity),
+			FreeCapacity:  formatCapacity(poolFreeCapacity),
+			SupportThin:   supportThin,
+			SupportThick:  supportThick,
+		})
+	}
+
+	content.Status.Pools = pools
+	content.Status.Capacity = map[xuanwuv1.CapacityType]string{
+		xuanwuv1.TotalCapacity: formatCapacity(totalCapacity),
+		xuanwuv1.UsedCapacity:  formatCapacity(totalCapacity - freeCapacity),
+		xuanwuv1.FreeCapacity:  formatCapacity(freeCapacity),
+	}
+}
+
+// formatCapacity used to format the capacity in bytes to a readable quantity, such as 100Gi,
+// the capacity is rounded down to MiB
+func formatCapacity(capacity int64) string {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return resource.NewQuantity(capacity/mebibyte*mebibyte, resource.BinarySI).String()
+}
+
 func (ctrl *backendController) getContentStats(ctx context.Context, content *xuanwuv1.StorageBackendContent) (
 	*xuanwuv1.StorageBackendContent, error) {
 
@@ -161,6 +221,7 @@ func (ctrl *backendController) getContentStats(ctx context.Context, content *xua
 	if err != nil {
 		log.AddContext(ctx).Errorf("getContentStats: get storage backend status for content %s, "+
 			"return error: %v", content.Name, err)
+		ctrl.updateContentConditions(ctx, content, err)
 		return nil, err
 	}
 
@@ -197,6 +258,34 @@ func (ctrl *backendController) updateContentStatusWithEvent(ctx context.Context,
 	return newContent, nil
 }
 
+// updateContentConditions used to record why the provider failed in the conditions of the content, the status is
+// updated in best effort since the error of the provider will be returned anyway
+func (ctrl *backendController) updateContentConditions(ctx context.Context,
+	content *xuanwuv1.StorageBackendContent, providerErr error) {
+	if content.Status == nil {
+		return
+	}
+
+	newContent := content.DeepCopy()
+	utils.SetBackendConditions(&newContent.Status.Conditions, newContent.Generation,
+		utils.ParseBackendError(providerErr))
+	if apiEquality.Semantic.DeepEqual(content.Status.Conditions, newContent.Status.Conditions) {
+		return
+	}
+
+	newContent, err := utils.UpdateContentStatus(ctx, ctrl.clientSet, newContent)
+	if err != nil {
+		log.AddContext(ctx).Warningf("Update conditions of content %s failed, error: %v", content.Name, err)
+		return
+	}
+
+	ctrl.eventRecorder.Event(newContent, coreV1.EventTypeWarning, "SyncContentFailed", providerErr.Error())
+	if _, err = ctrl.updateContentStore(ctx, newContent); err != nil {
+		log.AddContext(ctx).Warningf("Update conditions of content %s error: failed to update internal cache %v",
+			newContent.Name, err)
+	}
+}
+
 func (ctrl *backendController) updateContentObj(
 	ctx context.Context, content *xuanwuv1.StorageBackendContent) (
 	*xuanwuv1.StorageBackendContent, error) {
@@ -205,6 +294,7 @@ func (ctrl *backendController) updateContentObj(
 	if err != nil {
 		msg := fmt.Sprintf("Update the content %s from storage backend", content.Name)
 		log.AddContext(ctx).Errorln(msg)
+		ctrl.updateContentConditions(ctx, content, err)
 		return nil, err
 	}
 
@@ -0,0 +1,308 @@
+/*
+ Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilRuntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+
+	xuanwuv1 "huawei-csi-driver/client/apis/xuanwu/v1"
+	"huawei-csi-driver/csi/app"
+	clientSet "huawei-csi-driver/pkg/client/clientset/versioned"
+	backendInformers "huawei-csi-driver/pkg/client/informers/externalversions/xuanwu/v1"
+	storageBackend "huawei-csi-driver/pkg/storage-backend/handle"
+	"huawei-csi-driver/utils/log"
+)
+
+type replicationController struct {
+	providerName string
+
+	clientSet     clientSet.Interface
+	eventRecorder record.EventRecorder
+	timeout       time.Duration
+
+	actionQueue      workqueue.RateLimitingInterface
+	actionListerSync cache.InformerSynced
+
+	replication storageBackend.ReplicationInterfaces
+}
+
+// ReplicationControllerRequest is a request for new replication controller
+type ReplicationControllerRequest struct {
+	// provider name
+	ProviderName string
+	// storage backend client
+	ClientSet clientSet.Interface
+	// replication interfaces
+	Replication storageBackend.ReplicationInterfaces
+	// provider time out
+	TimeOut time.Duration
+	// volume replication action informer
+	ActionInformer backendInformers.VolumeReplicationActionInformer
+	// event recorder
+	EventRecorder record.EventRecorder
+}
+
+// NewReplicationController return a new *replicationController which executes the VolumeReplicationActions
+// of the volumes provisioned by the provider
+func NewReplicationController(request ReplicationControllerRequest) *replicationController {
+	rateLimiter := workqueue.NewItemExponentialFailureRateLimiter(*retryIntervalStart, *retryIntervalMax)
+	ctrl := &replicationController{
+		providerName:  request.ProviderName,
+		clientSet:     request.ClientSet,
+		eventRecorder: request.EventRecorder,
+		timeout:       request.TimeOut,
+		actionQueue:   workqueue.NewNamedRateLimitingQueue(rateLimiter, "sidecar-replication-controller-action"),
+		replication:   request.Replication,
+	}
+
+	request.ActionInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { ctrl.enqueueAction(obj) },
+			UpdateFunc: func(oldObj, newObj interface{}) { ctrl.enqueueAction(newObj) },
+		},
+	)
+	ctrl.actionListerSync = request.ActionInformer.Informer().HasSynced
+	return ctrl
+}
+
+func (ctrl *replicationController) enqueueAction(obj interface{}) {
+	action, ok := obj.(*xuanwuv1.VolumeReplicationAction)
+	if !ok || isActionFinished(action) {
+		return
+	}
+
+	objName, err := cache.MetaNamespaceKeyFunc(action)
+	if err != nil {
+		log.Errorf("failed to get key from object: %v, %v", action, err)
+		return
+	}
+	log.Infof("enqueued VolumeReplicationAction %q for sync", objName)
+	ctrl.actionQueue.Add(objName)
+}
+
+// Run defines the replication controller process
+func (ctrl *replicationController) Run(ctx context.Context, workers int, stopCh <-chan struct{}) {
+	defer ctrl.actionQueue.ShutDown()
+
+	log.AddContext(ctx).Infoln("Starting sidecar replication controller")
+	defer log.AddContext(ctx).Infoln("Shutting down sidecar replication controller")
+
+	if !cache.WaitForCacheSync(stopCh, ctrl.actionListerSync) {
+		log.AddContext(ctx).Errorln("Cannot sync caches")
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(ctrl.runActionWorker, time.Second, stopCh)
+	}
+
+	if stopCh != nil {
+		sign := <-stopCh
+		log.AddContext(ctx).Infof("Replication controller exited, reason: %v", sign)
+	}
+}
+
+func (ctrl *replicationController) runActionWorker() {
+	for ctrl.processNextActionWorkItem() {
+	}
+}
+
+func (ctrl *replicationController) processNextActionWorkItem() bool {
+	obj, shutdown := ctrl.actionQueue.Get()
+	if shutdown {
+		log.Infof("processNextActionWorkItem obj: [%v], shutdown: [%v]", obj, shutdown)
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *provisionTimeout)
+	defer cancel()
+	defer ctrl.actionQueue.Done(obj)
+
+	objKey, ok := obj.(string)
+	if !ok {
+		ctrl.actionQueue.Forget(obj)
+		utilRuntime.HandleError(fmt.Errorf("expected string in action workqueue but got %#v", obj))
+		return true
+	}
+
+	if err := ctrl.syncActionByKey(ctx, objKey); err != nil {
+		log.AddContext(ctx).Errorf("sync VolumeReplicationAction %s failed, error: %v", objKey, err)
+		ctrl.actionQueue.AddRateLimited(objKey)
+		utilRuntime.HandleError(err)
+		return true
+	}
+	ctrl.actionQueue.Forget(obj)
+	return true
+}
+
+// syncActionByKey executes the action and records the result in its status. A failed action is not retried
+// since the storage may be changed partly, but a Running one is executed again if its status is not updated,
+// the pairs already in the expected state are skipped by the provider.
+func (ctrl *replicationController) syncActionByKey(ctx context.Context, objKey string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(objKey)
+	if err != nil {
+		log.AddContext(ctx).Errorf("getting namespace & name of VolumeReplicationAction %s failed: %v", objKey, err)
+		return nil
+	}
+
+	// get the action from the api server, the cached one may be stale after the status is updated
+	action, err := ctrl.clientSet.XuanwuV1().VolumeReplicationActions(namespace).Get(ctx, name, metaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		log.AddContext(ctx).Infof("the VolumeReplicationAction %s is already deleted", objKey)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if isActionFinished(action) {
+		return nil
+	}
+
+	pv, actionErr, err := getClaimVolume(ctx, action)
+	if err != nil {
+		return err
+	}
+	if actionErr != nil {
+		return ctrl.finishAction(ctx, action, actionErr)
+	}
+	if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != ctrl.providerName {
+		// the volume belongs to another provider
+		return nil
+	}
+
+	if action.Status == nil || action.Status.Phase != xuanwuv1.ReplicationActionRunning {
+		action.Status = &xuanwuv1.VolumeReplicationActionStatus{
+			Phase:        xuanwuv1.ReplicationActionRunning,
+			VolumeHandle: pv.Spec.CSI.VolumeHandle,
+			StartTime:    &metaV1.Time{Time: time.Now()},
+		}
+		action, err = ctrl.clientSet.XuanwuV1().VolumeReplicationActions(namespace).UpdateStatus(ctx,
+			action, metaV1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	return ctrl.finishAction(ctx, action, ctrl.executeAction(ctx, action))
+}
+
+func (ctrl *replicationController) executeAction(ctx context.Context,
+	action *xuanwuv1.VolumeReplicationAction) error {
+	ctx, cancel := context.WithTimeout(ctx, ctrl.timeout)
+	defer cancel()
+
+	volumeID := action.Status.VolumeHandle
+	log.AddContext(ctx).Infof("Start to %s replication of volume %s", action.Spec.Action, volumeID)
+	switch action.Spec.Action {
+	case xuanwuv1.SwitchoverAction:
+		return ctrl.replication.SwitchoverReplication(ctx, volumeID, action.Spec.Parameters)
+	case xuanwuv1.FailoverAction:
+		return ctrl.replication.FailoverReplication(ctx, volumeID, action.Spec.Parameters)
+	case xuanwuv1.ResyncAction:
+		return ctrl.replication.ResyncReplication(ctx, volumeID, action.Spec.Reverse)
+	default:
+		return fmt.Errorf("unsupported replication action %s", action.Spec.Action)
+	}
+}
+
+// finishAction used to set the action succeeded if actionErr is nil, otherwise failed
+func (ctrl *replicationController) finishAction(ctx context.Context, action *xuanwuv1.VolumeReplicationAction,
+	actionErr error) error {
+	if action.Status == nil {
+		action.Status = &xuanwuv1.VolumeReplicationActionStatus{}
+	}
+
+	eventType, reason, message := coreV1.EventTypeNormal, "ReplicationActionSucceeded",
+		fmt.Sprintf("%s replication of PVC %s succeeded", action.Spec.Action, action.Spec.ClaimName)
+	action.Status.Phase = xuanwuv1.ReplicationActionSucceeded
+	action.Status.Message = ""
+	if actionErr != nil {
+		eventType, reason, message = coreV1.EventTypeWarning, "ReplicationActionFailed", actionErr.Error()
+		action.Status.Phase = xuanwuv1.ReplicationActionFailed
+		action.Status.Message = actionErr.Error()
+	}
+	action.Status.CompletionTime = &metaV1.Time{Time: time.Now()}
+
+	status := action.Status
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := ctrl.clientSet.XuanwuV1().VolumeReplicationActions(action.Namespace).Get(ctx,
+			action.Name, metaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		latest.Status = status
+		_, err = ctrl.clientSet.XuanwuV1().VolumeReplicationActions(action.Namespace).UpdateStatus(ctx,
+			latest, metaV1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		log.AddContext(ctx).Errorf("Update status of VolumeReplicationAction %s to %s failed, error: %v",
+			action.Name, status.Phase, err)
+		return err
+	}
+
+	log.AddContext(ctx).Infof("VolumeReplicationAction %s is %s: %s", action.Name, action.Status.Phase, message)
+	ctrl.eventRecorder.Event(action, eventType, reason, message)
+	return nil
+}
+
+// getClaimVolume used to get the PersistentVolume bound to the PersistentVolumeClaim of the action. The claim is
+// looked up in the namespace of the action, so the action can not operate the volumes of the other namespaces.
+// The actionErr is returned if the claim is missing or unbound, which fails the action, and the err is returned
+// if the action should be retried.
+func getClaimVolume(ctx context.Context, action *xuanwuv1.VolumeReplicationAction) (
+	pv *coreV1.PersistentVolume, actionErr error, err error) {
+	k8sUtils := app.GetGlobalConfig().K8sUtils
+	pvc, err := k8sUtils.GetVolumeClaim(ctx, action.Namespace, action.Spec.ClaimName)
+	if apiErrors.IsNotFound(err) {
+		return nil, fmt.Errorf("persistentVolumeClaim %s/%s does not exist", action.Namespace,
+			action.Spec.ClaimName), nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if pvc.Spec.VolumeName == "" || pvc.Status.Phase != coreV1.ClaimBound {
+		return nil, fmt.Errorf("persistentVolumeClaim %s/%s is not bound", action.Namespace,
+			action.Spec.ClaimName), nil
+	}
+
+	pv, err = k8sUtils.GetVolumeByName(ctx, pvc.Spec.VolumeName)
+	if apiErrors.IsNotFound(err) {
+		return nil, fmt.Errorf("persistentVolume %s does not exist", pvc.Spec.VolumeName), nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return pv, nil, nil
+}
+
+func isActionFinished(action *xuanwuv1.VolumeReplicationAction) bool {
+	return action.Status != nil && (action.Status.Phase == xuanwuv1.ReplicationActionSucceeded ||
+		action.Status.Phase == xuanwuv1.ReplicationActionFailed)
+}
@@ -0,0 +1,188 @@
+/*
+ Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/prashantv/gostub"
+	coreV1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+
+	xuanwuv1 "huawei-csi-driver/client/apis/xuanwu/v1"
+	"huawei-csi-driver/csi/app"
+	cfg "huawei-csi-driver/csi/app/config"
+	"huawei-csi-driver/pkg/client/clientset/versioned/fake"
+	"huawei-csi-driver/utils/k8sutils"
+	"huawei-csi-driver/utils/log"
+)
+
+const (
+	logName      = "replicationControllerTest.log"
+	providerName = "csi.huawei.com"
+	volumeHandle = "backend.pvc-volume"
+)
+
+func TestMain(m *testing.M) {
+	log.MockInitLogging(logName)
+	defer log.MockStopLogging(logName)
+
+	getGlobalConfig := gostub.StubFunc(&app.GetGlobalConfig, cfg.MockCompletedConfig())
+	defer getGlobalConfig.Reset()
+
+	m.Run()
+}
+
+// fakeReplication records the volumes of the actions executed by the provider
+type fakeReplication struct {
+	executed []string
+	err      error
+}
+
+func (f *fakeReplication) SwitchoverReplication(_ context.Context, volumeID string, _ map[string]string) error {
+	f.executed = append(f.executed, "Switchover "+volumeID)
+	return f.err
+}
+
+func (f *fakeReplication) FailoverReplication(_ context.Context, volumeID string, _ map[string]string) error {
+	f.executed = append(f.executed, "Failover "+volumeID)
+	return f.err
+}
+
+func (f *fakeReplication) ResyncReplication(_ context.Context, volumeID string, _ bool) error {
+	f.executed = append(f.executed, "Resync "+volumeID)
+	return f.err
+}
+
+func newTestAction(status *xuanwuv1.VolumeReplicationActionStatus) *xuanwuv1.VolumeReplicationAction {
+	return &xuanwuv1.VolumeReplicationAction{
+		ObjectMeta: metaV1.ObjectMeta{Namespace: "app", Name: "action"},
+		Spec:       xuanwuv1.VolumeReplicationActionSpec{ClaimName: "data", Action: xuanwuv1.FailoverAction},
+		Status:     status,
+	}
+}
+
+func newTestClaim(volumeName string) *coreV1.PersistentVolumeClaim {
+	claim := &coreV1.PersistentVolumeClaim{
+		ObjectMeta: metaV1.ObjectMeta{Namespace: "app", Name: "data"},
+		Spec:       coreV1.PersistentVolumeClaimSpec{VolumeName: volumeName},
+	}
+	if volumeName != "" {
+		claim.Status.Phase = coreV1.ClaimBound
+	}
+	return claim
+}
+
+func newTestVolume(driver string) *coreV1.PersistentVolume {
+	return &coreV1.PersistentVolume{
+		ObjectMeta: metaV1.ObjectMeta{Name: "pvc-volume"},
+		Spec: coreV1.PersistentVolumeSpec{PersistentVolumeSource: coreV1.PersistentVolumeSource{
+			CSI: &coreV1.CSIPersistentVolumeSource{Driver: driver, VolumeHandle: volumeHandle},
+		}},
+	}
+}
+
+func TestSyncActionByKey(t *testing.T) {
+	notFound := func(resource string) error {
+		return apiErrors.NewNotFound(schema.GroupResource{Resource: resource}, "data")
+	}
+
+	cases := []struct {
+		name         string
+		action       *xuanwuv1.VolumeReplicationAction
+		claim        *coreV1.PersistentVolumeClaim
+		claimErr     error
+		volume       *coreV1.PersistentVolume
+		providerErr  error
+		wantExecuted []string
+		wantPhase    xuanwuv1.ReplicationActionPhase
+	}{
+		{"Succeeded", newTestAction(nil), newTestClaim("pvc-volume"), nil, newTestVolume(providerName),
+			nil, []string{"Failover " + volumeHandle}, xuanwuv1.ReplicationActionSucceeded},
+		{"RunningExecutedAgain", newTestAction(&xuanwuv1.VolumeReplicationActionStatus{
+			Phase: xuanwuv1.ReplicationActionRunning, VolumeHandle: volumeHandle}),
+			newTestClaim("pvc-volume"), nil, newTestVolume(providerName),
+			nil, []string{"Failover " + volumeHandle}, xuanwuv1.ReplicationActionSucceeded},
+		{"ProviderFailed", newTestAction(nil), newTestClaim("pvc-volume"), nil, newTestVolume(providerName),
+			errors.New("split pair failed"), []string{"Failover " + volumeHandle}, xuanwuv1.ReplicationActionFailed},
+		{"ClaimNotExist", newTestAction(nil), nil, notFound("persistentvolumeclaims"), nil,
+			nil, nil, xuanwuv1.ReplicationActionFailed},
+		{"ClaimNotBound", newTestAction(nil), newTestClaim(""), nil, nil,
+			nil, nil, xuanwuv1.ReplicationActionFailed},
+		{"OtherProvider", newTestAction(nil), newTestClaim("pvc-volume"), nil, newTestVolume("other.csi.com"),
+			nil, nil, ""},
+		{"AlreadyFinished", newTestAction(&xuanwuv1.VolumeReplicationActionStatus{
+			Phase: xuanwuv1.ReplicationActionFailed}), newTestClaim("pvc-volume"), nil, newTestVolume(providerName),
+			nil, nil, xuanwuv1.ReplicationActionFailed},
+	}
+
+	k8sUtils := app.GetGlobalConfig().K8sUtils
+	for _, c := range cases {
+		var claimNamespace string
+		patches := gomonkey.ApplyMethod(reflect.TypeOf(k8sUtils), "GetVolumeClaim",
+			func(_ *k8sutils.KubeClient, _ context.Context, namespace, _ string) (*coreV1.PersistentVolumeClaim,
+				error) {
+				claimNamespace = namespace
+				return c.claim, c.claimErr
+			})
+		patches.ApplyMethod(reflect.TypeOf(k8sUtils), "GetVolumeByName",
+			func(_ *k8sutils.KubeClient, _ context.Context, _ string) (*coreV1.PersistentVolume, error) {
+				return c.volume, nil
+			})
+
+		replication := &fakeReplication{err: c.providerErr}
+		ctrl := &replicationController{
+			providerName:  providerName,
+			clientSet:     fake.NewSimpleClientset(c.action),
+			eventRecorder: record.NewFakeRecorder(10),
+			timeout:       time.Second,
+			replication:   replication,
+		}
+
+		if err := ctrl.syncActionByKey(context.Background(), "app/action"); err != nil {
+			t.Errorf("Test case %s failed, error: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(replication.executed, c.wantExecuted) {
+			t.Errorf("Test case %s failed, executed: %v, want: %v", c.name, replication.executed, c.wantExecuted)
+		}
+		if claimNamespace != "" && claimNamespace != c.action.Namespace {
+			t.Errorf("Test case %s failed, the claim is got from namespace %s", c.name, claimNamespace)
+		}
+
+		action, err := ctrl.clientSet.XuanwuV1().VolumeReplicationActions("app").Get(context.Background(),
+			"action", metaV1.GetOptions{})
+		if err != nil {
+			t.Errorf("Test case %s failed, get action error: %v", c.name, err)
+		} else if phase := getActionPhase(action); phase != c.wantPhase {
+			t.Errorf("Test case %s failed, phase: %s, want: %s", c.name, phase, c.wantPhase)
+		}
+		patches.Reset()
+	}
+}
+
+func getActionPhase(action *xuanwuv1.VolumeReplicationAction) xuanwuv1.ReplicationActionPhase {
+	if action.Status == nil {
+		return ""
+	}
+	return action.Status.Phase
+}
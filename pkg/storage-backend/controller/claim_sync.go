@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	coreV1 "k8s.io/api/core/v1"
+	apiEquality "k8s.io/apimachinery/pkg/api/equality"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
@@ -335,10 +336,22 @@ func (ctrl *BackendController) isUpdateFinalClaimStatus(
 	claimObj *xuanwuv1.StorageBackendClaim, content *xuanwuv1.StorageBackendContent) bool {
 	newStatus := claimObj.Status.DeepCopy()
 	var changed bool
-	if content.Status == nil || (content.Status.ContentName == "" && content.Status.VendorName == "") {
+	if content.Status == nil {
 		return false
 	}
 
+	if newStatus.ObservedGeneration != claimObj.Generation ||
+		!apiEquality.Semantic.DeepEqual(newStatus.Conditions, content.Status.Conditions) {
+		newStatus.ObservedGeneration = claimObj.Generation
+		newStatus.Conditions = content.Status.DeepCopy().Conditions
+		changed = true
+	}
+
+	if content.Status.ContentName == "" && content.Status.VendorName == "" {
+		claimObj.Status = newStatus
+		return changed
+	}
+
 	if content.Status.ContentName != "" && newStatus.StorageBackendId != content.Status.ContentName {
 		newStatus.StorageBackendId = content.Status.ContentName
 		changed = true
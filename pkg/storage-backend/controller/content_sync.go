@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	coreV1 "k8s.io/api/core/v1"
+	apiEquality "k8s.io/apimachinery/pkg/api/equality"
 
 	xuanwuv1 "huawei-csi-driver/client/apis/xuanwu/v1"
 	"huawei-csi-driver/pkg/finalizers"
@@ -72,6 +73,10 @@ func (ctrl *BackendController) syncContent(ctx context.Context, content *xuanwuv
 		return err
 	}
 
+	if claim != nil && utils.IsClaimReady(claim) && needSyncClaimConditions(claim, content) {
+		return ctrl.syncClaimConditions(ctx, claim, content)
+	}
+
 	if claim != nil && ctrl.needUpdateClaimStatus(claim, content) {
 		ctrl.claimQueue.Add(utils.StorageBackendClaimKey(claim))
 	}
@@ -79,6 +84,34 @@ func (ctrl *BackendController) syncContent(ctx context.Context, content *xuanwuv
 	return nil
 }
 
+// needSyncClaimConditions returns whether the conditions of the claim are different from the ones of the content
+func needSyncClaimConditions(claim *xuanwuv1.StorageBackendClaim, content *xuanwuv1.StorageBackendContent) bool {
+	if claim.Status == nil || content.Status == nil {
+		return false
+	}
+
+	return claim.Status.ObservedGeneration != claim.Generation ||
+		!apiEquality.Semantic.DeepEqual(claim.Status.Conditions, content.Status.Conditions)
+}
+
+// syncClaimConditions used to copy the conditions of the content to the bound claim
+func (ctrl *BackendController) syncClaimConditions(ctx context.Context, claim *xuanwuv1.StorageBackendClaim,
+	content *xuanwuv1.StorageBackendContent) error {
+
+	newClaim := claim.DeepCopy()
+	newClaim.Status.ObservedGeneration = newClaim.Generation
+	newClaim.Status.Conditions = content.Status.DeepCopy().Conditions
+	_, err := ctrl.updateClaimStatusWithEvent(ctx, newClaim, "SyncConditions",
+		"Successful sync conditions from storageBackendContent")
+	if err != nil {
+		log.AddContext(ctx).Errorf("syncClaimConditions: update claim %s status failed, error: %v",
+			utils.StorageBackendClaimKey(claim), err)
+		return err
+	}
+
+	return nil
+}
+
 func (ctrl *BackendController) addContentFinalizer(ctx context.Context, content *xuanwuv1.StorageBackendContent) error {
 	finalizers.SetFinalizer(content, utils.ContentBoundFinalizer)
 	newObj, err := utils.UpdateContent(ctx, ctrl.clientSet, content)
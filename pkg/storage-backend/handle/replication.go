@@ -0,0 +1,77 @@
+/*
+ Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package handle
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"huawei-csi-driver/lib/drcsi"
+	"huawei-csi-driver/utils/log"
+)
+
+// ReplicationInterfaces includes interfaces that call provider to operate the replication of the volumes
+type ReplicationInterfaces interface {
+	// SwitchoverReplication make the volume the primary of its replication pairs in a planned way
+	SwitchoverReplication(ctx context.Context, volumeID string, parameters map[string]string) error
+	// FailoverReplication promote the secondary volume when the primary site is lost
+	FailoverReplication(ctx context.Context, volumeID string, parameters map[string]string) error
+	// ResyncReplication resume the replication pairs of the volume after the failed site recovers
+	ResyncReplication(ctx context.Context, volumeID string, reverse bool) error
+}
+
+type replication struct {
+	conn *grpc.ClientConn
+}
+
+// NewReplication returns a new ReplicationInterfaces
+func NewReplication(conn *grpc.ClientConn) ReplicationInterfaces {
+	return &replication{
+		conn: conn,
+	}
+}
+
+// SwitchoverReplication make the volume the primary of its replication pairs in a planned way
+func (r *replication) SwitchoverReplication(ctx context.Context, volumeID string,
+	parameters map[string]string) error {
+	log.AddContext(ctx).Infof("SwitchoverReplication of volume %s", volumeID)
+	_, err := drcsi.NewReplicationClient(r.conn).SwitchoverReplication(ctx, &drcsi.SwitchoverReplicationRequest{
+		VolumeId:   volumeID,
+		Parameters: parameters,
+	})
+	return err
+}
+
+// FailoverReplication promote the secondary volume when the primary site is lost
+func (r *replication) FailoverReplication(ctx context.Context, volumeID string,
+	parameters map[string]string) error {
+	log.AddContext(ctx).Infof("FailoverReplication of volume %s", volumeID)
+	_, err := drcsi.NewReplicationClient(r.conn).FailoverReplication(ctx, &drcsi.FailoverReplicationRequest{
+		VolumeId:   volumeID,
+		Parameters: parameters,
+	})
+	return err
+}
+
+// ResyncReplication resume the replication pairs of the volume after the failed site recovers
+func (r *replication) ResyncReplication(ctx context.Context, volumeID string, reverse bool) error {
+	log.AddContext(ctx).Infof("ResyncReplication of volume %s, reverse: %v", volumeID, reverse)
+	_, err := drcsi.NewReplicationClient(r.conn).ResyncReplication(ctx, &drcsi.ResyncReplicationRequest{
+		VolumeId: volumeID,
+		Reverse:  reverse,
+	})
+	return err
+}
@@ -0,0 +1,155 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package utils
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xuanwuv1 "huawei-csi-driver/client/apis/xuanwu/v1"
+)
+
+const (
+	backendErrorDomain       = "xuanwu.huawei.io"
+	backendErrorConditionKey = "condition"
+)
+
+// BackendError records the condition of the storage backend broken by the error, so that the sidecar can
+// report why the backend is unavailable
+type BackendError struct {
+	// ConditionType is the condition broken by the error, such as Authenticated, Reachable and PoolsAvailable
+	ConditionType string
+	// Reason is the reason of the broken condition, such as WrongPassword and IPLocked
+	Reason string
+	Err    error
+}
+
+// NewBackendError used to wrap the error with the broken condition of the storage backend
+func NewBackendError(conditionType, reason string, err error) error {
+	return &BackendError{ConditionType: conditionType, Reason: reason, Err: err}
+}
+
+// Error returns the message of the wrapped error
+func (e *BackendError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (e *BackendError) Unwrap() error {
+	return e.Err
+}
+
+// BackendGRPCError used to convert the error to the grpc error with the message, the broken condition of the
+// BackendError is carried by the error details
+func BackendGRPCError(err error, msg string) error {
+	var backendErr *BackendError
+	if !errors.As(err, &backendErr) {
+		return errors.New(msg)
+	}
+
+	st := status.New(getBackendErrorCode(backendErr.ConditionType), msg)
+	detailedSt, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   backendErr.Reason,
+		Domain:   backendErrorDomain,
+		Metadata: map[string]string{backendErrorConditionKey: backendErr.ConditionType},
+	})
+	if detailErr != nil {
+		return st.Err()
+	}
+
+	return detailedSt.Err()
+}
+
+func getBackendErrorCode(conditionType string) codes.Code {
+	switch conditionType {
+	case xuanwuv1.ConditionAuthenticated:
+		return codes.Unauthenticated
+	case xuanwuv1.ConditionReachable:
+		return codes.Unavailable
+	case xuanwuv1.ConditionPoolsAvailable:
+		return codes.NotFound
+	default:
+		return codes.Unknown
+	}
+}
+
+// ParseBackendError used to restore the BackendError from the grpc error returned by the provider,
+// the error is returned as it is if no condition is carried
+func ParseBackendError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || err == nil {
+		return err
+	}
+
+	for _, detail := range st.Details() {
+		errInfo, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || errInfo.GetDomain() != backendErrorDomain {
+			continue
+		}
+
+		return NewBackendError(errInfo.GetMetadata()[backendErrorConditionKey], errInfo.GetReason(),
+			errors.New(st.Message()))
+	}
+
+	return err
+}
+
+// SetBackendConditions used to set the conditions of the storage backend by the result of the synchronization.
+// All conditions are true if err is nil. Otherwise, the condition broken by the BackendError is false, the conditions
+// it depends on are true, and the other conditions are left unchanged if the error is not a BackendError.
+func SetBackendConditions(conditions *[]metav1.Condition, generation int64, err error) {
+	setCondition := func(conditionType string, conditionStatus metav1.ConditionStatus, reason, message string) {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             conditionStatus,
+			ObservedGeneration: generation,
+			Reason:             reason,
+			Message:            message,
+		})
+	}
+
+	if err == nil {
+		setCondition(xuanwuv1.ConditionReachable, metav1.ConditionTrue, xuanwuv1.ReasonConnected, "")
+		setCondition(xuanwuv1.ConditionAuthenticated, metav1.ConditionTrue, xuanwuv1.ReasonLoginSucceeded, "")
+		setCondition(xuanwuv1.ConditionPoolsAvailable, metav1.ConditionTrue, xuanwuv1.ReasonPoolsFound, "")
+		setCondition(xuanwuv1.ConditionSynced, metav1.ConditionTrue, xuanwuv1.ReasonSynced, "")
+		return
+	}
+
+	setCondition(xuanwuv1.ConditionSynced, metav1.ConditionFalse, xuanwuv1.ReasonSyncFailed, err.Error())
+	var backendErr *BackendError
+	if !errors.As(err, &backendErr) {
+		return
+	}
+
+	switch backendErr.ConditionType {
+	case xuanwuv1.ConditionReachable:
+		setCondition(xuanwuv1.ConditionReachable, metav1.ConditionFalse, backendErr.Reason, err.Error())
+	case xuanwuv1.ConditionAuthenticated:
+		setCondition(xuanwuv1.ConditionReachable, metav1.ConditionTrue, xuanwuv1.ReasonConnected, "")
+		setCondition(xuanwuv1.ConditionAuthenticated, metav1.ConditionFalse, backendErr.Reason, err.Error())
+	case xuanwuv1.ConditionPoolsAvailable:
+		setCondition(xuanwuv1.ConditionReachable, metav1.ConditionTrue, xuanwuv1.ReasonConnected, "")
+		setCondition(xuanwuv1.ConditionAuthenticated, metav1.ConditionTrue, xuanwuv1.ReasonLoginSucceeded, "")
+		setCondition(xuanwuv1.ConditionPoolsAvailable, metav1.ConditionFalse, backendErr.Reason, err.Error())
+	}
+}
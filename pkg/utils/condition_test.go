@@ -0,0 +1,71 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xuanwuv1 "huawei-csi-driver/client/apis/xuanwu/v1"
+)
+
+func TestParseBackendError(t *testing.T) {
+	loginErr := NewBackendError(xuanwuv1.ConditionAuthenticated, xuanwuv1.ReasonWrongPassword,
+		errors.New("login failed"))
+	grpcErr := BackendGRPCError(loginErr, "register backend failed")
+
+	var backendErr *BackendError
+	if !errors.As(ParseBackendError(grpcErr), &backendErr) {
+		t.Fatalf("ParseBackendError of %v is not a BackendError", grpcErr)
+	}
+	if backendErr.ConditionType != xuanwuv1.ConditionAuthenticated ||
+		backendErr.Reason != xuanwuv1.ReasonWrongPassword || backendErr.Error() != "register backend failed" {
+		t.Errorf("ParseBackendError got %+v", backendErr)
+	}
+
+	plainErr := BackendGRPCError(errors.New("other error"), "get stats failed")
+	if errors.As(ParseBackendError(plainErr), &backendErr) {
+		t.Errorf("ParseBackendError of %v should not be a BackendError", plainErr)
+	}
+}
+
+func TestSetBackendConditions(t *testing.T) {
+	var conditions []metav1.Condition
+	SetBackendConditions(&conditions, 1, NewBackendError(xuanwuv1.ConditionPoolsAvailable,
+		xuanwuv1.ReasonPoolsNotFound, errors.New("pool not found")))
+	if !meta.IsStatusConditionTrue(conditions, xuanwuv1.ConditionAuthenticated) ||
+		!meta.IsStatusConditionFalse(conditions, xuanwuv1.ConditionPoolsAvailable) ||
+		!meta.IsStatusConditionFalse(conditions, xuanwuv1.ConditionSynced) {
+		t.Errorf("SetBackendConditions with pools not found got %+v", conditions)
+	}
+
+	SetBackendConditions(&conditions, 2, errors.New("other error"))
+	poolsCondition := meta.FindStatusCondition(conditions, xuanwuv1.ConditionPoolsAvailable)
+	if poolsCondition == nil || poolsCondition.Reason != xuanwuv1.ReasonPoolsNotFound {
+		t.Errorf("SetBackendConditions with other error should keep PoolsAvailable, got %+v", conditions)
+	}
+
+	SetBackendConditions(&conditions, 3, nil)
+	for _, condition := range conditions {
+		if condition.Status != metav1.ConditionTrue || condition.ObservedGeneration != 3 {
+			t.Errorf("SetBackendConditions without error got %+v", condition)
+		}
+	}
+}
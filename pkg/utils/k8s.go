@@ -216,3 +216,28 @@ func SetStorageBackendContentOnlineStatus(ctx context.Context, backendID string,
 		backendID, online)
 	return nil
 }
+
+// SetStorageBackendContentOffline used to set the storageBackendContent offline, the conditions of the content are
+// set by the error which makes the backend offline, such as the wrong password
+func SetStorageBackendContentOffline(ctx context.Context, backendID string, reason error) error {
+	content, err := GetContentByClaimMeta(ctx, backendID)
+	if err != nil {
+		msg := fmt.Sprintf("GetContentByClaimMeta: [%s] failed, err: [%v]", backendID, err)
+		return Errorln(ctx, msg)
+	}
+
+	if content.Status == nil {
+		msg := fmt.Sprintf("StorageBackendContent: [%s] status is nil, SetStorageBackendContentOffline failed.",
+			content.Name)
+		return Errorln(ctx, msg)
+	}
+
+	SetBackendConditions(&content.Status.Conditions, content.Generation, reason)
+	err = SetSBCTOnlineStatus(ctx, content, false)
+	if err != nil {
+		return err
+	}
+
+	log.AddContext(ctx).Infof("SetStorageBackendContentOffline [%s] succeeded, reason: %v", backendID, reason)
+	return nil
+}
@@ -0,0 +1,182 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package utils
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strconv"
+
+	xuanwuv1 "huawei-csi-driver/client/apis/xuanwu/v1"
+	"huawei-csi-driver/csi/app"
+	"huawei-csi-driver/utils/log"
+)
+
+const (
+	// CACertKey is the key of the CA bundle in the secret or the configmap
+	CACertKey = "ca.crt"
+	// ClientCertKey is the key of the client certificate in the tls secret
+	ClientCertKey = "tls.crt"
+	// ClientKeyKey is the key of the client private key in the tls secret
+	ClientKeyKey = "tls.key"
+)
+
+// BackendTLSConfig stores the tls settings of the storage backend configured in the backend configmap
+type BackendTLSConfig struct {
+	// VerifyCert is whether to verify the certificate of the storage, default is false for the backends configured
+	// before the certificate verification is supported. oceanctl sets it to true for the new backends.
+	VerifyCert bool
+	// CACertSecret is the secret of the CA bundle, the format is <namespace>/<name>
+	CACertSecret string
+	// CACertConfigMap is the configmap of the CA bundle, the format is <namespace>/<name>
+	CACertConfigMap string
+	// ClientCertSecret is the kubernetes.io/tls secret of the client certificate, the format is <namespace>/<name>
+	ClientCertSecret string
+	// ServerName is used to verify the hostname of the storage certificate instead of the host of the url
+	ServerName string
+}
+
+// ParseBackendTLSConfig used to parse the tls settings from the backend config, the certificate of the storage is
+// not verified if verifyCert is not configured, so that the existing backends still work after upgrade
+func ParseBackendTLSConfig(config map[string]interface{}) (*BackendTLSConfig, error) {
+	tlsConfig := &BackendTLSConfig{}
+	switch verifyCert := config["verifyCert"].(type) {
+	case nil:
+	case bool:
+		tlsConfig.VerifyCert = verifyCert
+	case string:
+		verify, err := strconv.ParseBool(verifyCert)
+		if err != nil {
+			return nil, fmt.Errorf("verifyCert [%s] must be true or false", verifyCert)
+		}
+		tlsConfig.VerifyCert = verify
+	default:
+		return nil, fmt.Errorf("verifyCert [%v] must be true or false", verifyCert)
+	}
+
+	tlsConfig.CACertSecret, _ = config["caCertSecret"].(string)
+	tlsConfig.CACertConfigMap, _ = config["caCertConfigMap"].(string)
+	tlsConfig.ClientCertSecret, _ = config["clientCertSecret"].(string)
+	tlsConfig.ServerName, _ = config["serverName"].(string)
+	if tlsConfig.CACertSecret != "" && tlsConfig.CACertConfigMap != "" {
+		return nil, errors.New("only one of caCertSecret and caCertConfigMap can be configured")
+	}
+
+	return tlsConfig, nil
+}
+
+// NewTLSConfig used to build the tls config of the storage client, the system CA pool is used
+// if no CA bundle is configured
+func NewTLSConfig(ctx context.Context, backendTLSConfig *BackendTLSConfig) (*tls.Config, error) {
+	if backendTLSConfig == nil {
+		return &tls.Config{}, nil
+	}
+
+	if !backendTLSConfig.VerifyCert {
+		log.AddContext(ctx).Warningln("The certificate verification of the storage is disabled")
+		return &tls.Config{InsecureSkipVerify: true}, nil
+	}
+
+	tlsConfig := &tls.Config{ServerName: backendTLSConfig.ServerName}
+	caCert, err := getCACert(ctx, backendTLSConfig)
+	if err != nil {
+		return nil, err
+	}
+	if caCert != nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, Errorf(ctx, "no valid PEM certificate is found in the CA bundle of the backend")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if backendTLSConfig.ClientCertSecret != "" {
+		secret, err := GetBackendSecret(ctx, backendTLSConfig.ClientCertSecret)
+		if err != nil {
+			return nil, Errorf(ctx, "get client certificate secret %s failed, error: %v",
+				backendTLSConfig.ClientCertSecret, err)
+		}
+
+		cert, err := tls.X509KeyPair(secret.Data[ClientCertKey], secret.Data[ClientKeyKey])
+		if err != nil {
+			return nil, Errorf(ctx, "load client certificate from secret %s failed, error: %v",
+				backendTLSConfig.ClientCertSecret, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func getCACert(ctx context.Context, backendTLSConfig *BackendTLSConfig) ([]byte, error) {
+	if backendTLSConfig.CACertSecret != "" {
+		secret, err := GetBackendSecret(ctx, backendTLSConfig.CACertSecret)
+		if err != nil {
+			return nil, Errorf(ctx, "get CA certificate secret %s failed, error: %v",
+				backendTLSConfig.CACertSecret, err)
+		}
+
+		caCert, exist := secret.Data[CACertKey]
+		if !exist {
+			return nil, Errorf(ctx, "%s does not exist in CA certificate secret %s",
+				CACertKey, backendTLSConfig.CACertSecret)
+		}
+		return caCert, nil
+	}
+
+	if backendTLSConfig.CACertConfigMap != "" {
+		namespace, name, err := SplitMetaNamespaceKey(backendTLSConfig.CACertConfigMap)
+		if err != nil {
+			return nil, fmt.Errorf("split configmap meta %s namespace failed, error: %v",
+				backendTLSConfig.CACertConfigMap, err)
+		}
+
+		configmap, err := app.GetGlobalConfig().K8sUtils.GetConfigmap(ctx, name, namespace)
+		if err != nil {
+			return nil, Errorf(ctx, "get CA certificate configmap %s failed, error: %v",
+				backendTLSConfig.CACertConfigMap, err)
+		}
+
+		caCert, exist := configmap.Data[CACertKey]
+		if !exist {
+			return nil, Errorf(ctx, "%s does not exist in CA certificate configmap %s",
+				CACertKey, backendTLSConfig.CACertConfigMap)
+		}
+		return []byte(caCert), nil
+	}
+
+	return nil, nil
+}
+
+// IsCertVerifyError used to check whether the error is caused by the certificate verification of the storage
+func IsCertVerifyError(err error) bool {
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var certInvalidErr x509.CertificateInvalidError
+	return errors.As(err, &unknownAuthorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &certInvalidErr)
+}
+
+// NewCertVerifyError used to make the certificate verification error of the storage readable
+func NewCertVerifyError(url string, err error) error {
+	return NewBackendError(xuanwuv1.ConditionReachable, xuanwuv1.ReasonCertificateInvalid,
+		fmt.Errorf("verify the certificate of storage %s failed: %v. Please configure the CA bundle of the "+
+			"storage by caCertSecret or caCertConfigMap, set serverName if the certificate is not issued to the "+
+			"address of the url, or set verifyCert to false to skip the verification", url, err))
+}
@@ -0,0 +1,109 @@
+/*
+ Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+      http://www.apache.org/licenses/LICENSE-2.0
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseBackendTLSConfig(t *testing.T) {
+	cases := []struct {
+		name       string
+		config     map[string]interface{}
+		wantVerify bool
+		wantErr    bool
+	}{
+		{"Legacy config without verify", map[string]interface{}{}, false, false},
+		{"Enable verify", map[string]interface{}{"verifyCert": true}, true, false},
+		{"Disable verify", map[string]interface{}{"verifyCert": false}, false, false},
+		{"Disable verify by string", map[string]interface{}{"verifyCert": "false"}, false, false},
+		{"Invalid verify", map[string]interface{}{"verifyCert": "no-such"}, false, true},
+		{"Both CA secret and configmap", map[string]interface{}{
+			"caCertSecret": "ns/secret", "caCertConfigMap": "ns/configmap"}, false, true},
+	}
+
+	for _, c := range cases {
+		tlsConfig, err := ParseBackendTLSConfig(c.config)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%s: ParseBackendTLSConfig error: %v, want error: %v", c.name, err, c.wantErr)
+			continue
+		}
+		if err == nil && tlsConfig.VerifyCert != c.wantVerify {
+			t.Errorf("%s: VerifyCert is %v, want %v", c.name, tlsConfig.VerifyCert, c.wantVerify)
+		}
+	}
+}
+
+func TestNewTLSConfigWithoutVerify(t *testing.T) {
+	tlsConfig, err := NewTLSConfig(context.TODO(), &BackendTLSConfig{VerifyCert: false})
+	if err != nil || !tlsConfig.InsecureSkipVerify {
+		t.Errorf("NewTLSConfig without verify failed, config: %v, error: %v", tlsConfig, err)
+	}
+
+	tlsConfig, err = NewTLSConfig(context.TODO(), &BackendTLSConfig{VerifyCert: true, ServerName: "storage"})
+	if err != nil || tlsConfig.InsecureSkipVerify || tlsConfig.ServerName != "storage" {
+		t.Errorf("NewTLSConfig with verify failed, config: %v, error: %v", tlsConfig, err)
+	}
+}
+
+func TestIsCertVerifyError(t *testing.T) {
+	certErr := fmt.Errorf("post failed: %w", x509.UnknownAuthorityError{})
+	if !IsCertVerifyError(certErr) {
+		t.Errorf("IsCertVerifyError of %v should be true", certErr)
+	}
+
+	if IsCertVerifyError(errors.New("connection refused")) {
+		t.Errorf("IsCertVerifyError of connection error should be false")
+	}
+}
+
+func TestLegacyConfigConnectsSelfSignedStorage(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	backendTLSConfig, err := ParseBackendTLSConfig(map[string]interface{}{"storage": "oceanstor-san"})
+	if err != nil {
+		t.Fatalf("ParseBackendTLSConfig of legacy config failed, error: %v", err)
+	}
+
+	tlsConfig, err := NewTLSConfig(context.TODO(), backendTLSConfig)
+	if err != nil {
+		t.Fatalf("NewTLSConfig of legacy config failed, error: %v", err)
+	}
+
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	resp, err := httpClient.Get(server.URL)
+	if err != nil {
+		t.Fatalf("legacy config should connect the storage with self-signed certificate, error: %v", err)
+	}
+	resp.Body.Close()
+
+	backendTLSConfig.VerifyCert = true
+	if tlsConfig, err = NewTLSConfig(context.TODO(), backendTLSConfig); err != nil {
+		t.Fatalf("NewTLSConfig with verify failed, error: %v", err)
+	}
+	httpClient = &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	if _, err = httpClient.Get(server.URL); !IsCertVerifyError(err) {
+		t.Errorf("config with verify should reject the self-signed certificate, error: %v", err)
+	}
+}
@@ -41,6 +41,10 @@ import (
 	"huawei-csi-driver/utils/log"
 )
 
+// StorageInfoGetter used to get the storage info of the StorageBackendClaim, which is the backend config of the
+// configmap with the account of the secret. Nil storage info means the storage is not validated.
+type StorageInfoGetter func(ctx context.Context, claim *xuanwuv1.StorageBackendClaim) (map[string]interface{}, error)
+
 // Controller include webhook resources
 type Controller struct {
 	Recorder record.EventRecorder
@@ -337,12 +341,24 @@ func getStorageBackendClaim(ctx context.Context, operation admissionV1.Operation
 }
 
 func validateCreate(ctx context.Context, claim *xuanwuv1.StorageBackendClaim) error {
+	return ValidateCreate(ctx, claim, getStorageBackendInfo)
+}
+
+func validateUpdate(ctx context.Context, newClaim, oldClaim *xuanwuv1.StorageBackendClaim) error {
+	return ValidateUpdate(ctx, newClaim, oldClaim, getStorageBackendInfo)
+}
+
+// ValidateCreate used to validate the StorageBackendClaim to be created. The storage info is got by getInfo, so
+// that the claim can be validated before its configmap and secret are persisted, e.g. the dry-run of oceanctl.
+func ValidateCreate(ctx context.Context, claim *xuanwuv1.StorageBackendClaim, getInfo StorageInfoGetter) error {
 	log.AddContext(ctx).Infof("Start to validateCreate %s.", utils.StorageBackendClaimKey(claim))
 	defer log.AddContext(ctx).Infof("Finished validateCreate %s.", utils.StorageBackendClaimKey(claim))
-	return validateCommon(ctx, claim)
+	return validateCommon(ctx, claim, getInfo)
 }
 
-func validateUpdate(ctx context.Context, newClaim, oldClaim *xuanwuv1.StorageBackendClaim) error {
+// ValidateUpdate used to validate the StorageBackendClaim to be updated, the storage info is got by getInfo
+func ValidateUpdate(ctx context.Context, newClaim, oldClaim *xuanwuv1.StorageBackendClaim,
+	getInfo StorageInfoGetter) error {
 	log.AddContext(ctx).Infof("Start to validateUpdate %s.", utils.StorageBackendClaimKey(newClaim))
 	defer log.AddContext(ctx).Infof("Finished validateUpdate %s.", utils.StorageBackendClaimKey(newClaim))
 	if reflect.DeepEqual(newClaim.Spec, oldClaim.Spec) && reflect.DeepEqual(
@@ -364,7 +380,7 @@ func validateUpdate(ctx context.Context, newClaim, oldClaim *xuanwuv1.StorageBac
 		return errors.New(msg)
 	}
 
-	return validateCommon(ctx, newClaim)
+	return validateCommon(ctx, newClaim, getInfo)
 }
 
 func validateDelete(ctx context.Context, claim *xuanwuv1.StorageBackendClaim) error {
@@ -411,20 +427,23 @@ func validateCommonClaim(ctx context.Context, claim *xuanwuv1.StorageBackendClai
 	return nil
 }
 
-func validateCommon(ctx context.Context, claim *xuanwuv1.StorageBackendClaim) error {
+func validateCommon(ctx context.Context, claim *xuanwuv1.StorageBackendClaim, getInfo StorageInfoGetter) error {
 	if err := validateCommonClaim(ctx, claim); err != nil {
 		return err
 	}
 
 	log.AddContext(ctx).Infof("claim name: %s", claim.Name)
-	storageInfo, err := backend.GetStorageBackendInfo(ctx,
-		utils.MakeMetaWithNamespace(app.GetGlobalConfig().Namespace, claim.Name),
-		claim.Spec.ConfigMapMeta,
-		claim.Spec.SecretMeta)
+	storageInfo, err := getInfo(ctx, claim)
 	if err != nil {
 		return err
 	}
 
+	if storageInfo == nil {
+		log.AddContext(ctx).Infof("Storage info of claim %s is not given, skip validating the storage.",
+			utils.StorageBackendClaimKey(claim))
+		return nil
+	}
+
 	// make new backend, meanwhile check some common param
 	targetBackend, err := backend.NewBackend(claim.Name, storageInfo)
 	if err != nil {
@@ -439,13 +458,51 @@ func validateCommon(ctx context.Context, claim *xuanwuv1.StorageBackendClaim) er
 	return nil
 }
 
+// getStorageBackendInfo used to get the storage info by the configmap and the secret of the claim in the cluster
+func getStorageBackendInfo(ctx context.Context, claim *xuanwuv1.StorageBackendClaim) (map[string]interface{},
+	error) {
+	return backend.GetStorageBackendInfo(ctx,
+		utils.MakeMetaWithNamespace(app.GetGlobalConfig().Namespace, claim.Name),
+		claim.Spec.ConfigMapMeta,
+		claim.Spec.SecretMeta)
+}
+
+// getDryRunStorageBackendInfo used to get the storage info of the claim in a dry-run request. The configmap and
+// the secret created by the same dry-run are not persisted, so nil is returned if they do not exist and only the
+// claim itself is validated, the storage is validated by oceanctl with the objects it renders instead.
+func getDryRunStorageBackendInfo(ctx context.Context, claim *xuanwuv1.StorageBackendClaim) (
+	map[string]interface{}, error) {
+	k8sUtils := app.GetGlobalConfig().K8sUtils
+	namespace, name, err := utils.SplitMetaNamespaceKey(claim.Spec.ConfigMapMeta)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = k8sUtils.GetConfigmap(ctx, name, namespace); apisErrors.IsNotFound(err) {
+		log.AddContext(ctx).Infof("Configmap %s of dry-run claim %s does not exist.", claim.Spec.ConfigMapMeta,
+			utils.StorageBackendClaimKey(claim))
+		return nil, nil
+	}
+
+	namespace, name, err = utils.SplitMetaNamespaceKey(claim.Spec.SecretMeta)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = k8sUtils.GetSecret(ctx, name, namespace); apisErrors.IsNotFound(err) {
+		log.AddContext(ctx).Infof("Secret %s of dry-run claim %s does not exist.", claim.Spec.SecretMeta,
+			utils.StorageBackendClaimKey(claim))
+		return nil, nil
+	}
+
+	return getStorageBackendInfo(ctx, claim)
+}
+
 func validateStorageBackendClaim(ctx context.Context, operation admissionV1.Operation,
-	newClaim, oldClaim *xuanwuv1.StorageBackendClaim) error {
+	newClaim, oldClaim *xuanwuv1.StorageBackendClaim, getInfo StorageInfoGetter) error {
 	switch operation {
 	case admissionV1.Create:
-		return validateCreate(ctx, newClaim)
+		return ValidateCreate(ctx, newClaim, getInfo)
 	case admissionV1.Update:
-		return validateUpdate(ctx, newClaim, oldClaim)
+		return ValidateUpdate(ctx, newClaim, oldClaim, getInfo)
 	case admissionV1.Delete:
 		return validateDelete(ctx, oldClaim)
 	case admissionV1.Connect:
@@ -468,7 +525,12 @@ func admitStorageBackendClaim(ar admissionV1.AdmissionReview) *admissionV1.Admis
 		return getFalseAdmissionResponse(err)
 	}
 
-	err = validateStorageBackendClaim(ctx, ar.Request.Operation, newClaim, oldClaim)
+	getInfo := getStorageBackendInfo
+	if ar.Request.DryRun != nil && *ar.Request.DryRun {
+		getInfo = getDryRunStorageBackendInfo
+	}
+
+	err = validateStorageBackendClaim(ctx, ar.Request.Operation, newClaim, oldClaim, getInfo)
 	if err != nil {
 		log.Errorf("Failed to validate StorageBackendClaim, error: %v", err)
 		return getFalseAdmissionResponse(err)
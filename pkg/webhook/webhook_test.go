@@ -98,3 +98,18 @@ func TestValidateUpdateConfigmapChanged(t *testing.T) {
 		t.Error("TestValidateUpdate failed")
 	}
 }
+
+func TestValidateCreateWithoutStorageInfo(t *testing.T) {
+	getInfo := func(ctx context.Context, claim *xuanwuv1.StorageBackendClaim) (map[string]interface{}, error) {
+		return nil, nil
+	}
+	if err := ValidateCreate(context.TODO(), newFakeClaim(
+		"provider-1", "huawei-csi/configmap-1", "huawei-csi/secret-1"), getInfo); err != nil {
+		t.Errorf("TestValidateCreateWithoutStorageInfo failed, error: %v", err)
+	}
+
+	if err := ValidateCreate(context.TODO(), newFakeClaim(
+		"", "huawei-csi/configmap-1", "huawei-csi/secret-1"), getInfo); err == nil {
+		t.Error("TestValidateCreateWithoutStorageInfo should fail without provider")
+	}
+}
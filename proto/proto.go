@@ -27,9 +27,17 @@ import (
 	"huawei-csi-driver/utils/log"
 )
 
+const (
+	// ISCSIInitiatorCmd is the command to get the iSCSI initiator of the host
+	ISCSIInitiatorCmd = "awk 'BEGIN{FS=\"=\";ORS=\"\"}/^InitiatorName=/{print $2}' /etc/iscsi/initiatorname.iscsi"
+	// FCInitiatorCmd is the command to get the FC initiators of the host
+	FCInitiatorCmd = "cat /sys/class/fc_host/host*/port_name | awk 'BEGIN{FS=\"0x\";ORS=\" \"}{print $2}'"
+	// RoCEInitiatorCmd is the command to get the NVMe initiator of the host
+	RoCEInitiatorCmd = "cat /etc/nvme/hostnqn"
+)
+
 func GetISCSIInitiator(ctx context.Context) (string, error) {
-	output, err := utils.ExecShellCmd(ctx,
-		"awk 'BEGIN{FS=\"=\";ORS=\"\"}/^InitiatorName=/{print $2}' /etc/iscsi/initiatorname.iscsi")
+	output, err := utils.ExecShellCmd(ctx, ISCSIInitiatorCmd)
 	if err != nil {
 		if strings.Contains(output, "cannot open file") {
 			return "", errors.New("no ISCSI initiator exist")
@@ -43,8 +51,7 @@ func GetISCSIInitiator(ctx context.Context) (string, error) {
 }
 
 func GetFCInitiator(ctx context.Context) ([]string, error) {
-	output, err := utils.ExecShellCmd(ctx,
-		"cat /sys/class/fc_host/host*/port_name | awk 'BEGIN{FS=\"0x\";ORS=\" \"}{print $2}'")
+	output, err := utils.ExecShellCmd(ctx, FCInitiatorCmd)
 	if err != nil {
 		log.AddContext(ctx).Infof("Get FC initiator error: %v", output)
 		return nil, err
@@ -58,7 +65,7 @@ func GetFCInitiator(ctx context.Context) ([]string, error) {
 }
 
 func GetRoCEInitiator(ctx context.Context) (string, error) {
-	output, err := utils.ExecShellCmd(ctx, "cat /etc/nvme/hostnqn")
+	output, err := utils.ExecShellCmd(ctx, RoCEInitiatorCmd)
 	if err != nil {
 		if strings.Contains(output, "No such file or directory") {
 			return "", errors.New("no NVME initiator exists")
@@ -31,10 +31,12 @@ import (
 	"sync"
 	"time"
 
+	xuanwuv1 "huawei-csi-driver/client/apis/xuanwu/v1"
 	pkgUtils "huawei-csi-driver/pkg/utils"
 	"huawei-csi-driver/storage/fusionstorage/types"
 	"huawei-csi-driver/utils"
 	"huawei-csi-driver/utils/log"
+	"huawei-csi-driver/utils/metrics"
 )
 
 const (
@@ -67,7 +69,6 @@ var (
 			"/api/v2/nas_protocol/nfs_service_config": true,
 		},
 	}
-	clientSemaphore *utils.Semaphore
 )
 
 func isFilterLog(method, url string) bool {
@@ -87,8 +88,12 @@ type Client struct {
 
 	authToken string
 	client    *http.Client
+	tlsConfig *tls.Config
 
 	reloginMutex sync.Mutex
+
+	// semaphore used to limit the concurrent requests to the storage, shared by the clients of the same backend
+	semaphore *utils.Semaphore
 }
 
 // NewClientConfig stores the information needed to create a new FusionStorage client
@@ -100,24 +105,14 @@ type NewClientConfig struct {
 	ParallelNum     string
 	BackendID       string
 	AccountName     string
+	// TLSConfig used to verify the certificate of the storage, nil means verify by the system CA pool
+	TLSConfig *tls.Config
 }
 
-func NewClient(url, user, secretName, secretNamespace, parallelNum, backendID, accountName string) *Client {
-	var err error
-	var parallelCount int
-
-	if len(parallelNum) > 0 {
-		parallelCount, err = strconv.Atoi(parallelNum)
-		if err != nil || parallelCount > maxParallelCount || parallelCount < minParallelCount {
-			log.Warningf("The config parallelNum %d is invalid, set it to the default value %d", parallelCount, defaultParallelCount)
-			parallelCount = defaultParallelCount
-		}
-	} else {
-		parallelCount = defaultParallelCount
-	}
-
-	log.Infof("Init parallel count is %d", parallelCount)
-	clientSemaphore = utils.NewSemaphore(parallelCount)
+func NewClient(url, user, secretName, secretNamespace, parallelNum, backendID, accountName string,
+	tlsConfig *tls.Config) *Client {
+	parallelCount := getParallelCount(parallelNum)
+	log.Infof("Init parallel count of backend %s is %d", backendID, parallelCount)
 	return &Client{
 		url:             url,
 		user:            user,
@@ -125,9 +120,45 @@ func NewClient(url, user, secretName, secretNamespace, parallelNum, backendID, a
 		secretNamespace: secretNamespace,
 		backendID:       backendID,
 		accountName:     accountName,
+		tlsConfig:       tlsConfig,
+		semaphore:       utils.NewBackendSemaphore(backendID, parallelCount),
 	}
 }
 
+// getParallelCount used to parse the max concurrent requests to the storage, the default value is used if
+// the parallelNum is empty or invalid
+func getParallelCount(parallelNum string) int {
+	if len(parallelNum) == 0 {
+		return defaultParallelCount
+	}
+
+	parallelCount, err := strconv.Atoi(parallelNum)
+	if err != nil || parallelCount > maxParallelCount || parallelCount < minParallelCount {
+		log.Warningf("The config parallelNum %s is invalid, set it to the default value %d",
+			parallelNum, defaultParallelCount)
+		return defaultParallelCount
+	}
+
+	return parallelCount
+}
+
+// GetSemaphoreStats used to get the in-flight and waiting requests to the storage of the backend
+func (cli *Client) GetSemaphoreStats() utils.SemaphoreStats {
+	return cli.semaphore.Stats()
+}
+
+// CheckHealth used to probe the management url of the storage
+func (cli *Client) CheckHealth(ctx context.Context) []utils.URLHealth {
+	var httpClient utils.HTTPClient = cli.client
+	if cli.client == nil {
+		httpClient = &http.Client{
+			Transport: &http.Transport{TLSClientConfig: cli.tlsConfig, DisableKeepAlives: true},
+		}
+	}
+
+	return []utils.URLHealth{utils.ProbeURL(ctx, httpClient, cli.url)}
+}
+
 func (cli *Client) DuplicateClient() *Client {
 	dup := *cli
 	dup.client = nil
@@ -139,7 +170,7 @@ func (cli *Client) ValidateLogin(ctx context.Context) error {
 	jar, _ := cookiejar.New(nil)
 	cli.client = &http.Client{
 		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			TLSClientConfig: cli.tlsConfig,
 		},
 		Jar:     jar,
 		Timeout: 60 * time.Second,
@@ -175,7 +206,7 @@ func (cli *Client) Login(ctx context.Context) error {
 	jar, _ := cookiejar.New(nil)
 	cli.client = &http.Client{
 		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			TLSClientConfig: cli.tlsConfig,
 		},
 		Jar:     jar,
 		Timeout: 60 * time.Second,
@@ -194,7 +225,10 @@ func (cli *Client) Login(ctx context.Context) error {
 	}
 
 	respHeader, resp, err := cli.baseCall(ctx, "POST", "/dsware/service/v1.3/sec/login", data)
-	if err != nil {
+	if err != nil && err.Error() == "unconnected" {
+		return pkgUtils.NewBackendError(xuanwuv1.ConditionReachable, xuanwuv1.ReasonUnreachable,
+			fmt.Errorf("the url %s of the storage is unreachable", cli.url))
+	} else if err != nil {
 		return err
 	}
 
@@ -203,19 +237,23 @@ func (cli *Client) Login(ctx context.Context) error {
 		msg := fmt.Sprintf("Login %s error: %+v", cli.url, resp)
 		errorCode, ok := resp["errorCode"].(float64)
 		if !ok {
-			return errors.New(msg)
+			return pkgUtils.NewBackendError(xuanwuv1.ConditionAuthenticated, xuanwuv1.ReasonLoginFailed,
+				errors.New(msg))
 		}
 
 		// If the password is incorrect, set sbct to offline.
 		code := int64(errorCode)
+		loginErr := pkgUtils.NewBackendError(xuanwuv1.ConditionAuthenticated, getLoginFailedReason(code),
+			errors.New(msg))
 		if code == loginFailed || code == loginFailedWithArg || code == userPasswordInvalid || code == IPLock {
-			setErr := pkgUtils.SetStorageBackendContentOnlineStatus(ctx, cli.backendID, false)
+			setErr := pkgUtils.SetStorageBackendContentOffline(ctx, cli.backendID, loginErr)
 			if setErr != nil {
-				msg = msg + fmt.Sprintf("\nSetStorageBackendContentOffline [%s] failed. error: %v", cli.backendID, setErr)
+				log.AddContext(ctx).Errorf("SetStorageBackendContentOffline [%s] failed. error: %v",
+					cli.backendID, setErr)
 			}
 		}
 
-		return errors.New(msg)
+		return loginErr
 	}
 
 	if respHeader["X-Auth-Token"] == nil || len(respHeader["X-Auth-Token"]) == 0 {
@@ -234,6 +272,18 @@ func (cli *Client) Login(ctx context.Context) error {
 	return nil
 }
 
+// getLoginFailedReason used to get the reason of the Authenticated condition by the error code of the login
+func getLoginFailedReason(code int64) string {
+	switch code {
+	case loginFailed, loginFailedWithArg, userPasswordInvalid:
+		return xuanwuv1.ReasonWrongPassword
+	case IPLock:
+		return xuanwuv1.ReasonIPLocked
+	default:
+		return xuanwuv1.ReasonLoginFailed
+	}
+}
+
 func (cli *Client) setAccountId(ctx context.Context) error {
 	if cli.accountName == "" {
 		cli.accountName = types.DefaultAccountName
@@ -330,12 +380,22 @@ func (cli *Client) doCall(ctx context.Context,
 	log.FilteredLog(ctx, isFilterLog(method, url), utils.IsDebugLog(method, url, debugLog),
 		fmt.Sprintf("Request method: %s, url: %s, body: %v", method, reqUrl, data))
 
-	clientSemaphore.Acquire()
-	defer clientSemaphore.Release()
+	if cli.semaphore.AvailablePermits() == 0 {
+		stats := cli.semaphore.Stats()
+		log.AddContext(ctx).Infof("Requests to backend %s are saturated, permits: %d, in-flight: %d, waiting: %d",
+			cli.backendID, stats.Permits, stats.InFlight, stats.Waiting)
+	}
+	cli.semaphore.Acquire()
+	defer cli.semaphore.Release()
 
+	start := time.Now()
 	resp, err := cli.client.Do(req)
+	metrics.ObserveStorageRequest(cli.backendID, method, start, err)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Send request method: %s, url: %s, error: %v", method, reqUrl, err)
+		if pkgUtils.IsCertVerifyError(err) {
+			return nil, nil, pkgUtils.NewCertVerifyError(cli.url, err)
+		}
 		return nil, nil, errors.New("unconnected")
 	}
 
@@ -441,6 +501,7 @@ func (cli *Client) reLogin(ctx context.Context) error {
 	}
 
 	err := cli.Login(ctx)
+	metrics.IncStorageReLogin(cli.backendID, err)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Try to relogin error: %v", err)
 		return err
@@ -0,0 +1,229 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	fusionURL "net/url"
+
+	"huawei-csi-driver/utils/log"
+)
+
+const (
+	fsSnapshotNotExist int64 = 33564708
+
+	listFSSnapshotPageSize int = 100
+)
+
+// getResultCode used for get the error code from the result of the response
+func getResultCode(ctx context.Context, resp map[string]interface{}) (int64, error) {
+	result, ok := resp["result"].(map[string]interface{})
+	if !ok {
+		msg := fmt.Sprintf("The result of response %v's format is not map[string]interface{}", resp)
+		log.AddContext(ctx).Errorln(msg)
+		return 0, errors.New(msg)
+	}
+
+	code, ok := result["code"].(float64)
+	if !ok {
+		msg := fmt.Sprintf("The code of result %v's format is not float64", result)
+		log.AddContext(ctx).Errorln(msg)
+		return 0, errors.New(msg)
+	}
+
+	return int64(code), nil
+}
+
+// CreateFSSnapshot used for create the snapshot of the filesystem
+func (cli *Client) CreateFSSnapshot(ctx context.Context, name, fsID string) error {
+	data := map[string]interface{}{
+		"name":         name,
+		"namespace_id": fsID,
+	}
+
+	resp, err := cli.post(ctx, "/api/v2/file_service/snapshots", data)
+	if err != nil {
+		return err
+	}
+
+	errorCode, err := getResultCode(ctx, resp)
+	if err != nil {
+		return err
+	}
+	if errorCode != 0 {
+		msg := fmt.Sprintf("Create snapshot %s of filesystem %s error: %d", name, fsID, errorCode)
+		log.AddContext(ctx).Errorln(msg)
+		return errors.New(msg)
+	}
+
+	return nil
+}
+
+// DeleteFSSnapshot used for delete the snapshot of the filesystem, success if the snapshot does not exist
+func (cli *Client) DeleteFSSnapshot(ctx context.Context, name, fsID string) error {
+	url := fmt.Sprintf("/api/v2/file_service/snapshots?namespace_id=%s&name=%s", fsID, name)
+	resp, err := cli.delete(ctx, url, nil)
+	if err != nil {
+		return err
+	}
+
+	errorCode, err := getResultCode(ctx, resp)
+	if err != nil {
+		return err
+	}
+	if errorCode == fsSnapshotNotExist {
+		log.AddContext(ctx).Warningf("Snapshot %s of filesystem %s doesn't exist while deleting.", name, fsID)
+		return nil
+	}
+	if errorCode != 0 {
+		msg := fmt.Sprintf("Delete snapshot %s of filesystem %s error: %d", name, fsID, errorCode)
+		log.AddContext(ctx).Errorln(msg)
+		return errors.New(msg)
+	}
+
+	return nil
+}
+
+// GetFSSnapshotByName used for get the snapshot of the filesystem by name, return nil if it does not exist
+func (cli *Client) GetFSSnapshotByName(ctx context.Context, fsID, name string) (map[string]interface{}, error) {
+	url := fmt.Sprintf("/api/v2/file_service/snapshots?namespace_id=%s&name=%s", fsID, name)
+	resp, err := cli.get(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	errorCode, err := getResultCode(ctx, resp)
+	if err != nil {
+		return nil, err
+	}
+	if errorCode == fsSnapshotNotExist || errorCode == fileSystemNotExist {
+		return nil, nil
+	}
+	if errorCode != 0 {
+		return nil, fmt.Errorf("get snapshot %s of filesystem %s error: %d", name, fsID, errorCode)
+	}
+
+	snapshot, ok := resp["data"].(map[string]interface{})
+	if !ok || len(snapshot) == 0 {
+		return nil, nil
+	}
+
+	return snapshot, nil
+}
+
+// GetFSSnapshotsByFsID used for get all snapshots of the filesystem batch by batch
+func (cli *Client) GetFSSnapshotsByFsID(ctx context.Context, fsID string) ([]map[string]interface{}, error) {
+	var snapshots []map[string]interface{}
+	for offset := 0; ; offset += listFSSnapshotPageSize {
+		bytesRange, err := json.Marshal(map[string]int{"offset": offset, "limit": listFSSnapshotPageSize})
+		if err != nil {
+			return nil, err
+		}
+
+		url := fmt.Sprintf("/api/v2/file_service/snapshots?namespace_id=%s&range=%s",
+			fsID, fusionURL.QueryEscape(string(bytesRange)))
+		resp, err := cli.get(ctx, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		errorCode, err := getResultCode(ctx, resp)
+		if err != nil {
+			return nil, err
+		}
+		if errorCode != 0 {
+			return nil, fmt.Errorf("get snapshots of filesystem %s error: %d", fsID, errorCode)
+		}
+
+		respData, ok := resp["data"].([]interface{})
+		if !ok {
+			break
+		}
+
+		for _, d := range respData {
+			if snapshot, ok := d.(map[string]interface{}); ok {
+				snapshots = append(snapshots, snapshot)
+			}
+		}
+
+		if len(respData) < listFSSnapshotPageSize {
+			break
+		}
+	}
+
+	return snapshots, nil
+}
+
+// CloneFileSystem used for create a clone filesystem from the snapshot of the parent filesystem
+func (cli *Client) CloneFileSystem(ctx context.Context, name, parentFsID, parentSnapshotName,
+	accountID string) (map[string]interface{}, error) {
+	data := map[string]interface{}{
+		"name":                 name,
+		"parent_namespace_id":  parentFsID,
+		"parent_snapshot_name": parentSnapshotName,
+		"account_id":           accountID,
+	}
+
+	resp, err := cli.post(ctx, "/api/v2/file_service/clone_namespace", data)
+	if err != nil {
+		return nil, err
+	}
+
+	errorCode, err := getResultCode(ctx, resp)
+	if err != nil {
+		return nil, err
+	}
+	if errorCode != 0 {
+		msg := fmt.Sprintf("Clone filesystem %v error: %d", data, errorCode)
+		log.AddContext(ctx).Errorln(msg)
+		return nil, errors.New(msg)
+	}
+
+	respData, ok := resp["data"].(map[string]interface{})
+	if !ok || respData == nil {
+		return nil, fmt.Errorf("failed to clone filesystem %v", data)
+	}
+
+	return respData, nil
+}
+
+// SplitCloneFileSystem used for split the clone filesystem from its parent snapshot
+func (cli *Client) SplitCloneFileSystem(ctx context.Context, fsID string) error {
+	data := map[string]interface{}{
+		"namespace_id": fsID,
+	}
+
+	resp, err := cli.put(ctx, "/api/v2/file_service/clone_namespace/split", data)
+	if err != nil {
+		return err
+	}
+
+	errorCode, err := getResultCode(ctx, resp)
+	if err != nil {
+		return err
+	}
+	if errorCode != 0 {
+		msg := fmt.Sprintf("Split clone filesystem %s error: %d", fsID, errorCode)
+		log.AddContext(ctx).Errorln(msg)
+		return errors.New(msg)
+	}
+
+	return nil
+}
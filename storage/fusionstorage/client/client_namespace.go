@@ -31,6 +31,8 @@ const (
 	clientAlreadyExist int64 = 1077939727
 	fileSystemNotExist int64 = 33564678
 	notForbidden       int   = 0
+
+	listFileSystemPageSize int = 100
 )
 
 func (cli *Client) CreateFileSystem(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
@@ -140,6 +142,54 @@ func (cli *Client) GetFileSystemByName(ctx context.Context, name string) (map[st
 	return nil, nil
 }
 
+// GetFileSystems used for get all filesystems batch by batch
+func (cli *Client) GetFileSystems(ctx context.Context) ([]map[string]interface{}, error) {
+	var fileSystems []map[string]interface{}
+	for offset := 0; ; offset += listFileSystemPageSize {
+		bytesRange, err := json.Marshal(map[string]int{"offset": offset, "limit": listFileSystemPageSize})
+		if err != nil {
+			return nil, err
+		}
+
+		url := fmt.Sprintf("/api/v2/converged_service/namespaces?range=%s", fusionURL.QueryEscape(string(bytesRange)))
+		resp, err := cli.get(ctx, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		result, ok := resp["result"].(map[string]interface{})
+		if !ok {
+			msg := fmt.Sprintf("The result of response %v's format is not map[string]interface{}", resp)
+			log.AddContext(ctx).Errorln(msg)
+			return nil, errors.New(msg)
+		}
+
+		errorCode := int64(result["code"].(float64))
+		if errorCode != 0 {
+			msg := fmt.Sprintf("Get filesystems error: %d", errorCode)
+			log.AddContext(ctx).Errorln(msg)
+			return nil, errors.New(msg)
+		}
+
+		respData, ok := resp["data"].([]interface{})
+		if !ok {
+			break
+		}
+
+		for _, d := range respData {
+			if fs, ok := d.(map[string]interface{}); ok {
+				fileSystems = append(fileSystems, fs)
+			}
+		}
+
+		if len(respData) < listFileSystemPageSize {
+			break
+		}
+	}
+
+	return fileSystems, nil
+}
+
 func (cli *Client) CreateNfsShare(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
 	data := map[string]interface{}{
 		"share_path":     params["sharepath"].(string),
@@ -38,7 +38,7 @@ func TestMain(m *testing.M) {
 	defer log.MockStopLogging(logName)
 
 	testClient = NewClient("https://192.168.125.*:8088", "dev-account", "mock-sec-name",
-		"mock-sec-namespace", "50", "mock-id", "mock-accountName")
+		"mock-sec-namespace", "50", "mock-id", "mock-accountName", nil)
 
 	m.Run()
 }
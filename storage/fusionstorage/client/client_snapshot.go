@@ -25,6 +25,8 @@ import (
 
 const (
 	snapshotNotExist int64 = 50150006
+
+	listSnapshotPageSize int = 100
 )
 
 func (cli *Client) CreateSnapshot(ctx context.Context, snapshotName, volName string) error {
@@ -90,6 +92,46 @@ func (cli *Client) GetSnapshotByName(ctx context.Context, snapshotName string) (
 	return snapshot, nil
 }
 
+// GetSnapshotsByVolumeName used for get all snapshots of the volume page by page
+func (cli *Client) GetSnapshotsByVolumeName(ctx context.Context, volName string) ([]map[string]interface{}, error) {
+	var snapshots []map[string]interface{}
+	for pageNum := 1; ; pageNum++ {
+		data := map[string]interface{}{
+			"volName":  volName,
+			"pageNum":  pageNum,
+			"pageSize": listSnapshotPageSize,
+		}
+
+		resp, err := cli.post(ctx, "/dsware/service/v1.3/snapshot/list", data)
+		if err != nil {
+			return nil, err
+		}
+
+		result := int64(resp["result"].(float64))
+		if result != 0 {
+			errorCode, _ := resp["errorCode"].(float64)
+			return nil, fmt.Errorf("get snapshots of volume %s error: %d", volName, int64(errorCode))
+		}
+
+		snapshotList, ok := resp["snapshotList"].([]interface{})
+		if !ok {
+			break
+		}
+
+		for _, s := range snapshotList {
+			if snapshot, ok := s.(map[string]interface{}); ok {
+				snapshots = append(snapshots, snapshot)
+			}
+		}
+
+		if len(snapshotList) < listSnapshotPageSize {
+			break
+		}
+	}
+
+	return snapshots, nil
+}
+
 func (cli *Client) CreateVolumeFromSnapshot(ctx context.Context,
 	volName string,
 	volSize int64,
@@ -29,6 +29,8 @@ const (
 	volumeNameNotExist   int64 = 50150005
 	deleteVolumeNotExist int64 = 32150005
 	queryVolumeNotExist  int64 = 31000000
+
+	listVolumePageSize int = 100
 )
 
 func (cli *Client) CreateVolume(ctx context.Context, params map[string]interface{}) error {
@@ -84,6 +86,46 @@ func (cli *Client) GetVolumeByName(ctx context.Context, name string) (map[string
 	return lun, nil
 }
 
+// GetVolumesByPoolId used for get all volumes of the pool page by page
+func (cli *Client) GetVolumesByPoolId(ctx context.Context, poolId int64) ([]map[string]interface{}, error) {
+	var volumes []map[string]interface{}
+	for pageNum := 1; ; pageNum++ {
+		data := map[string]interface{}{
+			"poolId":   poolId,
+			"pageNum":  pageNum,
+			"pageSize": listVolumePageSize,
+		}
+
+		resp, err := cli.post(ctx, "/dsware/service/v1.3/volume/list", data)
+		if err != nil {
+			return nil, err
+		}
+
+		result := int64(resp["result"].(float64))
+		if result != 0 {
+			errorCode, _ := resp["errorCode"].(float64)
+			return nil, fmt.Errorf("Get volumes of pool %d error: %d", poolId, int64(errorCode))
+		}
+
+		volumeList, ok := resp["volumeList"].([]interface{})
+		if !ok {
+			break
+		}
+
+		for _, v := range volumeList {
+			if volume, ok := v.(map[string]interface{}); ok {
+				volumes = append(volumes, volume)
+			}
+		}
+
+		if len(volumeList) < listVolumePageSize {
+			break
+		}
+	}
+
+	return volumes, nil
+}
+
 func (cli *Client) DeleteVolume(ctx context.Context, name string) error {
 	data := map[string]interface{}{
 		"volNames": []string{name},
@@ -143,3 +143,77 @@ func (p *QoS) RemoveQoS(ctx context.Context, volName string) error {
 
 	return nil
 }
+
+// ReplaceQoS used to replace the QoS of the volume with a new QoS of the params, or just remove it if the params
+// is empty. The new QoS is created before the old one is disassociated, and the old one is associated again if
+// the new one fails to be associated, so the volume is never left without any QoS on failure.
+func (p *QoS) ReplaceQoS(ctx context.Context, volName string, params map[string]int) (string, error) {
+	oldQoSName, err := p.cli.GetQoSNameByVolume(ctx, volName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get QoS of volume %s error: %v", volName, err)
+		return "", err
+	}
+
+	var newQoSName string
+	if len(params) != 0 {
+		newQoSName = ConstructQosNameByCurrentTime("volume")
+		if err = p.cli.CreateQoS(ctx, newQoSName, params); err != nil {
+			log.AddContext(ctx).Errorf("Create qos %v error: %v", params, err)
+			return "", err
+		}
+	}
+
+	if oldQoSName != "" {
+		if err = p.cli.DisassociateQoSWithVolume(ctx, volName, oldQoSName); err != nil {
+			p.deleteUnusedQoS(ctx, newQoSName)
+			return "", fmt.Errorf("disassociate qos %s of volume %s error: %v", oldQoSName, volName, err)
+		}
+	}
+
+	if newQoSName != "" {
+		if err = p.cli.AssociateQoSWithVolume(ctx, volName, newQoSName); err != nil {
+			p.restoreQoS(ctx, volName, oldQoSName)
+			p.deleteUnusedQoS(ctx, newQoSName)
+			return "", fmt.Errorf("associate qos %s with volume %s error: %v", newQoSName, volName, err)
+		}
+	}
+
+	p.deleteUnusedQoS(ctx, oldQoSName)
+	return newQoSName, nil
+}
+
+// restoreQoS used to associate the old QoS with the volume again, the failure is only logged since the error of
+// the replacement is returned anyway
+func (p *QoS) restoreQoS(ctx context.Context, volName, qosName string) {
+	if qosName == "" {
+		return
+	}
+
+	if err := p.cli.AssociateQoSWithVolume(ctx, volName, qosName); err != nil {
+		log.AddContext(ctx).Errorf("Restore qos %s of volume %s error: %v. Please associate it manually",
+			qosName, volName, err)
+	}
+}
+
+// deleteUnusedQoS used to delete the QoS if it is associated with nothing, the failure is only logged since the
+// QoS of the volume is already settled
+func (p *QoS) deleteUnusedQoS(ctx context.Context, qosName string) {
+	if qosName == "" {
+		return
+	}
+
+	count, err := p.cli.GetAssociateCountOfQoS(ctx, qosName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get Objs of QoS %s error: %v", qosName, err)
+		return
+	}
+	if count != 0 {
+		log.AddContext(ctx).Warningf("The Qos %s associate objs count %d. Please delete QoS manually",
+			qosName, count)
+		return
+	}
+
+	if err = p.cli.DeleteQoS(ctx, qosName); err != nil {
+		log.AddContext(ctx).Errorf("Delete QoS %s error: %v. Please delete QoS manually", qosName, err)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package smartx
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+
+	"huawei-csi-driver/storage/fusionstorage/client"
+	"huawei-csi-driver/utils/log"
+)
+
+const (
+	logName = "smartxTest.log"
+)
+
+func TestMain(m *testing.M) {
+	log.MockInitLogging(logName)
+	defer log.MockStopLogging(logName)
+
+	m.Run()
+}
+
+// fakeQoSStorage records the QoS associated with the volume and the existing QoS
+type fakeQoSStorage struct {
+	associated   string
+	created      map[string]bool
+	associateErr error
+}
+
+func (f *fakeQoSStorage) patch(cli *client.Client) *gomonkey.Patches {
+	patches := gomonkey.ApplyMethod(reflect.TypeOf(cli), "GetQoSNameByVolume",
+		func(_ *client.Client, _ context.Context, _ string) (string, error) {
+			return f.associated, nil
+		})
+	patches.ApplyMethod(reflect.TypeOf(cli), "CreateQoS",
+		func(_ *client.Client, _ context.Context, qosName string, _ map[string]int) error {
+			f.created[qosName] = true
+			return nil
+		})
+	patches.ApplyMethod(reflect.TypeOf(cli), "DisassociateQoSWithVolume",
+		func(_ *client.Client, _ context.Context, _, _ string) error {
+			f.associated = ""
+			return nil
+		})
+	patches.ApplyMethod(reflect.TypeOf(cli), "AssociateQoSWithVolume",
+		func(_ *client.Client, _ context.Context, _, qosName string) error {
+			if f.associateErr != nil && qosName != "old" {
+				return f.associateErr
+			}
+			f.associated = qosName
+			return nil
+		})
+	patches.ApplyMethod(reflect.TypeOf(cli), "GetAssociateCountOfQoS",
+		func(_ *client.Client, _ context.Context, qosName string) (int, error) {
+			if f.associated == qosName {
+				return 1, nil
+			}
+			return 0, nil
+		})
+	patches.ApplyMethod(reflect.TypeOf(cli), "DeleteQoS",
+		func(_ *client.Client, _ context.Context, qosName string) error {
+			delete(f.created, qosName)
+			return nil
+		})
+	return patches
+}
+
+func TestReplaceQoS(t *testing.T) {
+	cases := []struct {
+		name           string
+		params         map[string]int
+		associateErr   error
+		wantErr        bool
+		wantAssociated string
+	}{
+		{"Replace", map[string]int{"maxIOPS": 1000}, nil, false, "k8s_volume_"},
+		{"Remove", nil, nil, false, ""},
+		{"AssociateFailed", map[string]int{"maxIOPS": 1000}, errors.New("associate error"), true, "old"},
+	}
+
+	cli := &client.Client{}
+	for _, c := range cases {
+		storage := &fakeQoSStorage{associated: "old", created: map[string]bool{"old": true},
+			associateErr: c.associateErr}
+		patches := storage.patch(cli)
+
+		_, err := NewQoS(cli).ReplaceQoS(context.Background(), "vol", c.params)
+		if (err != nil) != c.wantErr {
+			t.Errorf("Test case %s failed, error: %v, wantErr: %v", c.name, err, c.wantErr)
+		}
+		if !strings.HasPrefix(storage.associated, c.wantAssociated) ||
+			(c.wantAssociated == "") != (storage.associated == "") {
+			t.Errorf("Test case %s failed, associated: %s, want: %s", c.name, storage.associated, c.wantAssociated)
+		}
+		// only the associated qos is left
+		delete(storage.created, storage.associated)
+		if len(storage.created) != 0 {
+			t.Errorf("Test case %s failed, unused qos left: %v", c.name, storage.created)
+		}
+		patches.Reset()
+	}
+}
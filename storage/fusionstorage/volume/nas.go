@@ -256,8 +256,12 @@ func (p *NAS) preCreate(ctx context.Context, params map[string]interface{}) erro
 		return err
 	}
 
-	if v, exist := params["clonefrom"].(string); exist {
-		params["clonefrom"] = v
+	if v, exist := params["sourcevolumename"].(string); exist && v != "" {
+		params["clonefrom"] = utils.GetFileSystemName(v)
+	} else if v, exist := params["sourcesnapshotname"].(string); exist && v != "" {
+		params["fromSnapshot"] = utils.GetFSSnapshotName(v)
+	} else if v, exist := params["clonefrom"].(string); exist && v != "" {
+		params["clonefrom"] = utils.GetFileSystemName(v)
 	}
 
 	if err := p.preProcessQuota(ctx, params); err != nil {
@@ -327,10 +331,14 @@ func (p *NAS) createFS(ctx context.Context,
 		return nil, err
 	}
 
+	var isClone bool
 	if fs == nil {
-		_, exist := params["clonefrom"]
-		if exist {
-			fs, err = p.clone(params)
+		if _, exist := params["clonefrom"]; exist {
+			fs, err = p.clone(ctx, params)
+			isClone = true
+		} else if _, exist := params["fromSnapshot"]; exist {
+			fs, err = p.createFromSnapshot(ctx, params)
+			isClone = true
 		} else {
 			fs, err = p.cli.CreateFileSystem(ctx, params)
 		}
@@ -347,13 +355,134 @@ func (p *NAS) createFS(ctx context.Context,
 	}
 
 	return map[string]interface{}{
-		"fsID":   strconv.FormatInt(int64(fs["id"].(float64)), 10),
-		"fsName": fsName,
+		"fsID":    strconv.FormatInt(int64(fs["id"].(float64)), 10),
+		"fsName":  fsName,
+		"isClone": isClone,
 	}, nil
 }
 
-func (p *NAS) clone(params map[string]interface{}) (map[string]interface{}, error) {
-	return nil, fmt.Errorf("unimplemented")
+// clone used for clone the filesystem from a temporary snapshot of the source filesystem,
+// the temporary snapshot is deleted after the clone filesystem is split
+func (p *NAS) clone(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+	cloneFrom, _ := params["clonefrom"].(string)
+	srcFS, err := p.cli.GetFileSystemByName(ctx, cloneFrom)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get clone src filesystem %s error: %v", cloneFrom, err)
+		return nil, err
+	}
+	if srcFS == nil {
+		return nil, pkgUtils.Errorln(ctx, fmt.Sprintf("Clone src filesystem %s does not exist", cloneFrom))
+	}
+
+	err = p.checkCloneCapacity(ctx, cloneFrom, params)
+	if err != nil {
+		return nil, err
+	}
+
+	srcFSID := strconv.FormatInt(int64(srcFS["id"].(float64)), 10)
+	snapshotName := fmt.Sprintf("k8s_fs_%s_snap_%d", cloneFrom, utils.RandomInt(10000000000))
+	err = p.cli.CreateFSSnapshot(ctx, snapshotName, srcFSID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Create snapshot %s of filesystem %s error: %v", snapshotName, cloneFrom, err)
+		return nil, err
+	}
+
+	defer func() {
+		err := p.cli.DeleteFSSnapshot(ctx, snapshotName, srcFSID)
+		if err != nil {
+			log.AddContext(ctx).Warningf("Delete temporary snapshot %s of filesystem %s error: %v",
+				snapshotName, cloneFrom, err)
+		}
+	}()
+
+	return p.cloneFilesystem(ctx, params, srcFSID, snapshotName)
+}
+
+// createFromSnapshot used for clone the filesystem from the snapshot, the clone filesystem is split
+// so that the snapshot can be deleted independently
+func (p *NAS) createFromSnapshot(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+	srcSnapshotName, _ := params["fromSnapshot"].(string)
+	snapshotParentID, _ := params["snapshotparentid"].(string)
+	srcSnapshot, err := p.cli.GetFSSnapshotByName(ctx, snapshotParentID, srcSnapshotName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get src filesystem snapshot %s error: %v", srcSnapshotName, err)
+		return nil, err
+	}
+	if srcSnapshot == nil {
+		return nil, pkgUtils.Errorln(ctx, fmt.Sprintf("Src snapshot %s of filesystem %s does not exist",
+			srcSnapshotName, snapshotParentID))
+	}
+
+	parentName, _ := srcSnapshot["namespace_name"].(string)
+	err = p.checkCloneCapacity(ctx, parentName, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.cloneFilesystem(ctx, params, snapshotParentID, srcSnapshotName)
+}
+
+func (p *NAS) checkCloneCapacity(ctx context.Context, srcFSName string, params map[string]interface{}) error {
+	srcVol, err := p.Query(ctx, srcFSName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get capacity of clone src filesystem %s error: %v", srcFSName, err)
+		return err
+	}
+
+	srcCapacity, err := srcVol.GetSize()
+	if err != nil {
+		return err
+	}
+
+	// the capacity of the params is in KiB
+	cloneCapacity, _ := params["capacity"].(int64)
+	if cloneCapacity*1024 < srcCapacity {
+		return pkgUtils.Errorln(ctx, fmt.Sprintf("Clone filesystem capacity must be >= src %s", srcFSName))
+	}
+
+	return nil
+}
+
+func (p *NAS) cloneFilesystem(ctx context.Context, params map[string]interface{},
+	parentFSID, parentSnapshotName string) (map[string]interface{}, error) {
+	fsName, _ := params["name"].(string)
+	accountID, _ := params["accountid"].(string)
+	cloneFS, err := p.cli.CloneFileSystem(ctx, fsName, parentFSID, parentSnapshotName, accountID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Clone filesystem %s from snapshot %s error: %v",
+			fsName, parentSnapshotName, err)
+		return nil, err
+	}
+
+	cloneFSID := strconv.FormatInt(int64(cloneFS["id"].(float64)), 10)
+	err = p.cli.SplitCloneFileSystem(ctx, cloneFSID)
+	if err == nil {
+		err = p.waitFSSplitDone(ctx, fsName)
+	}
+	if err != nil {
+		log.AddContext(ctx).Errorf("Split clone filesystem %s error: %v", fsName, err)
+		if delErr := p.deleteFS(ctx, cloneFSID); delErr != nil {
+			log.AddContext(ctx).Errorf("Delete clone filesystem %s error: %v", cloneFSID, delErr)
+		}
+		return nil, err
+	}
+
+	return cloneFS, nil
+}
+
+func (p *NAS) waitFSSplitDone(ctx context.Context, fsName string) error {
+	return utils.WaitUntil(func() (bool, error) {
+		fs, err := p.cli.GetFileSystemByName(ctx, fsName)
+		if err != nil {
+			return false, err
+		}
+		if fs == nil {
+			return false, fmt.Errorf("clone filesystem %s does not exist", fsName)
+		}
+
+		isClone, _ := fs["is_clone"].(bool)
+		return !isClone, nil
+	}, time.Hour*6, time.Second*5)
 }
 
 func (p *NAS) revertFS(ctx context.Context, taskResult map[string]interface{}) error {
@@ -466,11 +595,42 @@ func (p *NAS) createQuota(ctx context.Context,
 			log.AddContext(ctx).Errorf("Create filesystem quota %v error: %v", quotaParams, err)
 			return nil, err
 		}
+	} else if isClone, _ := taskResult["isClone"].(bool); isClone {
+		// the clone filesystem inherits the quota of its source, update it to the requested capacity
+		err := p.updateCloneQuota(ctx, quota, params)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Update quota of clone filesystem %s error: %v", fsID, err)
+			return nil, err
+		}
 	}
 
 	return nil, nil
 }
 
+func (p *NAS) updateCloneQuota(ctx context.Context, quota, params map[string]interface{}) error {
+	quotaID, ok := quota["id"].(string)
+	if !ok {
+		return pkgUtils.Errorln(ctx, fmt.Sprintf("Quota %v does not contain id field.", quota))
+	}
+
+	capacity, ok := params["capacity"].(int64)
+	if !ok {
+		return utils.Errorf(ctx, "The params %v does not contain capacity.", params)
+	}
+
+	quotaParams := map[string]interface{}{
+		"id":              quotaID,
+		"space_unit_type": spaceQuotaUnitKB,
+	}
+	if v, exist := params["spaceQuota"].(string); exist && v == "softQuota" {
+		quotaParams["space_soft_quota"] = capacity
+	} else {
+		quotaParams["space_hard_quota"] = capacity
+	}
+
+	return p.cli.UpdateQuota(ctx, quotaParams)
+}
+
 func (p *NAS) revertConvergedQoS(ctx context.Context, taskResult map[string]interface{}) error {
 	fsName, exist := taskResult["fsName"].(string)
 	if !exist {
@@ -661,6 +821,81 @@ func (p *NAS) Query(ctx context.Context, fsName string) (utils.Volume, error) {
 	return p.setSize(ctx, fsName, quota)
 }
 
+// List used for get all filesystems with the given name prefix which belong to the given pools
+func (p *NAS) List(ctx context.Context, prefix string, pools []string) ([]utils.Volume, error) {
+	var poolIds []string
+	for _, poolName := range pools {
+		pool, err := p.cli.GetPoolByName(ctx, poolName)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Get storage pool %s error: %v", poolName, err)
+			return nil, err
+		}
+		if pool != nil {
+			poolIds = append(poolIds, strconv.FormatInt(int64(pool["poolId"].(float64)), 10))
+		}
+	}
+
+	fileSystems, err := p.cli.GetFileSystems(ctx)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystems error: %v", err)
+		return nil, err
+	}
+
+	fsPrefix := utils.GetFileSystemName(prefix)
+	var volumes []utils.Volume
+	for _, fs := range fileSystems {
+		fsName, ok := fs["name"].(string)
+		if !ok || !strings.HasPrefix(fsName, fsPrefix) {
+			continue
+		}
+
+		poolId, ok := fs["storage_pool_id"].(float64)
+		if !ok || !utils.IsContain(strconv.FormatInt(int64(poolId), 10), poolIds) {
+			continue
+		}
+
+		fsId, ok := fs["id"].(float64)
+		if !ok {
+			continue
+		}
+
+		volObj := utils.NewVolume(fsName)
+		quota, err := p.cli.GetQuotaByFileSystemById(ctx, strconv.FormatInt(int64(fsId), 10))
+		if err == nil && quota != nil {
+			if sizedVol, err := p.setSize(ctx, fsName, quota); err == nil {
+				volObj = sizedVol
+			}
+		} else {
+			log.AddContext(ctx).Warningf("Get quota of filesystem %s failed, error: %v", fsName, err)
+		}
+		volumes = append(volumes, volObj)
+	}
+
+	return volumes, nil
+}
+
+// GetCondition used for get whether the filesystem is abnormal, return nil if the filesystem does not exist
+func (p *NAS) GetCondition(ctx context.Context, fsName string) (map[string]interface{}, error) {
+	fs, err := p.cli.GetFileSystemByName(ctx, fsName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystem %s error: %v", fsName, err)
+		return nil, err
+	}
+	if fs == nil {
+		return nil, nil
+	}
+
+	var message string
+	if status, ok := fs["running_status"].(float64); ok && status != 0 { // 0 means the filesystem is ok
+		message = fmt.Sprintf("filesystem %s is abnormal, running status: %v", fsName, status)
+	}
+
+	return map[string]interface{}{
+		"Abnormal": message != "",
+		"Message":  message,
+	}, nil
+}
+
 func (p *NAS) setSize(ctx context.Context, fsName string, quota map[string]interface{}) (utils.Volume, error) {
 	volObj := utils.NewVolume(fsName)
 	var capacity int64
@@ -785,3 +1020,165 @@ func (p *NAS) Expand(ctx context.Context, fsName string, newSize int64) error {
 	}
 	return nil
 }
+
+// CreateSnapshot used for create the snapshot of the filesystem, return the existing one if it has been created
+func (p *NAS) CreateSnapshot(ctx context.Context, fsName, snapshotName string) (map[string]interface{}, error) {
+	fs, err := p.cli.GetFileSystemByName(ctx, fsName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystem by name %s error: %v", fsName, err)
+		return nil, err
+	}
+	if fs == nil {
+		return nil, pkgUtils.Errorln(ctx, fmt.Sprintf("Filesystem %s to create snapshot does not exist", fsName))
+	}
+
+	fsID := strconv.FormatInt(int64(fs["id"].(float64)), 10)
+	snapshot, err := p.cli.GetFSSnapshotByName(ctx, fsID, snapshotName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystem snapshot by name %s error: %v", snapshotName, err)
+		return nil, err
+	}
+
+	if snapshot != nil {
+		log.AddContext(ctx).Infof("The snapshot %s is already exist.", snapshotName)
+		return p.getSnapshotInfo(snapshot, fsName, fsID, p.getFSSize(ctx, fsName)), nil
+	}
+
+	createTask := taskflow.NewTaskFlow(ctx, "Create-FileSystem-Snapshot")
+	createTask.AddTask("Create-Snapshot", p.createSnapshot, p.revertSnapshot)
+	_, err = createTask.Run(map[string]interface{}{
+		"fsID":         fsID,
+		"snapshotName": snapshotName,
+	})
+	if err != nil {
+		createTask.Revert()
+		return nil, err
+	}
+
+	snapshot, err = p.cli.GetFSSnapshotByName(ctx, fsID, snapshotName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystem snapshot by name %s error: %v", snapshotName, err)
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, pkgUtils.Errorln(ctx, fmt.Sprintf("Snapshot %s of filesystem %s does not exist after created",
+			snapshotName, fsName))
+	}
+
+	return p.getSnapshotInfo(snapshot, fsName, fsID, p.getFSSize(ctx, fsName)), nil
+}
+
+func (p *NAS) createSnapshot(ctx context.Context,
+	params, taskResult map[string]interface{}) (map[string]interface{}, error) {
+	fsID, _ := params["fsID"].(string)
+	snapshotName, _ := params["snapshotName"].(string)
+	err := p.cli.CreateFSSnapshot(ctx, snapshotName, fsID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Create snapshot %s for filesystem %s error: %v", snapshotName, fsID, err)
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"fsID":         fsID,
+		"snapshotName": snapshotName,
+	}, nil
+}
+
+func (p *NAS) revertSnapshot(ctx context.Context, taskResult map[string]interface{}) error {
+	fsID, exist := taskResult["fsID"].(string)
+	if !exist {
+		return nil
+	}
+	snapshotName, _ := taskResult["snapshotName"].(string)
+	return p.cli.DeleteFSSnapshot(ctx, snapshotName, fsID)
+}
+
+// DeleteSnapshot used for delete the snapshot of the filesystem, success if the snapshot does not exist
+func (p *NAS) DeleteSnapshot(ctx context.Context, snapshotParentID, snapshotName string) error {
+	snapshot, err := p.cli.GetFSSnapshotByName(ctx, snapshotParentID, snapshotName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystem snapshot by name %s error: %v", snapshotName, err)
+		return err
+	}
+
+	if snapshot == nil {
+		log.AddContext(ctx).Infof("Filesystem snapshot %s to delete does not exist", snapshotName)
+		return nil
+	}
+
+	err = p.cli.DeleteFSSnapshot(ctx, snapshotName, snapshotParentID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Delete filesystem snapshot %s error: %v", snapshotName, err)
+		return err
+	}
+
+	return nil
+}
+
+// QuerySnapshot used for get the snapshot of the filesystem, return nil if the snapshot does not exist
+func (p *NAS) QuerySnapshot(ctx context.Context, parentID, snapshotName string) (map[string]interface{}, error) {
+	snapshot, err := p.cli.GetFSSnapshotByName(ctx, parentID, snapshotName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystem snapshot by name %s error: %v", snapshotName, err)
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	fsName, _ := snapshot["namespace_name"].(string)
+	return p.getSnapshotInfo(snapshot, fsName, parentID, p.getFSSize(ctx, fsName)), nil
+}
+
+// ListSnapshots used for get all snapshots of the filesystem
+func (p *NAS) ListSnapshots(ctx context.Context, fsName string) ([]map[string]interface{}, error) {
+	fs, err := p.cli.GetFileSystemByName(ctx, fsName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystem by name %s error: %v", fsName, err)
+		return nil, err
+	}
+	if fs == nil {
+		log.AddContext(ctx).Infof("Filesystem %s to list snapshots does not exist", fsName)
+		return nil, nil
+	}
+
+	fsID := strconv.FormatInt(int64(fs["id"].(float64)), 10)
+	snapshots, err := p.cli.GetFSSnapshotsByFsID(ctx, fsID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get snapshots of filesystem %s error: %v", fsName, err)
+		return nil, err
+	}
+
+	sizeBytes := p.getFSSize(ctx, fsName)
+	var snapshotInfos []map[string]interface{}
+	for _, snapshot := range snapshots {
+		snapshotInfos = append(snapshotInfos, p.getSnapshotInfo(snapshot, fsName, fsID, sizeBytes))
+	}
+
+	return snapshotInfos, nil
+}
+
+// getFSSize used for get the capacity in bytes of the filesystem by its quota, return 0 if failed
+func (p *NAS) getFSSize(ctx context.Context, fsName string) int64 {
+	vol, err := p.Query(ctx, fsName)
+	if err != nil {
+		log.AddContext(ctx).Warningf("Get capacity of filesystem %s failed, error: %v", fsName, err)
+		return 0
+	}
+
+	sizeBytes, _ := vol.GetSize()
+	return sizeBytes
+}
+
+// getSnapshotInfo used for get the snapshot info, the size of the snapshot is the capacity of its filesystem
+func (p *NAS) getSnapshotInfo(snapshot map[string]interface{}, fsName, fsID string,
+	sizeBytes int64) map[string]interface{} {
+	createTime, _ := snapshot["create_time"].(float64)
+	return map[string]interface{}{
+		"Name":         snapshot["name"],
+		"CreationTime": int64(createTime),
+		"SizeBytes":    sizeBytes,
+		"ParentID":     fsID,
+		"ParentName":   fsName,
+	}
+}
@@ -374,3 +374,102 @@ func TestDeleteConvergedQoSByFsName(t *testing.T) {
 		So(err, ShouldBeNil)
 	})
 }
+
+func TestCreateSnapshot(t *testing.T) {
+	Convey("Normal", t, func() {
+		m := gomonkey.ApplyMethod(reflect.TypeOf(testClient), "GetFileSystemByName",
+			func(_ *client.Client, _ context.Context, _ string) (map[string]interface{}, error) {
+				return map[string]interface{}{"id": float64(522)}, nil
+			})
+		defer m.Reset()
+		created := false
+		m.ApplyMethod(reflect.TypeOf(testClient), "GetFSSnapshotByName",
+			func(_ *client.Client, _ context.Context, _, name string) (map[string]interface{}, error) {
+				if !created {
+					return nil, nil
+				}
+				return map[string]interface{}{"name": name, "create_time": float64(1)}, nil
+			})
+		m.ApplyMethod(reflect.TypeOf(testClient), "CreateFSSnapshot",
+			func(_ *client.Client, _ context.Context, _, _ string) error {
+				created = true
+				return nil
+			})
+		m.ApplyMethod(reflect.TypeOf(testClient), "GetQuotaByFileSystemName",
+			func(_ *client.Client, _ context.Context, _ string) (map[string]interface{}, error) {
+				return map[string]interface{}{"space_hard_quota": float64(1024), "space_unit_type": float64(1)}, nil
+			})
+
+		nas := NewNAS(testClient)
+		snapshot, err := nas.CreateSnapshot(context.TODO(), "pvc_mock", "snapshot_mock")
+		So(err, ShouldBeNil)
+		So(snapshot["ParentID"], ShouldEqual, "522")
+		So(snapshot["SizeBytes"], ShouldEqual, int64(1024*1024))
+		So(snapshot["CreationTime"], ShouldEqual, int64(1))
+	})
+
+	Convey("Filesystem does not exist", t, func() {
+		m := gomonkey.ApplyMethod(reflect.TypeOf(testClient), "GetFileSystemByName",
+			func(_ *client.Client, _ context.Context, _ string) (map[string]interface{}, error) {
+				return nil, nil
+			})
+		defer m.Reset()
+
+		nas := NewNAS(testClient)
+		_, err := nas.CreateSnapshot(context.TODO(), "pvc_mock", "snapshot_mock")
+		So(err, ShouldBeError)
+	})
+}
+
+func TestDeleteSnapshot(t *testing.T) {
+	Convey("Snapshot does not exist", t, func() {
+		m := gomonkey.ApplyMethod(reflect.TypeOf(testClient), "GetFSSnapshotByName",
+			func(_ *client.Client, _ context.Context, _, _ string) (map[string]interface{}, error) {
+				return nil, nil
+			})
+		defer m.Reset()
+
+		nas := NewNAS(testClient)
+		err := nas.DeleteSnapshot(context.TODO(), "522", "snapshot_mock")
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Delete snapshot error", t, func() {
+		m := gomonkey.ApplyMethod(reflect.TypeOf(testClient), "GetFSSnapshotByName",
+			func(_ *client.Client, _ context.Context, _, name string) (map[string]interface{}, error) {
+				return map[string]interface{}{"name": name}, nil
+			})
+		defer m.Reset()
+		m.ApplyMethod(reflect.TypeOf(testClient), "DeleteFSSnapshot",
+			func(_ *client.Client, _ context.Context, _, _ string) error {
+				return errors.New("mock error")
+			})
+
+		nas := NewNAS(testClient)
+		err := nas.DeleteSnapshot(context.TODO(), "522", "snapshot_mock")
+		So(err, ShouldBeError)
+	})
+}
+
+func TestCreateFromSnapshotWithSmallCapacity(t *testing.T) {
+	Convey("Capacity is smaller than the source", t, func() {
+		m := gomonkey.ApplyMethod(reflect.TypeOf(testClient), "GetFSSnapshotByName",
+			func(_ *client.Client, _ context.Context, _, name string) (map[string]interface{}, error) {
+				return map[string]interface{}{"name": name, "namespace_name": "pvc_src"}, nil
+			})
+		defer m.Reset()
+		m.ApplyMethod(reflect.TypeOf(testClient), "GetQuotaByFileSystemName",
+			func(_ *client.Client, _ context.Context, _ string) (map[string]interface{}, error) {
+				return map[string]interface{}{"space_hard_quota": float64(2048), "space_unit_type": float64(1)}, nil
+			})
+
+		nas := NewNAS(testClient)
+		_, err := nas.createFromSnapshot(context.TODO(), map[string]interface{}{
+			"name":             "pvc_mock",
+			"capacity":         int64(1024),
+			"fromSnapshot":     "snapshot_mock",
+			"snapshotparentid": "522",
+		})
+		So(err, ShouldBeError)
+	})
+}
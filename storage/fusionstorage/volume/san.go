@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"huawei-csi-driver/storage/fusionstorage/client"
 	"huawei-csi-driver/storage/fusionstorage/smartx"
@@ -286,6 +287,85 @@ func (p *SAN) Query(ctx context.Context, name string) (utils.Volume, error) {
 	return volObj, nil
 }
 
+// List used for get all volumes with the given name prefix which belong to the given pools
+func (p *SAN) List(ctx context.Context, prefix string, pools []string) ([]utils.Volume, error) {
+	volPrefix := utils.GetFusionStorageLunName(prefix)
+	var volumes []utils.Volume
+	for _, poolName := range pools {
+		pool, err := p.cli.GetPoolByName(ctx, poolName)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Get storage pool %s error: %v", poolName, err)
+			return nil, err
+		}
+		if pool == nil {
+			log.AddContext(ctx).Warningf("Storage pool %s doesn't exist", poolName)
+			continue
+		}
+
+		vols, err := p.cli.GetVolumesByPoolId(ctx, int64(pool["poolId"].(float64)))
+		if err != nil {
+			log.AddContext(ctx).Errorf("Get volumes of pool %s error: %v", poolName, err)
+			return nil, err
+		}
+
+		for _, vol := range vols {
+			name, ok := vol["volName"].(string)
+			if !ok || !strings.HasPrefix(name, volPrefix) {
+				continue
+			}
+
+			volObj := utils.NewVolume(name)
+			if lunWWN, ok := vol["wwn"].(string); ok {
+				volObj.SetLunWWN(lunWWN)
+			}
+			// set the size, need to trans MiB to Bytes
+			if capacity, ok := vol["volSize"].(float64); ok {
+				volObj.SetSize(utils.TransK8SCapacity(int64(capacity), 1024*1024))
+			}
+			volumes = append(volumes, volObj)
+		}
+	}
+
+	return volumes, nil
+}
+
+// GetCondition used for get the hosts which the volume is mapped to and whether the volume is abnormal,
+// return nil if the volume does not exist
+func (p *SAN) GetCondition(ctx context.Context, name string) (map[string]interface{}, error) {
+	vol, err := p.cli.GetVolumeByName(ctx, name)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get volume by name %s error: %v", name, err)
+		return nil, err
+	}
+	if vol == nil {
+		return nil, nil
+	}
+
+	hosts, err := p.cli.QueryHostOfVolume(ctx, name)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get hosts of volume %s error: %v", name, err)
+		return nil, err
+	}
+
+	var hostNames []string
+	for _, host := range hosts {
+		if hostName, ok := host["hostName"].(string); ok {
+			hostNames = append(hostNames, hostName)
+		}
+	}
+
+	var message string
+	if status, ok := vol["status"].(float64); ok && status != 0 { // 0 means the volume is normal
+		message = fmt.Sprintf("volume %s is abnormal, status: %v", name, status)
+	}
+
+	return map[string]interface{}{
+		"PublishedNodes": hostNames,
+		"Abnormal":       message != "",
+		"Message":        message,
+	}, nil
+}
+
 func (p *SAN) Delete(ctx context.Context, name string) error {
 	vol, err := p.cli.GetVolumeByName(ctx, name)
 	if err != nil {
@@ -451,6 +531,72 @@ func (p *SAN) DeleteSnapshot(ctx context.Context, snapshotName string) error {
 	return nil
 }
 
+// QuerySnapshot used for get the snapshot of the volume, return nil if the snapshot does not exist
+func (p *SAN) QuerySnapshot(ctx context.Context, parentID, snapshotName string) (map[string]interface{}, error) {
+	snapshot, err := p.cli.GetSnapshotByName(ctx, snapshotName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get lun snapshot by name %s error: %v", snapshotName, err)
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	lunName, _ := snapshot["fatherName"].(string)
+	lun, err := p.cli.GetVolumeByName(ctx, lunName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get lun by name %s error: %v", lunName, err)
+		return nil, err
+	}
+	if lun == nil || strconv.FormatInt(int64(lun["volId"].(float64)), 10) != parentID {
+		log.AddContext(ctx).Infof("Lun snapshot %s of lun %s does not exist", snapshotName, parentID)
+		return nil, nil
+	}
+
+	return p.getSnapshotListInfo(snapshot, parentID), nil
+}
+
+// ListSnapshots used for get all snapshots of the volume
+func (p *SAN) ListSnapshots(ctx context.Context, lunName string) ([]map[string]interface{}, error) {
+	lun, err := p.cli.GetVolumeByName(ctx, lunName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get lun by name %s error: %v", lunName, err)
+		return nil, err
+	}
+	if lun == nil {
+		log.AddContext(ctx).Infof("Lun %s to list snapshots does not exist", lunName)
+		return nil, nil
+	}
+
+	snapshots, err := p.cli.GetSnapshotsByVolumeName(ctx, lunName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get snapshots of lun %s error: %v", lunName, err)
+		return nil, err
+	}
+
+	parentID := strconv.FormatInt(int64(lun["volId"].(float64)), 10)
+	var snapshotInfos []map[string]interface{}
+	for _, snapshot := range snapshots {
+		snapshot["fatherName"] = lunName
+		snapshotInfos = append(snapshotInfos, p.getSnapshotListInfo(snapshot, parentID))
+	}
+
+	return snapshotInfos, nil
+}
+
+func (p *SAN) getSnapshotListInfo(snapshot map[string]interface{}, parentID string) map[string]interface{} {
+	createTime, _ := snapshot["createTime"].(string)
+	snapshotCreated, _ := strconv.ParseInt(createTime, 10, 64)
+	snapshotSize, _ := snapshot["snapshotSize"].(float64)
+	return map[string]interface{}{
+		"Name":         snapshot["snapshotName"],
+		"CreationTime": snapshotCreated,
+		"SizeBytes":    int64(snapshotSize) * 1024 * 1024,
+		"ParentID":     parentID,
+		"ParentName":   snapshot["fatherName"],
+	}
+}
+
 func (p *SAN) createSnapshot(ctx context.Context,
 	params, taskResult map[string]interface{}) (map[string]interface{}, error) {
 	lunName := params["lunName"].(string)
@@ -466,3 +612,34 @@ func (p *SAN) createSnapshot(ctx context.Context,
 		"snapshotName": params["snapshotName"].(string),
 	}, nil
 }
+
+// ModifyQoS used to replace the qos of the volume by a new one of the qos config online, the qos of the volume
+// is removed if qosConfig is empty
+func (p *SAN) ModifyQoS(ctx context.Context, name, qosConfig string) error {
+	params := map[string]interface{}{
+		"qos": qosConfig,
+	}
+	err := p.getQoS(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	lun, err := p.cli.GetVolumeByName(ctx, name)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get lun by name %s error: %v", name, err)
+		return err
+	}
+	if lun == nil {
+		return utils.Errorf(ctx, "Lun %s to modify qos does not exist", name)
+	}
+
+	qos, _ := params["qos"].(map[string]int)
+	qosName, err := smartx.NewQoS(p.cli).ReplaceQoS(ctx, name, qos)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Replace qos of lun %s with %v error: %v", name, qos, err)
+		return err
+	}
+
+	log.AddContext(ctx).Infof("The qos of lun %s is %s now", name, qosName)
+	return nil
+}
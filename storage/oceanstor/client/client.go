@@ -29,12 +29,15 @@ import (
 	"net/http/cookiejar"
 	"regexp"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	xuanwuv1 "huawei-csi-driver/client/apis/xuanwu/v1"
 	pkgUtils "huawei-csi-driver/pkg/utils"
 	"huawei-csi-driver/utils"
 	"huawei-csi-driver/utils/log"
+	"huawei-csi-driver/utils/metrics"
 )
 
 const (
@@ -73,6 +76,7 @@ type BaseClientInterface interface {
 	VStore
 	DTree
 	OceanStorQuota
+	User
 
 	Call(ctx context.Context, method string, url string, data map[string]interface{}) (Response, error)
 	BaseCall(ctx context.Context, method string, url string, data map[string]interface{}) (Response, error)
@@ -85,6 +89,7 @@ type BaseClientInterface interface {
 	Login(ctx context.Context) error
 	Logout(ctx context.Context)
 	ReLogin(ctx context.Context) error
+	CheckHealth(ctx context.Context) []utils.URLHealth
 }
 
 var (
@@ -95,6 +100,9 @@ var (
 	}
 
 	filterLogRegex = map[string][]string{
+		"PUT": {
+			`^/user/`,
+		},
 		"GET": {
 			`/vstore_pair\?filter=ID`,
 			`/FsHyperMetroDomain\?RUNNINGSTATUS=0`,
@@ -109,8 +117,6 @@ var (
 			"/storagepool":     true,
 		},
 	}
-
-	ClientSemaphore *utils.Semaphore
 )
 
 func isFilterLog(method, url string) bool {
@@ -148,17 +154,25 @@ type BaseClient struct {
 	Token    string
 
 	ReLoginMutex sync.Mutex
+
+	// semaphore used to limit the concurrent requests to the storage, shared by the clients of the same backend
+	semaphore *utils.Semaphore
+
+	// password used to login instead of the one in the secret of the backend, see NewClientConfig
+	password string
+	// tlsConfig used to create the http client of the new session, see VerifyUserPassword
+	tlsConfig *tls.Config
 }
 
 type HTTP interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
-var newHTTPClient = func() HTTP {
+var newHTTPClient = func(tlsConfig *tls.Config) HTTP {
 	jar, _ := cookiejar.New(nil)
 	return &http.Client{
 		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			TLSClientConfig: tlsConfig,
 		},
 		Jar:     jar,
 		Timeout: 60 * time.Second,
@@ -179,34 +193,50 @@ type NewClientConfig struct {
 	VstoreName      string
 	ParallelNum     string
 	BackendID       string
+	// TLSConfig used to verify the certificate of the storage, nil means verify by the system CA pool
+	TLSConfig *tls.Config
+	// Password used to login the storage directly instead of the one in the secret of the backend, e.g. by
+	// oceanctl out of the cluster. The status of the backend is not changed if the login with it fails.
+	Password string
 }
 
 func NewClient(param *NewClientConfig) *BaseClient {
-	var err error
-	var parallelCount int
-
-	if len(param.ParallelNum) > 0 {
-		parallelCount, err = strconv.Atoi(param.ParallelNum)
-		if err != nil || parallelCount > MaxParallelCount || parallelCount < MinParallelCount {
-			log.Warningf("The config parallelNum %d is invalid, set it to the default value %d",
-				parallelCount, DefaultParallelCount)
-			parallelCount = DefaultParallelCount
-		}
-	} else {
-		parallelCount = DefaultParallelCount
-	}
-
-	log.Infof("Init parallel count is %d", parallelCount)
-	ClientSemaphore = utils.NewSemaphore(parallelCount)
+	parallelCount := GetParallelCount(param.ParallelNum)
+	log.Infof("Init parallel count of backend %s is %d", param.BackendID, parallelCount)
 	return &BaseClient{
 		Urls:            param.Urls,
 		User:            param.User,
 		SecretName:      param.SecretName,
 		SecretNamespace: param.SecretNamespace,
 		VStoreName:      param.VstoreName,
-		Client:          newHTTPClient(),
+		Client:          newHTTPClient(param.TLSConfig),
 		BackendID:       param.BackendID,
+		semaphore:       utils.NewBackendSemaphore(param.BackendID, parallelCount),
+		password:        param.Password,
+		tlsConfig:       param.TLSConfig,
+	}
+}
+
+// GetParallelCount used to parse the max concurrent requests to the storage, the default value is used if
+// the parallelNum is empty or invalid
+func GetParallelCount(parallelNum string) int {
+	if len(parallelNum) == 0 {
+		return DefaultParallelCount
 	}
+
+	parallelCount, err := strconv.Atoi(parallelNum)
+	if err != nil || parallelCount > MaxParallelCount || parallelCount < MinParallelCount {
+		log.Warningf("The config parallelNum %s is invalid, set it to the default value %d",
+			parallelNum, DefaultParallelCount)
+		return DefaultParallelCount
+	}
+
+	return parallelCount
+}
+
+// GetSemaphoreStats used to get the in-flight and waiting requests to the storage of the backend
+func (cli *BaseClient) GetSemaphoreStats() utils.SemaphoreStats {
+	return cli.semaphore.Stats()
 }
 
 func (cli *BaseClient) Call(ctx context.Context,
@@ -296,12 +326,22 @@ func (cli *BaseClient) BaseCall(ctx context.Context,
 	log.FilteredLog(ctx, isFilterLog(method, url), utils.IsDebugLog(method, url, debugLog),
 		fmt.Sprintf("Request method: %s, Url: %s, body: %v", method, reqUrl, data))
 
-	ClientSemaphore.Acquire()
-	defer ClientSemaphore.Release()
+	if cli.semaphore.AvailablePermits() == 0 {
+		stats := cli.semaphore.Stats()
+		log.AddContext(ctx).Infof("Requests to backend %s are saturated, permits: %d, in-flight: %d, waiting: %d",
+			cli.BackendID, stats.Permits, stats.InFlight, stats.Waiting)
+	}
+	cli.semaphore.Acquire()
+	defer cli.semaphore.Release()
 
+	start := time.Now()
 	resp, err := cli.Client.Do(req)
+	metrics.ObserveStorageRequest(cli.BackendID, method, start, err)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Send request method: %s, Url: %s, error: %v", method, reqUrl, err)
+		if pkgUtils.IsCertVerifyError(err) {
+			return r, pkgUtils.NewCertVerifyError(cli.Url, err)
+		}
 		return r, errors.New("unconnected")
 	}
 
@@ -356,9 +396,12 @@ func (cli *BaseClient) ValidateLogin(ctx context.Context) error {
 	var resp Response
 	var err error
 
-	password, err := utils.GetPasswordFromSecret(ctx, cli.SecretName, cli.SecretNamespace)
-	if err != nil {
-		return err
+	password := cli.password
+	if password == "" {
+		password, err = utils.GetPasswordFromSecret(ctx, cli.SecretName, cli.SecretNamespace)
+		if err != nil {
+			return err
+		}
 	}
 
 	data := map[string]interface{}{
@@ -409,9 +452,12 @@ func (cli *BaseClient) Login(ctx context.Context) error {
 	var resp Response
 	var err error
 
-	password, err := pkgUtils.GetPasswordFromBackendID(ctx, cli.BackendID)
-	if err != nil {
-		return err
+	password := cli.password
+	if password == "" {
+		password, err = pkgUtils.GetPasswordFromBackendID(ctx, cli.BackendID)
+		if err != nil {
+			return err
+		}
 	}
 
 	data := map[string]interface{}{
@@ -445,35 +491,58 @@ func (cli *BaseClient) Login(ctx context.Context) error {
 			cli.Url)
 	}
 
-	if err != nil {
+	if err != nil && err.Error() == "unconnected" {
+		return pkgUtils.NewBackendError(xuanwuv1.ConditionReachable, xuanwuv1.ReasonUnreachable,
+			fmt.Errorf("none of the urls %v of the storage is reachable", cli.Urls))
+	} else if err != nil {
 		return err
 	}
 
 	code := int64(resp.Error["code"].(float64))
 	if code != 0 {
-		msg := fmt.Sprintf("Login %s error: %+v", cli.Url, resp)
+		loginErr := pkgUtils.NewBackendError(xuanwuv1.ConditionAuthenticated, getLoginFailedReason(code),
+			fmt.Errorf("Login %s error: %+v", cli.Url, resp))
 		if code == WrongPasswordErrorCode || code == IPLockErrorCode {
-			err := pkgUtils.SetStorageBackendContentOnlineStatus(ctx, cli.BackendID, false)
-			if err != nil {
-				msg = msg + fmt.Sprintf("\nSetStorageBackendContentOffline [%s] failed. error: %v", cli.BackendID, err)
-			}
+			cli.setBackendOffline(ctx, loginErr)
 		}
 
-		return errors.New(msg)
+		return loginErr
 	}
 
 	err = cli.setDataFromRespData(ctx, resp)
 	if err != nil {
-		setErr := pkgUtils.SetStorageBackendContentOnlineStatus(ctx, cli.BackendID, false)
-		if setErr != nil {
-			log.AddContext(ctx).Errorf("SetStorageBackendContentOffline [%s] failed. error: %v", cli.BackendID, setErr)
-		}
+		cli.setBackendOffline(ctx, err)
 		return err
 	}
 
 	return nil
 }
 
+// setBackendOffline used to set the storageBackendContent of the backend offline for the reason, it is skipped if
+// the client logins with the given password since the backend may be not managed by the client
+func (cli *BaseClient) setBackendOffline(ctx context.Context, reason error) {
+	if cli.password != "" {
+		return
+	}
+
+	err := pkgUtils.SetStorageBackendContentOffline(ctx, cli.BackendID, reason)
+	if err != nil {
+		log.AddContext(ctx).Errorf("SetStorageBackendContentOffline [%s] failed. error: %v", cli.BackendID, err)
+	}
+}
+
+// getLoginFailedReason used to get the reason of the Authenticated condition by the error code of the login
+func getLoginFailedReason(code int64) string {
+	switch code {
+	case WrongPasswordErrorCode:
+		return xuanwuv1.ReasonWrongPassword
+	case IPLockErrorCode:
+		return xuanwuv1.ReasonIPLocked
+	default:
+		return xuanwuv1.ReasonLoginFailed
+	}
+}
+
 func (cli *BaseClient) setDataFromRespData(ctx context.Context, resp Response) error {
 	respData, ok := resp.Data.(map[string]interface{})
 	if !ok {
@@ -543,6 +612,7 @@ func (cli *BaseClient) ReLogin(ctx context.Context) error {
 	}
 
 	err := cli.Login(ctx)
+	metrics.IncStorageReLogin(cli.BackendID, err)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Try to relogin error: %v", err)
 		return err
@@ -551,6 +621,49 @@ func (cli *BaseClient) ReLogin(ctx context.Context) error {
 	return nil
 }
 
+// CheckHealth used to probe all management urls of the storage, and relogin to a healthy url proactively
+// if the current url is unreachable
+func (cli *BaseClient) CheckHealth(ctx context.Context) []utils.URLHealth {
+	// Urls and Url are changed by the relogin, so read them with the relogin lock held
+	cli.ReLoginMutex.Lock()
+	urls := append([]string{}, cli.Urls...)
+	currentUrl := cli.Url
+	cli.ReLoginMutex.Unlock()
+
+	var currentHealthy bool
+	var healthyUrls, unhealthyUrls []string
+	healths := make([]utils.URLHealth, 0, len(urls))
+	for _, url := range urls {
+		health := utils.ProbeURL(ctx, cli.Client, url+"/deviceManager/rest")
+		health.URL = url
+		healths = append(healths, health)
+		if !health.Healthy {
+			unhealthyUrls = append(unhealthyUrls, url)
+			continue
+		}
+
+		healthyUrls = append(healthyUrls, url)
+		if url+"/deviceManager/rest" == currentUrl {
+			currentHealthy = true
+		}
+	}
+
+	if currentUrl == "" || currentHealthy || len(healthyUrls) == 0 {
+		return healths
+	}
+
+	log.AddContext(ctx).Warningf("Current url %s of backend %s is unreachable, switch to the healthy urls %v",
+		currentUrl, cli.BackendID, healthyUrls)
+	cli.ReLoginMutex.Lock()
+	cli.Urls = append(healthyUrls, unhealthyUrls...)
+	cli.ReLoginMutex.Unlock()
+	if err := cli.ReLogin(ctx); err != nil {
+		log.AddContext(ctx).Errorf("Switch the url of backend %s failed, error: %v", cli.BackendID, err)
+	}
+
+	return healths
+}
+
 func (cli *BaseClient) getResponseDataMap(ctx context.Context, data interface{}) (map[string]interface{}, error) {
 	respData, ok := data.(map[string]interface{})
 	if !ok {
@@ -688,3 +801,66 @@ func (cli *BaseClient) getBatchObjs(ctx context.Context, url string, filterLog b
 	}
 	return objList, nil
 }
+
+// getObjsByNamePrefix used for get all objects of the current vStore whose name has the given prefix,
+// the objects are queried batch by batch with the fuzzy name filter of the storage.
+func (cli *BaseClient) getObjsByNamePrefix(ctx context.Context, url, prefix string) (
+	[]map[string]interface{}, error) {
+	objs, err := cli.getBatchObjsWithQuery(ctx, fmt.Sprintf("%s?filter=NAME:%s", url, prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	var objList []map[string]interface{}
+	for _, obj := range objs {
+		vStoreName, ok := obj["vstoreName"].(string)
+		if !ok {
+			vStoreName = defaultVStore
+		}
+
+		name, _ := obj["NAME"].(string)
+		if strings.HasPrefix(name, prefix) && vStoreName == cli.GetvStoreName() {
+			objList = append(objList, obj)
+		}
+	}
+
+	return objList, nil
+}
+
+// getBatchObjsWithQuery used for get all objects batch by batch, the url must already contain a query string
+func (cli *BaseClient) getBatchObjsWithQuery(ctx context.Context, url string) ([]map[string]interface{}, error) {
+	var objList []map[string]interface{}
+	for rangeStart := 0; ; rangeStart += QueryCountPerBatch {
+		objUrl := fmt.Sprintf("%s&range=[%d-%d]", url, rangeStart, rangeStart+QueryCountPerBatch)
+		resp, err := cli.Get(ctx, objUrl, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		code := int64(resp.Error["code"].(float64))
+		if code != 0 {
+			return nil, fmt.Errorf("get batch obj list of %s error: %d", url, code)
+		}
+
+		if resp.Data == nil {
+			break
+		}
+
+		respData, err := cli.getResponseDataList(ctx, resp.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, data := range respData {
+			if obj, ok := data.(map[string]interface{}); ok && obj != nil {
+				objList = append(objList, obj)
+			}
+		}
+
+		if len(respData) < QueryCountPerBatch {
+			break
+		}
+	}
+
+	return objList, nil
+}
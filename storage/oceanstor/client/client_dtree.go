@@ -0,0 +1,155 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"huawei-csi-driver/utils/log"
+)
+
+const (
+	dTreeNotExist     int64 = 1077955336
+	dTreeAlreadyExist int64 = 1077955342
+
+	// DTreeParentTypeFS means the parent of the dTree is a filesystem
+	DTreeParentTypeFS = 40
+	// dTreeSecurityStyleUnix means the dTree uses the unix security style
+	dTreeSecurityStyleUnix = 3
+)
+
+// DTree defines interfaces for dTree operations
+type DTree interface {
+	// CreateDTree used for create a dTree under the parent filesystem
+	CreateDTree(ctx context.Context, parentName, name, vStoreID string) (map[string]interface{}, error)
+	// GetDTreeByName used for get the dTree by name under the parent filesystem
+	GetDTreeByName(ctx context.Context, parentName, name, vStoreID string) (map[string]interface{}, error)
+	// GetDTreesByParentName used for get all dTrees under the parent filesystem
+	GetDTreesByParentName(ctx context.Context, parentName, vStoreID string) ([]map[string]interface{}, error)
+	// DeleteDTreeByID used for delete the dTree by id
+	DeleteDTreeByID(ctx context.Context, id, vStoreID string) error
+}
+
+// CreateDTree used for create a dTree under the parent filesystem
+func (cli *BaseClient) CreateDTree(ctx context.Context,
+	parentName, name, vStoreID string) (map[string]interface{}, error) {
+	data := map[string]interface{}{
+		"NAME":          name,
+		"PARENTNAME":    parentName,
+		"PARENTTYPE":    DTreeParentTypeFS,
+		"securityStyle": dTreeSecurityStyleUnix,
+	}
+	if vStoreID != "" {
+		data["vstoreId"] = vStoreID
+	}
+
+	resp, err := cli.Post(ctx, "/QUOTATREE", data)
+	if err != nil {
+		return nil, err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code == dTreeAlreadyExist {
+		log.AddContext(ctx).Infof("DTree %s already exists under filesystem %s while creating", name, parentName)
+		return cli.GetDTreeByName(ctx, parentName, name, vStoreID)
+	}
+	if code != 0 {
+		return nil, fmt.Errorf("create dTree %v error: %d", data, code)
+	}
+
+	respData := resp.Data.(map[string]interface{})
+	return respData, nil
+}
+
+// GetDTreeByName used for get the dTree by name under the parent filesystem
+func (cli *BaseClient) GetDTreeByName(ctx context.Context,
+	parentName, name, vStoreID string) (map[string]interface{}, error) {
+	url := fmt.Sprintf("/QUOTATREE?PARENTNAME=%s&NAME=%s", parentName, name)
+	var data = make(map[string]interface{})
+	if vStoreID != "" {
+		data["vstoreId"] = vStoreID
+	}
+
+	resp, err := cli.Get(ctx, url, data)
+	if err != nil {
+		return nil, err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code == dTreeNotExist || code == filesystemNotExist {
+		log.AddContext(ctx).Infof("DTree %s of filesystem %s does not exist", name, parentName)
+		return nil, nil
+	}
+	if code != 0 {
+		return nil, fmt.Errorf("get dTree %s of filesystem %s error: %d", name, parentName, code)
+	}
+
+	if resp.Data == nil {
+		log.AddContext(ctx).Infof("DTree %s of filesystem %s does not exist", name, parentName)
+		return nil, nil
+	}
+
+	// the response data is an object when querying by name, but some versions return a list
+	if respData, ok := resp.Data.([]interface{}); ok {
+		if len(respData) == 0 {
+			log.AddContext(ctx).Infof("DTree %s of filesystem %s does not exist", name, parentName)
+			return nil, nil
+		}
+		dTree, _ := respData[0].(map[string]interface{})
+		return dTree, nil
+	}
+
+	dTree, _ := resp.Data.(map[string]interface{})
+	return dTree, nil
+}
+
+// GetDTreesByParentName used for get all dTrees under the parent filesystem
+func (cli *BaseClient) GetDTreesByParentName(ctx context.Context,
+	parentName, vStoreID string) ([]map[string]interface{}, error) {
+	url := fmt.Sprintf("/QUOTATREE?PARENTNAME=%s", parentName)
+	if vStoreID != "" {
+		url = fmt.Sprintf("%s&vstoreId=%s", url, vStoreID)
+	}
+
+	return cli.getBatchObjsWithQuery(ctx, url)
+}
+
+// DeleteDTreeByID used for delete the dTree by id
+func (cli *BaseClient) DeleteDTreeByID(ctx context.Context, id, vStoreID string) error {
+	url := fmt.Sprintf("/QUOTATREE?ID=%s", id)
+	var data = make(map[string]interface{})
+	if vStoreID != "" {
+		data["vstoreId"] = vStoreID
+	}
+
+	resp, err := cli.Delete(ctx, url, data)
+	if err != nil {
+		return err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code == dTreeNotExist {
+		log.AddContext(ctx).Infof("DTree %s does not exist while deleting", id)
+		return nil
+	}
+	if code != 0 {
+		return fmt.Errorf("delete dTree %s error: %d", id, code)
+	}
+
+	return nil
+}
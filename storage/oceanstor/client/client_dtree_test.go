@@ -0,0 +1,82 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"bou.ke/monkey"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetDTreeByName(t *testing.T) {
+	Convey("Normal", t, func() {
+		guard := monkey.PatchInstanceMethod(reflect.TypeOf(testClient), "Get",
+			func(_ *BaseClient, _ context.Context, _ string, _ map[string]interface{}) (Response, error) {
+				return Response{
+					Data: map[string]interface{}{
+						"ID":   "1@4097",
+						"NAME": "test",
+					},
+					Error: map[string]interface{}{
+						"code":        float64(0),
+						"description": "0",
+					},
+				}, nil
+			})
+		defer guard.Unpatch()
+
+		dTree, err := testClient.GetDTreeByName(context.TODO(), "parent", "test", "")
+		So(err, ShouldBeNil)
+		So(dTree["ID"], ShouldEqual, "1@4097")
+	})
+
+	Convey("DTree does not exist", t, func() {
+		guard := monkey.PatchInstanceMethod(reflect.TypeOf(testClient), "Get",
+			func(_ *BaseClient, _ context.Context, _ string, _ map[string]interface{}) (Response, error) {
+				return Response{
+					Error: map[string]interface{}{
+						"code":        float64(dTreeNotExist),
+						"description": "0",
+					},
+				}, nil
+			})
+		defer guard.Unpatch()
+
+		dTree, err := testClient.GetDTreeByName(context.TODO(), "parent", "test", "")
+		So(err, ShouldBeNil)
+		So(dTree, ShouldBeNil)
+	})
+
+	Convey("Error code is not zero", t, func() {
+		guard := monkey.PatchInstanceMethod(reflect.TypeOf(testClient), "Get",
+			func(_ *BaseClient, _ context.Context, _ string, _ map[string]interface{}) (Response, error) {
+				return Response{
+					Error: map[string]interface{}{
+						"code":        float64(100),
+						"description": "0",
+					},
+				}, nil
+			})
+		defer guard.Unpatch()
+
+		_, err := testClient.GetDTreeByName(context.TODO(), "parent", "test", "")
+		So(err, ShouldBeError)
+	})
+}
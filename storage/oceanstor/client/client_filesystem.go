@@ -44,6 +44,8 @@ const (
 type Filesystem interface {
 	// GetFileSystemByName used for get file system by name
 	GetFileSystemByName(ctx context.Context, name string) (map[string]interface{}, error)
+	// GetFileSystemsByNamePrefix used for get all file systems whose name has the given prefix
+	GetFileSystemsByNamePrefix(ctx context.Context, prefix string) ([]map[string]interface{}, error)
 	// GetFileSystemByID used for get file system by id
 	GetFileSystemByID(ctx context.Context, id string) (map[string]interface{}, error)
 	// GetNfsShareByPath used for get nfs share by path
@@ -117,6 +119,12 @@ func (cli *BaseClient) GetFileSystemByName(ctx context.Context, name string) (ma
 	return cli.getObjByvStoreName(respData), nil
 }
 
+// GetFileSystemsByNamePrefix used for get all file systems whose name has the given prefix
+func (cli *BaseClient) GetFileSystemsByNamePrefix(ctx context.Context, prefix string) (
+	[]map[string]interface{}, error) {
+	return cli.getObjsByNamePrefix(ctx, "/filesystem", prefix)
+}
+
 // GetFileSystemByID used for get file system by id
 func (cli *BaseClient) GetFileSystemByID(ctx context.Context, id string) (map[string]interface{}, error) {
 	url := fmt.Sprintf("/filesystem/%s", id)
@@ -341,6 +349,11 @@ func (cli *BaseClient) CreateNfsShare(ctx context.Context,
 		data["vstoreId"] = vStoreID
 	}
 
+	// the share of a dTree is created under its parent filesystem
+	if dTreeID, _ := params["dtreeid"].(string); dTreeID != "" {
+		data["DTREEID"] = dTreeID
+	}
+
 	resp, err := cli.Post(ctx, "/NFSHARE", data)
 	if err != nil {
 		return nil, err
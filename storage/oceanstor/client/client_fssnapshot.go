@@ -34,12 +34,18 @@ const (
 type FSSnapshot interface {
 	// DeleteFSSnapshot used for delete file system snapshot by id
 	DeleteFSSnapshot(ctx context.Context, snapshotID string) error
-	// CreateFSSnapshot used for create file system snapshot
-	CreateFSSnapshot(ctx context.Context, name, parentID string) (map[string]interface{}, error)
+	// CreateFSSnapshot used for create file system snapshot, the default description is used if desc is empty
+	CreateFSSnapshot(ctx context.Context, name, parentID, desc string) (map[string]interface{}, error)
 	// GetFSSnapshotByName used for get file system snapshot by snapshot name
 	GetFSSnapshotByName(ctx context.Context, parentID, snapshotName string) (map[string]interface{}, error)
+	// GetFSSnapshotsByParentId used for get all snapshots of the file system
+	GetFSSnapshotsByParentId(ctx context.Context, parentID string) ([]map[string]interface{}, error)
 	// GetFSSnapshotCountByParentId used for get file system snapshot count by parent id
 	GetFSSnapshotCountByParentId(ctx context.Context, ParentId string) (int, error)
+	// CreateFSConsistentSnapshots used for create the snapshots of the file systems at the same point in time
+	CreateFSConsistentSnapshots(ctx context.Context, names, parentIDs []string) error
+	// RollbackFSSnapshot used for roll the parent file system back to the file system snapshot
+	RollbackFSSnapshot(ctx context.Context, snapshotID, speed string) error
 }
 
 // DeleteFSSnapshot used for delete file system snapshot by id
@@ -97,6 +103,12 @@ func (cli *BaseClient) GetFSSnapshotByName(ctx context.Context, parentID, snapsh
 	return snapshot, nil
 }
 
+// GetFSSnapshotsByParentId used for get all snapshots of the file system
+func (cli *BaseClient) GetFSSnapshotsByParentId(ctx context.Context, parentID string) (
+	[]map[string]interface{}, error) {
+	return cli.getBatchObjsWithQuery(ctx, fmt.Sprintf("/FSSNAPSHOT?PARENTID=%s", parentID))
+}
+
 // GetFSSnapshotCountByParentId used for get file system snapshot count by parent id
 func (cli *BaseClient) GetFSSnapshotCountByParentId(ctx context.Context, ParentId string) (int, error) {
 	url := fmt.Sprintf("/FSSNAPSHOT/count?PARENTID=%s", ParentId)
@@ -117,11 +129,16 @@ func (cli *BaseClient) GetFSSnapshotCountByParentId(ctx context.Context, ParentI
 	return count, nil
 }
 
-// CreateFSSnapshot used for create file system snapshot
-func (cli *BaseClient) CreateFSSnapshot(ctx context.Context, name, parentID string) (map[string]interface{}, error) {
+// CreateFSSnapshot used for create file system snapshot, the default description is used if desc is empty
+func (cli *BaseClient) CreateFSSnapshot(ctx context.Context,
+	name, parentID, desc string) (map[string]interface{}, error) {
+	if desc == "" {
+		desc = description
+	}
+
 	data := map[string]interface{}{
 		"NAME":        name,
-		"DESCRIPTION": description,
+		"DESCRIPTION": desc,
 		"PARENTID":    parentID,
 		"PARENTTYPE":  "40",
 	}
@@ -139,3 +156,58 @@ func (cli *BaseClient) CreateFSSnapshot(ctx context.Context, name, parentID stri
 	respData := resp.Data.(map[string]interface{})
 	return respData, nil
 }
+
+// CreateFSConsistentSnapshots used for create the snapshots of the file systems at the same point in time, the
+// snapshot of names[i] is created for parentIDs[i]. The description of each snapshot is its name, which is the
+// same as the name of the member snapshot of the group in kubernetes.
+func (cli *BaseClient) CreateFSConsistentSnapshots(ctx context.Context, names, parentIDs []string) error {
+	if len(names) != len(parentIDs) {
+		return errors.New("the count of the snapshot names does not match the count of the file systems")
+	}
+
+	snapshots := make([]map[string]interface{}, 0, len(names))
+	for i, name := range names {
+		snapshots = append(snapshots, map[string]interface{}{
+			"NAME":        name,
+			"DESCRIPTION": name,
+			"PARENTID":    parentIDs[i],
+		})
+	}
+	data := map[string]interface{}{
+		"PARENTTYPE":   "40",
+		"SNAPSHOTLIST": snapshots,
+	}
+
+	resp, err := cli.Post(ctx, "/FSSNAPSHOT/CONSISTENT_SNAPSHOT", data)
+	if err != nil {
+		return err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code != 0 {
+		return fmt.Errorf("Create consistent snapshots %v for FS %v error: %d", names, parentIDs, code)
+	}
+
+	return nil
+}
+
+// RollbackFSSnapshot used for roll the parent file system back to the file system snapshot, the data written to
+// the file system after the snapshot is created is overwritten
+func (cli *BaseClient) RollbackFSSnapshot(ctx context.Context, snapshotID, speed string) error {
+	data := map[string]interface{}{
+		"ID":            snapshotID,
+		"ROLLBACKSPEED": speed,
+	}
+
+	resp, err := cli.Put(ctx, "/FSSNAPSHOT/ROLLBACK_FSSNAPSHOT", data)
+	if err != nil {
+		return err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code != 0 {
+		return fmt.Errorf("Rollback FS snapshot %s error: %d", snapshotID, code)
+	}
+
+	return nil
+}
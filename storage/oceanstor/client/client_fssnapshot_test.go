@@ -0,0 +1,71 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"bou.ke/monkey"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRollbackFSSnapshot(t *testing.T) {
+	var url string
+	var data map[string]interface{}
+
+	Convey("Normal", t, func() {
+		guard := patchPut(0, &url, &data)
+		defer guard.Unpatch()
+
+		err := testClient.RollbackFSSnapshot(context.TODO(), "1", "2")
+		So(err, ShouldBeNil)
+		So(url, ShouldEqual, "/FSSNAPSHOT/ROLLBACK_FSSNAPSHOT")
+		So(data["ID"], ShouldEqual, "1")
+		So(data["ROLLBACKSPEED"], ShouldEqual, "2")
+	})
+
+	Convey("Error code is not zero", t, func() {
+		guard := patchPut(100, &url, &data)
+		defer guard.Unpatch()
+
+		err := testClient.RollbackFSSnapshot(context.TODO(), "1", "2")
+		So(err, ShouldBeError)
+	})
+}
+
+func TestCreateFSConsistentSnapshots(t *testing.T) {
+	var data map[string]interface{}
+	Convey("Normal", t, func() {
+		guard := monkey.PatchInstanceMethod(reflect.TypeOf(testClient), "Post",
+			func(_ *BaseClient, _ context.Context, _ string, d map[string]interface{}) (Response, error) {
+				data = d
+				return Response{Error: map[string]interface{}{"code": float64(0), "description": "0"}}, nil
+			})
+		defer guard.Unpatch()
+
+		err := testClient.CreateFSConsistentSnapshots(context.TODO(), []string{"s1", "s2"}, []string{"1", "2"})
+		So(err, ShouldBeNil)
+		So(data["SNAPSHOTLIST"], ShouldHaveLength, 2)
+	})
+
+	Convey("Count mismatch", t, func() {
+		err := testClient.CreateFSConsistentSnapshots(context.TODO(), []string{"s1"}, []string{"1", "2"})
+		So(err, ShouldBeError)
+	})
+}
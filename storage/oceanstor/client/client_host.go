@@ -35,6 +35,8 @@ const (
 type Host interface {
 	// QueryAssociateHostGroup used for query associate host group
 	QueryAssociateHostGroup(ctx context.Context, objType int, objID string) ([]interface{}, error)
+	// GetHostsByLunId used for get all hosts which the lun is mapped to
+	GetHostsByLunId(ctx context.Context, lunID string) ([]map[string]interface{}, error)
 	// GetHostByName used to get host by name
 	GetHostByName(ctx context.Context, name string) (map[string]interface{}, error)
 	// GetHostGroupByName used for get host group by name
@@ -127,6 +129,33 @@ func (cli *BaseClient) QueryAssociateHostGroup(ctx context.Context, objType int,
 	return respData, nil
 }
 
+// GetHostsByLunId used for get all hosts which the lun is mapped to
+func (cli *BaseClient) GetHostsByLunId(ctx context.Context, lunID string) ([]map[string]interface{}, error) {
+	url := fmt.Sprintf("/host/associate?TYPE=21&ASSOCIATEOBJTYPE=11&ASSOCIATEOBJID=%s", lunID)
+	resp, err := cli.Get(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code != 0 {
+		return nil, fmt.Errorf("associate query host by lun %s error: %d", lunID, code)
+	}
+
+	if resp.Data == nil {
+		log.AddContext(ctx).Infof("Lun %s doesn't map to any host", lunID)
+		return nil, nil
+	}
+
+	var hosts []map[string]interface{}
+	for _, i := range resp.Data.([]interface{}) {
+		if host, ok := i.(map[string]interface{}); ok {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts, nil
+}
+
 // CreateHost used for create  host
 func (cli *BaseClient) CreateHost(ctx context.Context, name string) (map[string]interface{}, error) {
 	data := map[string]interface{}{
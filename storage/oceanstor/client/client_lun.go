@@ -39,6 +39,8 @@ type Lun interface {
 	QueryAssociateLunGroup(ctx context.Context, objType int, objID string) ([]interface{}, error)
 	// GetLunByName used for get lun by name
 	GetLunByName(ctx context.Context, name string) (map[string]interface{}, error)
+	// GetLunsByNamePrefix used for get all luns whose name has the given prefix
+	GetLunsByNamePrefix(ctx context.Context, prefix string) ([]map[string]interface{}, error)
 	// MakeLunName create lun name based on different storage models
 	MakeLunName(name string) string
 	// GetLunByID used for get lun by id
@@ -119,6 +121,11 @@ func (cli *BaseClient) GetLunByName(ctx context.Context, name string) (map[strin
 	return cli.getObjByvStoreName(respData), nil
 }
 
+// GetLunsByNamePrefix used for get all luns whose name has the given prefix
+func (cli *BaseClient) GetLunsByNamePrefix(ctx context.Context, prefix string) ([]map[string]interface{}, error) {
+	return cli.getObjsByNamePrefix(ctx, "/lun", prefix)
+}
+
 // MakeLunName v3/v5 storage support 1 to 31 characters
 func (cli *BaseClient) MakeLunName(name string) string {
 	if len(name) <= 31 {
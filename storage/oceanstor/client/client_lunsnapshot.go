@@ -31,21 +31,32 @@ const (
 type LunSnapshot interface {
 	// GetLunSnapshotByName used for get lun snapshot by name
 	GetLunSnapshotByName(ctx context.Context, name string) (map[string]interface{}, error)
+	// GetLunSnapshotsByParentId used for get all snapshots of the lun
+	GetLunSnapshotsByParentId(ctx context.Context, parentID string) ([]map[string]interface{}, error)
 	// DeleteLunSnapshot used for delete lun snapshot
 	DeleteLunSnapshot(ctx context.Context, snapshotID string) error
-	// CreateLunSnapshot used for create lun snapshot
-	CreateLunSnapshot(ctx context.Context, name, lunID string) (map[string]interface{}, error)
+	// CreateLunSnapshot used for create lun snapshot, the default description is used if desc is empty
+	CreateLunSnapshot(ctx context.Context, name, lunID, desc string) (map[string]interface{}, error)
 	// ActivateLunSnapshot used for activate lun snapshot
 	ActivateLunSnapshot(ctx context.Context, snapshotID string) error
+	// ActivateLunSnapshots used for activate the lun snapshots at the same point in time
+	ActivateLunSnapshots(ctx context.Context, snapshotIDs []string) error
 	// DeactivateLunSnapshot used for stop lun snapshot
 	DeactivateLunSnapshot(ctx context.Context, snapshotID string) error
+	// RollbackLunSnapshot used for roll the parent lun back to the lun snapshot
+	RollbackLunSnapshot(ctx context.Context, snapshotID, speed string) error
 }
 
-// CreateLunSnapshot used for create lun snapshot
-func (cli *BaseClient) CreateLunSnapshot(ctx context.Context, name, lunID string) (map[string]interface{}, error) {
+// CreateLunSnapshot used for create lun snapshot, the default description is used if desc is empty
+func (cli *BaseClient) CreateLunSnapshot(ctx context.Context,
+	name, lunID, desc string) (map[string]interface{}, error) {
+	if desc == "" {
+		desc = description
+	}
+
 	data := map[string]interface{}{
 		"NAME":        name,
-		"DESCRIPTION": description,
+		"DESCRIPTION": desc,
 		"PARENTID":    lunID,
 	}
 
@@ -91,6 +102,12 @@ func (cli *BaseClient) GetLunSnapshotByName(ctx context.Context, name string) (m
 	return snapshot, nil
 }
 
+// GetLunSnapshotsByParentId used for get all snapshots of the lun
+func (cli *BaseClient) GetLunSnapshotsByParentId(ctx context.Context, parentID string) (
+	[]map[string]interface{}, error) {
+	return cli.getBatchObjsWithQuery(ctx, fmt.Sprintf("/snapshot?filter=PARENTID::%s", parentID))
+}
+
 // DeleteLunSnapshot used for delete lun snapshot
 func (cli *BaseClient) DeleteLunSnapshot(ctx context.Context, snapshotID string) error {
 	url := fmt.Sprintf("/snapshot/%s", snapshotID)
@@ -113,8 +130,14 @@ func (cli *BaseClient) DeleteLunSnapshot(ctx context.Context, snapshotID string)
 
 // ActivateLunSnapshot used for activate lun snapshot
 func (cli *BaseClient) ActivateLunSnapshot(ctx context.Context, snapshotID string) error {
+	return cli.ActivateLunSnapshots(ctx, []string{snapshotID})
+}
+
+// ActivateLunSnapshots used for activate the lun snapshots at the same point in time, so the snapshots are
+// crash-consistent
+func (cli *BaseClient) ActivateLunSnapshots(ctx context.Context, snapshotIDs []string) error {
 	data := map[string]interface{}{
-		"SNAPSHOTLIST": []string{snapshotID},
+		"SNAPSHOTLIST": snapshotIDs,
 	}
 
 	resp, err := cli.Post(ctx, "/snapshot/activate", data)
@@ -124,7 +147,7 @@ func (cli *BaseClient) ActivateLunSnapshot(ctx context.Context, snapshotID strin
 
 	code := int64(resp.Error["code"].(float64))
 	if code != 0 {
-		return fmt.Errorf("Activate snapshot %s error: %d", snapshotID, code)
+		return fmt.Errorf("Activate snapshots %v error: %d", snapshotIDs, code)
 	}
 
 	return nil
@@ -151,3 +174,24 @@ func (cli *BaseClient) DeactivateLunSnapshot(ctx context.Context, snapshotID str
 
 	return nil
 }
+
+// RollbackLunSnapshot used for roll the parent lun back to the lun snapshot, the data written to the lun after
+// the snapshot is activated is overwritten
+func (cli *BaseClient) RollbackLunSnapshot(ctx context.Context, snapshotID, speed string) error {
+	data := map[string]interface{}{
+		"ID":            snapshotID,
+		"ROLLBACKSPEED": speed,
+	}
+
+	resp, err := cli.Put(ctx, "/snapshot/rollback", data)
+	if err != nil {
+		return err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code != 0 {
+		return fmt.Errorf("Rollback snapshot %s error: %d", snapshotID, code)
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"huawei-csi-driver/utils/log"
+)
+
+const (
+	quotaNotExist int64 = 1077953029
+
+	// QuotaParentTypeDTree means the parent of the quota is a dTree
+	QuotaParentTypeDTree = 16445
+	// quotaTypeDirectory means the quota limits the space of the directory
+	quotaTypeDirectory = 1
+	// quotaSpaceUnitByte means the unit of the space quota is byte
+	quotaSpaceUnitByte = 0
+)
+
+// OceanStorQuota defines interfaces for filesystem quota operations
+type OceanStorQuota interface {
+	// CreateDTreeQuota used for create the directory quota of the dTree with the space hard quota in bytes
+	CreateDTreeQuota(ctx context.Context, dTreeID string, spaceHardQuota int64, vStoreID string) (
+		map[string]interface{}, error)
+	// GetQuotaByDTreeID used for get the quota of the dTree
+	GetQuotaByDTreeID(ctx context.Context, dTreeID, vStoreID string) (map[string]interface{}, error)
+	// UpdateQuotaSpaceHardQuota used for update the space hard quota in bytes of the quota
+	UpdateQuotaSpaceHardQuota(ctx context.Context, id string, spaceHardQuota int64, vStoreID string) error
+	// DeleteQuota used for delete the quota by id
+	DeleteQuota(ctx context.Context, id, vStoreID string) error
+}
+
+// CreateDTreeQuota used for create the directory quota of the dTree with the space hard quota in bytes
+func (cli *BaseClient) CreateDTreeQuota(ctx context.Context, dTreeID string, spaceHardQuota int64,
+	vStoreID string) (map[string]interface{}, error) {
+	data := map[string]interface{}{
+		"PARENTTYPE":     QuotaParentTypeDTree,
+		"PARENTID":       dTreeID,
+		"QUOTATYPE":      quotaTypeDirectory,
+		"SPACEHARDQUOTA": spaceHardQuota,
+		"SPACEUNITTYPE":  quotaSpaceUnitByte,
+	}
+	if vStoreID != "" {
+		data["vstoreId"] = vStoreID
+	}
+
+	resp, err := cli.Post(ctx, "/FS_QUOTA", data)
+	if err != nil {
+		return nil, err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code != 0 {
+		return nil, fmt.Errorf("create quota %v error: %d", data, code)
+	}
+
+	respData := resp.Data.(map[string]interface{})
+	return respData, nil
+}
+
+// GetQuotaByDTreeID used for get the quota of the dTree
+func (cli *BaseClient) GetQuotaByDTreeID(ctx context.Context, dTreeID, vStoreID string) (
+	map[string]interface{}, error) {
+	url := fmt.Sprintf("/FS_QUOTA?PARENTTYPE=%d&PARENTID=%s&range=[0-100]&SPACEUNITTYPE=%d",
+		QuotaParentTypeDTree, dTreeID, quotaSpaceUnitByte)
+	var data = make(map[string]interface{})
+	if vStoreID != "" {
+		data["vstoreId"] = vStoreID
+	}
+
+	resp, err := cli.Get(ctx, url, data)
+	if err != nil {
+		return nil, err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code != 0 {
+		return nil, fmt.Errorf("get quota of dTree %s error: %d", dTreeID, code)
+	}
+
+	if resp.Data == nil {
+		log.AddContext(ctx).Infof("Quota of dTree %s does not exist", dTreeID)
+		return nil, nil
+	}
+
+	respData := resp.Data.([]interface{})
+	if len(respData) == 0 {
+		log.AddContext(ctx).Infof("Quota of dTree %s does not exist", dTreeID)
+		return nil, nil
+	}
+
+	quota, _ := respData[0].(map[string]interface{})
+	return quota, nil
+}
+
+// UpdateQuotaSpaceHardQuota used for update the space hard quota in bytes of the quota
+func (cli *BaseClient) UpdateQuotaSpaceHardQuota(ctx context.Context, id string, spaceHardQuota int64,
+	vStoreID string) error {
+	data := map[string]interface{}{
+		"ID":             id,
+		"SPACEHARDQUOTA": spaceHardQuota,
+		"SPACEUNITTYPE":  quotaSpaceUnitByte,
+	}
+	if vStoreID != "" {
+		data["vstoreId"] = vStoreID
+	}
+
+	resp, err := cli.Put(ctx, "/FS_QUOTA", data)
+	if err != nil {
+		return err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code != 0 {
+		return fmt.Errorf("update quota %s to %d error: %d", id, spaceHardQuota, code)
+	}
+
+	return nil
+}
+
+// DeleteQuota used for delete the quota by id
+func (cli *BaseClient) DeleteQuota(ctx context.Context, id, vStoreID string) error {
+	url := fmt.Sprintf("/FS_QUOTA?ID=%s", id)
+	var data = make(map[string]interface{})
+	if vStoreID != "" {
+		data["vstoreId"] = vStoreID
+	}
+
+	resp, err := cli.Delete(ctx, url, data)
+	if err != nil {
+		return err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code == quotaNotExist {
+		log.AddContext(ctx).Infof("Quota %s does not exist while deleting", id)
+		return nil
+	}
+	if code != 0 {
+		return fmt.Errorf("delete quota %s error: %d", id, code)
+	}
+
+	return nil
+}
@@ -26,6 +26,11 @@ import (
 
 const (
 	replicationNotExist int64 = 1077937923
+
+	// ReplicationSecondaryReadOnly means the secondary resource of replication pair is read-only
+	ReplicationSecondaryReadOnly = "2"
+	// ReplicationSecondaryReadWrite means the secondary resource of replication pair is readable and writable
+	ReplicationSecondaryReadWrite = "3"
 )
 
 type Replication interface {
@@ -47,6 +52,10 @@ type Replication interface {
 	SyncReplicationPair(ctx context.Context, pairID string) error
 	// SplitReplicationPair used for split replication pair by pair id
 	SplitReplicationPair(ctx context.Context, pairID string) error
+	// SwitchReplicationPair used for switch the primary and the secondary of replication pair by pair id
+	SwitchReplicationPair(ctx context.Context, pairID string) error
+	// SetReplicationPairSecondaryAccess used for set the access of the secondary resource of replication pair
+	SetReplicationPairSecondaryAccess(ctx context.Context, pairID, access string) error
 }
 
 // CreateReplicationPair used for create replication pair
@@ -105,6 +114,48 @@ func (cli *BaseClient) SyncReplicationPair(ctx context.Context, pairID string) e
 	return nil
 }
 
+// SwitchReplicationPair used for switch the primary and the secondary of replication pair by pair id,
+// the pair must be split before switching
+func (cli *BaseClient) SwitchReplicationPair(ctx context.Context, pairID string) error {
+	data := map[string]interface{}{
+		"ID": pairID,
+	}
+
+	resp, err := cli.Put(ctx, "/REPLICATIONPAIR/switch", data)
+	if err != nil {
+		return err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code != 0 {
+		return fmt.Errorf("Switch replication pair %s error: %d", pairID, code)
+	}
+
+	return nil
+}
+
+// SetReplicationPairSecondaryAccess used for set the access of the secondary resource of replication pair,
+// the access is ReplicationSecondaryReadOnly or ReplicationSecondaryReadWrite
+func (cli *BaseClient) SetReplicationPairSecondaryAccess(ctx context.Context, pairID, access string) error {
+	data := map[string]interface{}{
+		"ID":           pairID,
+		"SECRESACCESS": access,
+	}
+
+	url := fmt.Sprintf("/REPLICATIONPAIR/%s", pairID)
+	resp, err := cli.Put(ctx, url, data)
+	if err != nil {
+		return err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code != 0 {
+		return fmt.Errorf("Set secondary access of replication pair %s to %s error: %d", pairID, access, code)
+	}
+
+	return nil
+}
+
 // DeleteReplicationPair used for delete replication pair by pair id
 func (cli *BaseClient) DeleteReplicationPair(ctx context.Context, pairID string) error {
 	url := fmt.Sprintf("/REPLICATIONPAIR/%s", pairID)
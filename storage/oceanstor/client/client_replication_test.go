@@ -0,0 +1,85 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"bou.ke/monkey"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func patchPut(code float64, url *string, data *map[string]interface{}) *monkey.PatchGuard {
+	return monkey.PatchInstanceMethod(reflect.TypeOf(testClient), "Put",
+		func(_ *BaseClient, _ context.Context, u string, d map[string]interface{}) (Response, error) {
+			*url, *data = u, d
+			return Response{
+				Error: map[string]interface{}{
+					"code":        code,
+					"description": "0",
+				},
+			}, nil
+		})
+}
+
+func TestSwitchReplicationPair(t *testing.T) {
+	var url string
+	var data map[string]interface{}
+
+	Convey("Normal", t, func() {
+		guard := patchPut(0, &url, &data)
+		defer guard.Unpatch()
+
+		err := testClient.SwitchReplicationPair(context.TODO(), "1")
+		So(err, ShouldBeNil)
+		So(url, ShouldEqual, "/REPLICATIONPAIR/switch")
+		So(data["ID"], ShouldEqual, "1")
+	})
+
+	Convey("Error code is not zero", t, func() {
+		guard := patchPut(100, &url, &data)
+		defer guard.Unpatch()
+
+		err := testClient.SwitchReplicationPair(context.TODO(), "1")
+		So(err, ShouldBeError)
+	})
+}
+
+func TestSetReplicationPairSecondaryAccess(t *testing.T) {
+	var url string
+	var data map[string]interface{}
+
+	Convey("Normal", t, func() {
+		guard := patchPut(0, &url, &data)
+		defer guard.Unpatch()
+
+		err := testClient.SetReplicationPairSecondaryAccess(context.TODO(), "1", ReplicationSecondaryReadWrite)
+		So(err, ShouldBeNil)
+		So(url, ShouldEqual, "/REPLICATIONPAIR/1")
+		So(data["SECRESACCESS"], ShouldEqual, ReplicationSecondaryReadWrite)
+	})
+
+	Convey("Error code is not zero", t, func() {
+		guard := patchPut(100, &url, &data)
+		defer guard.Unpatch()
+
+		err := testClient.SetReplicationPairSecondaryAccess(context.TODO(), "1", ReplicationSecondaryReadOnly)
+		So(err, ShouldBeError)
+	})
+}
@@ -34,6 +34,7 @@ import (
 	"huawei-csi-driver/csi/app"
 	cfg "huawei-csi-driver/csi/app/config"
 	pkgUtils "huawei-csi-driver/pkg/utils"
+	"huawei-csi-driver/utils"
 	"huawei-csi-driver/utils/k8sutils"
 	"huawei-csi-driver/utils/log"
 )
@@ -83,7 +84,7 @@ func TestLogin(t *testing.T) {
 		func(ctx context.Context, backendID string) (string, error) {
 			return "mock", nil
 		})
-	m.ApplyFunc(pkgUtils.SetStorageBackendContentOnlineStatus, func(ctx context.Context, backendID string, online bool) error {
+	m.ApplyFunc(pkgUtils.SetStorageBackendContentOffline, func(ctx context.Context, backendID string, reason error) error {
 		return nil
 	})
 	defer m.Reset()
@@ -103,6 +104,92 @@ func TestLogin(t *testing.T) {
 	}
 }
 
+func TestLoginWithPassword(t *testing.T) {
+	var cases = []struct {
+		Name         string
+		ResponseBody string
+		wantErr      bool
+	}{
+		{
+			"Normal",
+			"{\"data\":{\"deviceid\":\"2102352TRW10KB000001\",\"iBaseToken\":\"508C457614FEA541\"}," +
+				"\"error\":{\"code\":0,\"description\":\"0\"}}",
+			false,
+		},
+		{
+			"The user name or password is incorrect",
+			"{\"data\":{},\"error\":{\"code\":1077987870,\"description\":\"The password is incorrect.\"}}",
+			true,
+		},
+	}
+
+	m := gomonkey.ApplyFunc(pkgUtils.GetPasswordFromBackendID,
+		func(ctx context.Context, backendID string) (string, error) {
+			return "", errors.New("the password should not be got from the secret")
+		})
+	m.ApplyFunc(pkgUtils.SetStorageBackendContentOffline, func(ctx context.Context, backendID string,
+		reason error) error {
+		t.Errorf("the backend should not be set offline for the login with the given password")
+		return nil
+	})
+	defer m.Reset()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := NewMockHTTPClient(ctrl)
+
+	cli := NewClient(&NewClientConfig{
+		Urls:      []string{"https://127.0.0.1:8088"},
+		User:      "dev-account",
+		BackendID: "mock-backend",
+		Password:  "mock",
+	})
+	cli.Client = mockClient
+
+	for _, s := range cases {
+		mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: int(successStatus),
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(s.ResponseBody))),
+			}, nil
+		})
+
+		err := cli.Login(context.TODO())
+		assert.Equal(t, s.wantErr, err != nil, "%s, err:%v", s.Name, err)
+	}
+}
+
+func TestValidateLoginWithPassword(t *testing.T) {
+	m := gomonkey.ApplyFunc(utils.GetPasswordFromSecret,
+		func(ctx context.Context, SecretName, SecretNamespace string) (string, error) {
+			return "", errors.New("the password should not be got from the secret")
+		})
+	defer m.Reset()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := NewMockHTTPClient(ctrl)
+
+	cli := NewClient(&NewClientConfig{
+		Urls:      []string{"https://127.0.0.1:8088"},
+		User:      "dev-account",
+		BackendID: "mock-backend",
+		Password:  "mock",
+	})
+	cli.Client = mockClient
+
+	mockClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: int(successStatus),
+			Body: ioutil.NopCloser(bytes.NewReader([]byte("{\"data\":{\"deviceid\":\"2102352TRW10KB000001\"," +
+				"\"iBaseToken\":\"508C457614FEA541\"},\"error\":{\"code\":0,\"description\":\"0\"}}"))),
+		}, nil
+	})
+
+	err := cli.ValidateLogin(context.TODO())
+	assert.Nil(t, err)
+}
+
 func TestLogout(t *testing.T) {
 	var cases = []struct {
 		Name         string
@@ -179,7 +266,7 @@ func TestReLogin(t *testing.T) {
 		func(ctx context.Context, backendID string) (string, error) {
 			return "mock", nil
 		})
-	m.ApplyFunc(pkgUtils.SetStorageBackendContentOnlineStatus, func(ctx context.Context, backendID string, online bool) error {
+	m.ApplyFunc(pkgUtils.SetStorageBackendContentOffline, func(ctx context.Context, backendID string, reason error) error {
 		return nil
 	})
 
@@ -0,0 +1,79 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"huawei-csi-driver/utils/log"
+)
+
+// User provides the operations of the storage account
+type User interface {
+	// ChangeUserPassword used for change the password of the login user
+	ChangeUserPassword(ctx context.Context, oldPassword, newPassword string) error
+	// VerifyUserPassword used for check whether the password of the login user is valid by a new session
+	VerifyUserPassword(ctx context.Context, password string) error
+}
+
+// ChangeUserPassword used for change the password of the login user
+func (cli *BaseClient) ChangeUserPassword(ctx context.Context, oldPassword, newPassword string) error {
+	url := fmt.Sprintf("/user/%s", cli.User)
+	data := map[string]interface{}{
+		"ID":          cli.User,
+		"OLDPASSWORD": oldPassword,
+		"PASSWORD":    newPassword,
+	}
+
+	resp, err := cli.Put(ctx, url, data)
+	if err != nil {
+		return err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code != 0 {
+		return fmt.Errorf("change password of user %s error: %d, description: %v",
+			cli.User, code, resp.Error["description"])
+	}
+
+	log.AddContext(ctx).Infof("Change password of user %s success", cli.User)
+	return nil
+}
+
+// VerifyUserPassword used for check whether the password of the login user is valid by a new session, the session
+// of the client is not changed and the backend is not set offline if the login fails
+func (cli *BaseClient) VerifyUserPassword(ctx context.Context, password string) error {
+	cli.ReLoginMutex.Lock()
+	verifier := &BaseClient{
+		Urls:       append([]string{}, cli.Urls...),
+		User:       cli.User,
+		VStoreName: cli.VStoreName,
+		BackendID:  cli.BackendID,
+		Client:     newHTTPClient(cli.tlsConfig),
+		semaphore:  cli.semaphore,
+		password:   password,
+	}
+	cli.ReLoginMutex.Unlock()
+
+	if err := verifier.Login(ctx); err != nil {
+		return err
+	}
+
+	verifier.Logout(ctx)
+	return nil
+}
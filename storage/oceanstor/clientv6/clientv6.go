@@ -19,11 +19,8 @@ package clientv6
 import (
 	"context"
 	"fmt"
-	"strconv"
 
 	"huawei-csi-driver/storage/oceanstor/client"
-	"huawei-csi-driver/utils"
-	"huawei-csi-driver/utils/log"
 )
 
 type ClientV6 struct {
@@ -31,23 +28,6 @@ type ClientV6 struct {
 }
 
 func NewClientV6(param *client.NewClientConfig) *ClientV6 {
-	var err error
-	var parallelCount int
-
-	if len(param.ParallelNum) > 0 {
-		parallelCount, err = strconv.Atoi(param.ParallelNum)
-		if err != nil || parallelCount > client.MaxParallelCount || parallelCount < client.MinParallelCount {
-			log.Warningf("The config parallelNum %d is invalid, set it to the default value %d",
-				parallelCount, client.DefaultParallelCount)
-			parallelCount = client.DefaultParallelCount
-		}
-	} else {
-		parallelCount = client.DefaultParallelCount
-	}
-
-	log.Infof("Init parallel count is %d", parallelCount)
-	client.ClientSemaphore = utils.NewSemaphore(parallelCount)
-
 	return &ClientV6{
 		*client.NewClient(param),
 	}
@@ -253,30 +253,9 @@ func (p *SmartX) getQosName(objID, objType string) string {
 func (p *SmartX) CreateQos(ctx context.Context,
 	objID, objType, vStoreID string,
 	params map[string]int) (string, error) {
-	var err error
-	var lowerLimit bool
-
-	for k := range params {
-		if strings.HasPrefix(k, "MIN") || strings.HasPrefix(k, "LATENCY") {
-			lowerLimit = true
-		}
-	}
-
-	if lowerLimit {
-		data := map[string]interface{}{
-			"IOPRIORITY": 3,
-		}
-
-		if objType == "fs" {
-			err = p.cli.UpdateFileSystem(ctx, objID, data)
-		} else {
-			err = p.cli.UpdateLun(ctx, objID, data)
-		}
-
-		if err != nil {
-			log.AddContext(ctx).Errorf("Upgrade obj %s of type %s IOPRIORITY error: %v", objID, objID, err)
-			return "", err
-		}
+	err := p.upgradeIOPriority(ctx, objID, objType, params)
+	if err != nil {
+		return "", err
 	}
 
 	name := p.getQosName(objID, objType)
@@ -308,28 +287,126 @@ func (p *SmartX) CreateQos(ctx context.Context,
 	return qosID, nil
 }
 
-func (p *SmartX) DeleteQos(ctx context.Context, qosID, objID, objType, vStoreID string) error {
+// upgradeIOPriority used to set the highest IO priority of the object if the lower limits are in the params
+func (p *SmartX) upgradeIOPriority(ctx context.Context, objID, objType string, params map[string]int) error {
+	var lowerLimit bool
+	for k := range params {
+		if strings.HasPrefix(k, "MIN") || strings.HasPrefix(k, "LATENCY") {
+			lowerLimit = true
+		}
+	}
+
+	if !lowerLimit {
+		return nil
+	}
+
+	var err error
+	data := map[string]interface{}{
+		"IOPRIORITY": 3,
+	}
+
+	if objType == "fs" {
+		err = p.cli.UpdateFileSystem(ctx, objID, data)
+	} else {
+		err = p.cli.UpdateLun(ctx, objID, data)
+	}
+
+	if err != nil {
+		log.AddContext(ctx).Errorf("Upgrade obj %s of type %s IOPRIORITY error: %v", objID, objType, err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateQos used to change the limits of the qos of the object to the params. The qos is recreated if it is
+// shared with other objects or some of its limits are not in the params, since a limit can't be unset.
+func (p *SmartX) UpdateQos(ctx context.Context,
+	qosID, objID, objType, vStoreID string,
+	params map[string]int) (string, error) {
 	qos, err := p.cli.GetQosByID(ctx, qosID, vStoreID)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Get qos by ID %s error: %v", qosID, err)
-		return err
+		return "", err
 	}
 
-	var objList []string
+	objList, err := getQosObjList(ctx, qos, objType)
+	if err != nil {
+		return "", err
+	}
+
+	recreate := len(objList) != 1 || objList[0] != objID
+	for k := range oceanStorCommonParameters {
+		if _, exist := params[k]; exist {
+			continue
+		}
+
+		if value := utils.ToStringSafe(qos[k]); value != "" && value != "0" {
+			recreate = true
+		}
+	}
+
+	if recreate {
+		log.AddContext(ctx).Infof("Recreate qos %s of obj %s of type %s with %v", qosID, objID, objType, params)
+		err = p.DeleteQos(ctx, qosID, objID, objType, vStoreID)
+		if err != nil {
+			return "", err
+		}
+
+		return p.CreateQos(ctx, objID, objType, vStoreID, params)
+	}
+
+	err = p.upgradeIOPriority(ctx, objID, objType, params)
+	if err != nil {
+		return "", err
+	}
+
+	data := make(map[string]interface{})
+	for k, v := range params {
+		data[k] = v
+	}
 
-	listObj := "LUNLIST"
+	err = p.cli.UpdateQos(ctx, qosID, vStoreID, data)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Update qos %s to %v error: %v", qosID, params, err)
+		return "", err
+	}
+
+	return qosID, nil
+}
+
+func getQosObjListKey(objType string) string {
 	if objType == "fs" {
-		listObj = "FSLIST"
+		return "FSLIST"
 	}
+	return "LUNLIST"
+}
 
-	listStr, ok := qos[listObj].(string)
+func getQosObjList(ctx context.Context, qos map[string]interface{}, objType string) ([]string, error) {
+	var objList []string
+	listStr, ok := qos[getQosObjListKey(objType)].(string)
 	if !ok {
-		return errors.New("qos volume list is expected as marshaled string")
+		return nil, errors.New("qos volume list is expected as marshaled string")
 	}
 
-	err = json.Unmarshal([]byte(listStr), &objList)
+	err := json.Unmarshal([]byte(listStr), &objList)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Unmarshal %s error: %v", listStr, err)
+		return nil, err
+	}
+
+	return objList, nil
+}
+
+func (p *SmartX) DeleteQos(ctx context.Context, qosID, objID, objType, vStoreID string) error {
+	qos, err := p.cli.GetQosByID(ctx, qosID, vStoreID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get qos by ID %s error: %v", qosID, err)
+		return err
+	}
+
+	objList, err := getQosObjList(ctx, qos, objType)
+	if err != nil {
 		return err
 	}
 
@@ -343,7 +420,7 @@ func (p *SmartX) DeleteQos(ctx context.Context, qosID, objID, objType, vStoreID
 	if len(leftList) > 0 {
 		log.AddContext(ctx).Warningf("There're some other obj %v associated to qos %s", leftList, qosID)
 		params := map[string]interface{}{
-			listObj: leftList,
+			getQosObjListKey(objType): leftList,
 		}
 		err := p.cli.UpdateQos(ctx, qosID, vStoreID, params)
 		if err != nil {
@@ -371,7 +448,7 @@ func (p *SmartX) DeleteQos(ctx context.Context, qosID, objID, objType, vStoreID
 }
 
 func (p *SmartX) CreateLunSnapshot(ctx context.Context, name, srcLunID string) (map[string]interface{}, error) {
-	snapshot, err := p.cli.CreateLunSnapshot(ctx, name, srcLunID)
+	snapshot, err := p.cli.CreateLunSnapshot(ctx, name, srcLunID, "")
 	if err != nil {
 		log.AddContext(ctx).Errorf("Create snapshot %s for lun %s error: %v", name, srcLunID, err)
 		return nil, err
@@ -408,7 +485,7 @@ func (p *SmartX) DeleteLunSnapshot(ctx context.Context, snapshotID string) error
 }
 
 func (p *SmartX) CreateFSSnapshot(ctx context.Context, name, srcFSID string) (string, error) {
-	snapshot, err := p.cli.CreateFSSnapshot(ctx, name, srcFSID)
+	snapshot, err := p.cli.CreateFSSnapshot(ctx, name, srcFSID, "")
 	if err != nil {
 		log.AddContext(ctx).Errorf("Create snapshot %s for FS %s error: %v", name, srcFSID, err)
 		return "", err
@@ -166,6 +166,15 @@ func (p *Base) getSnapshotReturnInfo(snapshot map[string]interface{}, snapshotSi
 	}
 }
 
+// getSnapshotListInfo used for get the snapshot info with its own name and its parent name
+func (p *Base) getSnapshotListInfo(snapshot map[string]interface{}, snapshotSize int64) map[string]interface{} {
+	info := p.getSnapshotReturnInfo(snapshot, snapshotSize)
+	info["Name"], _ = snapshot["NAME"].(string)
+	info["ParentName"], _ = snapshot["PARENTNAME"].(string)
+	info["Description"], _ = snapshot["DESCRIPTION"].(string)
+	return info
+}
+
 func (p *Base) createReplicationPair(ctx context.Context,
 	params, taskResult map[string]interface{}) (map[string]interface{}, error) {
 	resType := taskResult["resType"].(int)
@@ -241,6 +250,105 @@ func (p *Base) getRemoteDeviceID(ctx context.Context, deviceSN string) (string,
 	return remoteDevice["ID"].(string), nil
 }
 
+// getPairsAbnormalMessage used for check the hyperMetro and the replication pairs of the object,
+// return the reason if any pair is not in a normal running state
+func (p *Base) getPairsAbnormalMessage(ctx context.Context, objID string, resType int,
+	hasHyperMetro, hasReplication bool) (string, error) {
+	if hasHyperMetro {
+		pair, err := p.cli.GetHyperMetroPairByLocalObjID(ctx, objID)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Get hyperMetro pair of %s error: %v", objID, err)
+			return "", err
+		}
+
+		if pair != nil && (pair["HEALTHSTATUS"] == hyperMetroPairHealthStatusFault ||
+			(pair["RUNNINGSTATUS"] != hyperMetroPairRunningStatusNormal &&
+				pair["RUNNINGSTATUS"] != hyperMetroPairRunningStatusSyncing)) {
+			return fmt.Sprintf("hyperMetro pair %v is abnormal, health status: %v, running status: %v",
+				pair["ID"], pair["HEALTHSTATUS"], pair["RUNNINGSTATUS"]), nil
+		}
+	}
+
+	if hasReplication {
+		pairs, err := p.cli.GetReplicationPairByResID(ctx, objID, resType)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Get replication pairs of %s error: %v", objID, err)
+			return "", err
+		}
+
+		for _, pair := range pairs {
+			if pair["RUNNINGSTATUS"] != replicationPairRunningStatusNormal &&
+				pair["RUNNINGSTATUS"] != replicationPairRunningStatusSync {
+				return fmt.Sprintf("replication pair %v is abnormal, running status: %v",
+					pair["ID"], pair["RUNNINGSTATUS"]), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// getPairsStatus used for query the status of the hyperMetro and the replication pairs of the object
+func (p *Base) getPairsStatus(ctx context.Context, objID string, resType int,
+	hasHyperMetro, hasReplication bool) ([]map[string]interface{}, error) {
+	pairs := make([]map[string]interface{}, 0)
+	if hasHyperMetro {
+		pair, err := p.cli.GetHyperMetroPairByLocalObjID(ctx, objID)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Get hyperMetro pair of %s error: %v", objID, err)
+			return nil, err
+		}
+
+		if pair != nil {
+			var remoteDeviceSN string
+			if p.metroRemoteCli != nil {
+				remoteDeviceSN = p.metroRemoteCli.GetDeviceSN()
+			}
+
+			pairs = append(pairs, map[string]interface{}{
+				"Type":           "HyperMetro",
+				"ID":             pair["ID"],
+				"RunningStatus":  getStatusName(hyperMetroPairRunningStatuses, pair["RUNNINGSTATUS"]),
+				"HealthStatus":   getStatusName(pairHealthStatuses, pair["HEALTHSTATUS"]),
+				"SyncProgress":   pair["SYNCPROGRESS"],
+				"RemoteDeviceSN": remoteDeviceSN,
+				"LastSyncTime":   pair["ENDTIME"],
+			})
+		}
+	}
+
+	if hasReplication {
+		replicationPairs, err := p.cli.GetReplicationPairByResID(ctx, objID, resType)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Get replication pairs of %s error: %v", objID, err)
+			return nil, err
+		}
+
+		for _, pair := range replicationPairs {
+			pairs = append(pairs, map[string]interface{}{
+				"Type":           "Replication",
+				"ID":             pair["ID"],
+				"RunningStatus":  getStatusName(replicationPairRunningStatuses, pair["RUNNINGSTATUS"]),
+				"HealthStatus":   getStatusName(pairHealthStatuses, pair["HEALTHSTATUS"]),
+				"SyncProgress":   pair["REPLICATIONPROGRESS"],
+				"RemoteDeviceSN": pair["REMOTEDEVICESN"],
+				"LastSyncTime":   pair["ENDTIME"],
+			})
+		}
+	}
+
+	return pairs, nil
+}
+
+// getStatusName returns the name of the status code, or the code itself if it is unknown
+func getStatusName(names map[string]string, status interface{}) string {
+	code, _ := status.(string)
+	if name, exist := names[code]; exist {
+		return name
+	}
+	return code
+}
+
 func (p *Base) getWorkLoadIDByName(ctx context.Context,
 	cli client.BaseClientInterface,
 	workloadTypeName string) (string, error) {
@@ -18,6 +18,7 @@
 package volume
 
 const (
+	lunHealthStatusNormal        = "1"
 	filesystemHealthStatusNormal = "1"
 
 	filesystemSplitStatusNotStart  = "1"
@@ -28,8 +29,13 @@ const (
 	remoteDeviceHealthStatus        = "1"
 	remoteDeviceRunningStatusLinkUp = "10"
 
-	replicationPairRunningStatusNormal = "1"
-	replicationPairRunningStatusSync   = "23"
+	replicationPairRunningStatusNormal        = "1"
+	replicationPairRunningStatusSync          = "23"
+	replicationPairRunningStatusSplit         = "26"
+	replicationPairRunningStatusToBeRecovered = "33"
+	replicationPairRunningStatusInterrupted   = "34"
+	replicationPairRunningStatusInvalid       = "35"
+	replicationPairRunningStatusStandby       = "110"
 
 	replicationVStorePairRunningStatusNormal = "1"
 	replicationVStorePairRunningStatusSync   = "23"
@@ -38,6 +44,7 @@ const (
 
 	systemVStore = "0"
 
+	pairHealthStatusNormal          = "1"
 	hyperMetroPairHealthStatusFault = "2"
 
 	hyperMetroPairRunningStatusUnknown = "0"
@@ -62,6 +69,40 @@ const (
 	clonePairRunningStatusNormal       = "2"
 	clonePairRunningStatusInitializing = "3"
 
-	snapshotRunningStatusActive   = "43"
-	snapshotRunningStatusInactive = "45"
+	snapshotRunningStatusActive      = "43"
+	snapshotRunningStatusRollingBack = "44"
+	snapshotRunningStatusInactive    = "45"
+
+	// snapshotRollbackSpeedHigh used to roll back the snapshot at the high speed, the speeds are from 1 to 4
+	snapshotRollbackSpeedHigh = "3"
+)
+
+var (
+	// hyperMetroPairRunningStatuses used to show the running status of the hyperMetro pair by name
+	hyperMetroPairRunningStatuses = map[string]string{
+		hyperMetroPairRunningStatusUnknown: "Unknown",
+		hyperMetroPairRunningStatusNormal:  "Normal",
+		hyperMetroPairRunningStatusSyncing: "Synchronizing",
+		hyperMetroPairRunningStatusInvalid: "Invalid",
+		hyperMetroPairRunningStatusPause:   "Paused",
+		hyperMetroPairRunningStatusError:   "Error",
+		hyperMetroPairRunningStatusToSync:  "ToBeSynchronized",
+	}
+
+	// replicationPairRunningStatuses used to show the running status of the replication pair by name
+	replicationPairRunningStatuses = map[string]string{
+		replicationPairRunningStatusNormal:        "Normal",
+		replicationPairRunningStatusSync:          "Synchronizing",
+		replicationPairRunningStatusSplit:         "Split",
+		replicationPairRunningStatusToBeRecovered: "ToBeRecovered",
+		replicationPairRunningStatusInterrupted:   "Interrupted",
+		replicationPairRunningStatusInvalid:       "Invalid",
+		replicationPairRunningStatusStandby:       "Standby",
+	}
+
+	// pairHealthStatuses used to show the health status of the hyperMetro and the replication pair by name
+	pairHealthStatuses = map[string]string{
+		pairHealthStatusNormal:          "Normal",
+		hyperMetroPairHealthStatusFault: "Fault",
+	}
 )
@@ -0,0 +1,408 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package volume
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"huawei-csi-driver/storage/oceanstor/client"
+	"huawei-csi-driver/utils"
+	"huawei-csi-driver/utils/log"
+	"huawei-csi-driver/utils/taskflow"
+)
+
+// DTree provides the operations of the dTree volumes which share one parent filesystem
+type DTree struct {
+	Base
+	vStoreID string
+
+	// nas is used to reuse the nfs share access operations of the filesystem volume
+	nas *NAS
+}
+
+// NewDTree used to create the dTree volume operator
+func NewDTree(cli client.BaseClientInterface, product, vStoreID string) *DTree {
+	base := Base{
+		cli:     cli,
+		product: product,
+	}
+	return &DTree{
+		Base:     base,
+		vStoreID: vStoreID,
+		nas:      &NAS{Base: base},
+	}
+}
+
+func (p *DTree) preCreate(ctx context.Context, params map[string]interface{}) error {
+	if _, exist := params["authclient"].(string); !exist {
+		msg := "authclient must be provided for dtree"
+		log.AddContext(ctx).Errorln(msg)
+		return errors.New(msg)
+	}
+
+	if parentName, exist := params["parentname"].(string); !exist || parentName == "" {
+		msg := "parentname must be provided for dtree"
+		log.AddContext(ctx).Errorln(msg)
+		return errors.New(msg)
+	}
+
+	if _, exist := params["sourcevolumename"]; exist {
+		return errors.New("dtree volume does not support clone")
+	}
+	if _, exist := params["sourcesnapshotname"]; exist {
+		return errors.New("dtree volume does not support creating from snapshot")
+	}
+
+	name := params["name"].(string)
+	params["name"] = utils.GetFileSystemName(name)
+
+	return getNfsSquash(ctx, params)
+}
+
+// Create used to create the dTree under the parent filesystem with its quota and nfs share
+func (p *DTree) Create(ctx context.Context, params map[string]interface{}) (utils.Volume, error) {
+	err := p.preCreate(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	taskflow := taskflow.NewTaskFlow(ctx, "Create-DTree-Volume")
+	taskflow.AddTask("Create-DTree", p.createDTree, p.revertDTree)
+	taskflow.AddTask("Create-Quota", p.createQuota, p.revertQuota)
+	taskflow.AddTask("Create-Share", p.createShare, p.revertShare)
+	taskflow.AddTask("Allow-Share-Access", p.nas.allowShareAccess, p.nas.revertShareAccess)
+	_, err = taskflow.Run(params)
+	if err != nil {
+		taskflow.Revert()
+		return nil, err
+	}
+
+	volObj := utils.NewVolume(params["name"].(string))
+	volObj.SetDTreeParentName(params["parentname"].(string))
+	return volObj, nil
+}
+
+func (p *DTree) createDTree(ctx context.Context,
+	params, taskResult map[string]interface{}) (map[string]interface{}, error) {
+	parentName := params["parentname"].(string)
+	name := params["name"].(string)
+
+	fs, err := p.cli.GetFileSystemByName(ctx, parentName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get parent filesystem %s error: %v", parentName, err)
+		return nil, err
+	}
+	if fs == nil {
+		return nil, fmt.Errorf("parent filesystem %s of dtree %s does not exist", parentName, name)
+	}
+
+	dTree, err := p.cli.GetDTreeByName(ctx, parentName, name, p.vStoreID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get dtree %s of filesystem %s error: %v", name, parentName, err)
+		return nil, err
+	}
+
+	created := dTree == nil
+	if created {
+		dTree, err = p.cli.CreateDTree(ctx, parentName, name, p.vStoreID)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Create dtree %s of filesystem %s error: %v", name, parentName, err)
+			return nil, err
+		}
+	}
+
+	return map[string]interface{}{
+		"dTreeID":       dTree["ID"].(string),
+		"dTreeCreated":  created,
+		"parentID":      fs["ID"].(string),
+		"localVStoreID": p.vStoreID,
+	}, nil
+}
+
+// revertDTree used to delete the dTree only if it's created by this task, the existing one is kept
+func (p *DTree) revertDTree(ctx context.Context, taskResult map[string]interface{}) error {
+	dTreeID, exist := taskResult["dTreeID"].(string)
+	if created, _ := taskResult["dTreeCreated"].(bool); !exist || dTreeID == "" || !created {
+		return nil
+	}
+	return p.cli.DeleteDTreeByID(ctx, dTreeID, p.vStoreID)
+}
+
+func (p *DTree) createQuota(ctx context.Context,
+	params, taskResult map[string]interface{}) (map[string]interface{}, error) {
+	dTreeID := taskResult["dTreeID"].(string)
+	spaceHardQuota, ok := params["spacehardquota"].(int64)
+	if !ok || spaceHardQuota <= 0 {
+		return nil, fmt.Errorf("the space hard quota %v of dtree %s is invalid", params["spacehardquota"],
+			params["name"])
+	}
+
+	quota, err := p.cli.GetQuotaByDTreeID(ctx, dTreeID, p.vStoreID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get quota of dtree %s error: %v", dTreeID, err)
+		return nil, err
+	}
+
+	created := quota == nil
+	if created {
+		quota, err = p.cli.CreateDTreeQuota(ctx, dTreeID, spaceHardQuota, p.vStoreID)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Create quota of dtree %s error: %v", dTreeID, err)
+			return nil, err
+		}
+	} else if curSize := getSpaceHardQuota(quota); curSize != spaceHardQuota {
+		// the existing quota is left by the dTree created before, so its size must be the requested one
+		log.AddContext(ctx).Infof("Update quota of dtree %s from %d to %d", dTreeID, curSize, spaceHardQuota)
+		err = p.cli.UpdateQuotaSpaceHardQuota(ctx, quota["ID"].(string), spaceHardQuota, p.vStoreID)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Update quota of dtree %s error: %v", dTreeID, err)
+			return nil, err
+		}
+	}
+
+	return map[string]interface{}{
+		"quotaID":      quota["ID"].(string),
+		"quotaCreated": created,
+	}, nil
+}
+
+// revertQuota used to delete the quota only if it's created by this task, the existing one is kept
+func (p *DTree) revertQuota(ctx context.Context, taskResult map[string]interface{}) error {
+	quotaID, exist := taskResult["quotaID"].(string)
+	if created, _ := taskResult["quotaCreated"].(bool); !exist || quotaID == "" || !created {
+		return nil
+	}
+	return p.cli.DeleteQuota(ctx, quotaID, p.vStoreID)
+}
+
+func (p *DTree) createShare(ctx context.Context,
+	params, taskResult map[string]interface{}) (map[string]interface{}, error) {
+	sharePath := p.getSharePath(params["parentname"].(string), params["name"].(string))
+	share, err := p.cli.GetNfsShareByPath(ctx, sharePath, p.vStoreID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get nfs share by path %s error: %v", sharePath, err)
+		return nil, err
+	}
+
+	created := share == nil
+	if created {
+		shareParams := map[string]interface{}{
+			"sharepath":   sharePath,
+			"fsid":        taskResult["parentID"].(string),
+			"dtreeid":     taskResult["dTreeID"].(string),
+			"description": params["description"].(string),
+			"vStoreID":    p.vStoreID,
+		}
+
+		share, err = p.cli.CreateNfsShare(ctx, shareParams)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Create nfs share %v error: %v", shareParams, err)
+			return nil, err
+		}
+	}
+
+	return map[string]interface{}{
+		"shareID":      share["ID"].(string),
+		"shareCreated": created,
+	}, nil
+}
+
+// revertShare used to delete the nfs share only if it's created by this task, the existing one is kept
+func (p *DTree) revertShare(ctx context.Context, taskResult map[string]interface{}) error {
+	shareID, exist := taskResult["shareID"].(string)
+	if created, _ := taskResult["shareCreated"].(bool); !exist || shareID == "" || !created {
+		return nil
+	}
+	return p.cli.DeleteNfsShare(ctx, shareID, p.vStoreID)
+}
+
+// getSharePath used to get the share path of the dTree, which is the path under its parent filesystem
+func (p *DTree) getSharePath(parentName, name string) string {
+	return "/" + parentName + utils.GetDtreeSharePath(name)
+}
+
+// Query used to query the dTree and its size from the quota
+func (p *DTree) Query(ctx context.Context, parentName, name string) (utils.Volume, error) {
+	dTree, err := p.cli.GetDTreeByName(ctx, parentName, name, p.vStoreID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get dtree %s of filesystem %s error: %v", name, parentName, err)
+		return nil, err
+	}
+	if dTree == nil {
+		return nil, utils.Errorf(ctx, "dtree [%s] of filesystem [%s] to query does not exist", name, parentName)
+	}
+
+	return p.makeVolume(ctx, parentName, dTree)
+}
+
+// List used to get all dTrees with the given name prefix under the parent filesystem
+func (p *DTree) List(ctx context.Context, parentName, prefix string) ([]utils.Volume, error) {
+	dTrees, err := p.cli.GetDTreesByParentName(ctx, parentName, p.vStoreID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get dtrees of filesystem %s error: %v", parentName, err)
+		return nil, err
+	}
+
+	namePrefix := utils.GetFileSystemName(prefix)
+	var volumes []utils.Volume
+	for _, dTree := range dTrees {
+		name, _ := dTree["NAME"].(string)
+		if !strings.HasPrefix(name, namePrefix) {
+			continue
+		}
+
+		volObj, err := p.makeVolume(ctx, parentName, dTree)
+		if err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, volObj)
+	}
+
+	return volumes, nil
+}
+
+func (p *DTree) makeVolume(ctx context.Context, parentName string, dTree map[string]interface{}) (
+	utils.Volume, error) {
+	name, _ := dTree["NAME"].(string)
+	quota, err := p.cli.GetQuotaByDTreeID(ctx, dTree["ID"].(string), p.vStoreID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get quota of dtree %s error: %v", name, err)
+		return nil, err
+	}
+
+	volObj := utils.NewVolume(name)
+	volObj.SetDTreeParentName(parentName)
+	if quota != nil {
+		volObj.SetSize(getSpaceHardQuota(quota))
+	}
+	return volObj, nil
+}
+
+// getSpaceHardQuota used to get the space hard quota in bytes, the array returns it as a string
+func getSpaceHardQuota(quota map[string]interface{}) int64 {
+	spaceHardQuota, _ := strconv.ParseInt(utils.ToStringSafe(quota["SPACEHARDQUOTA"]), 10, 64)
+	return spaceHardQuota
+}
+
+// GetCondition used to get whether the dTree is abnormal, return nil if the dTree does not exist
+func (p *DTree) GetCondition(ctx context.Context, parentName, name string) (map[string]interface{}, error) {
+	dTree, err := p.cli.GetDTreeByName(ctx, parentName, name, p.vStoreID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get dtree %s of filesystem %s error: %v", name, parentName, err)
+		return nil, err
+	}
+	if dTree == nil {
+		return nil, nil
+	}
+
+	fs, err := p.cli.GetFileSystemByName(ctx, parentName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get parent filesystem %s error: %v", parentName, err)
+		return nil, err
+	}
+
+	var message string
+	if fs == nil {
+		message = fmt.Sprintf("parent filesystem %s of dtree %s does not exist", parentName, name)
+	} else if fs["HEALTHSTATUS"] != filesystemHealthStatusNormal {
+		message = fmt.Sprintf("parent filesystem %s of dtree %s is abnormal, health status: %v",
+			parentName, name, fs["HEALTHSTATUS"])
+	}
+
+	return map[string]interface{}{
+		"Abnormal": message != "",
+		"Message":  message,
+	}, nil
+}
+
+// Delete used to delete the nfs share, the quota and the dTree
+func (p *DTree) Delete(ctx context.Context, parentName, name string) error {
+	dTree, err := p.cli.GetDTreeByName(ctx, parentName, name, p.vStoreID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get dtree %s of filesystem %s error: %v", name, parentName, err)
+		return err
+	}
+	if dTree == nil {
+		log.AddContext(ctx).Infof("Dtree %s of filesystem %s to delete does not exist", name, parentName)
+		return nil
+	}
+
+	sharePath := p.getSharePath(parentName, name)
+	share, err := p.cli.GetNfsShareByPath(ctx, sharePath, p.vStoreID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get nfs share by path %s error: %v", sharePath, err)
+		return err
+	}
+	if share != nil {
+		err = p.cli.DeleteNfsShare(ctx, share["ID"].(string), p.vStoreID)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Delete nfs share %s error: %v", sharePath, err)
+			return err
+		}
+	}
+
+	dTreeID := dTree["ID"].(string)
+	quota, err := p.cli.GetQuotaByDTreeID(ctx, dTreeID, p.vStoreID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get quota of dtree %s error: %v", name, err)
+		return err
+	}
+	if quota != nil {
+		err = p.cli.DeleteQuota(ctx, quota["ID"].(string), p.vStoreID)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Delete quota of dtree %s error: %v", name, err)
+			return err
+		}
+	}
+
+	return p.cli.DeleteDTreeByID(ctx, dTreeID, p.vStoreID)
+}
+
+// Expand used to expand the dTree by updating the space hard quota in bytes
+func (p *DTree) Expand(ctx context.Context, parentName, name string, newSize int64) error {
+	dTree, err := p.cli.GetDTreeByName(ctx, parentName, name, p.vStoreID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get dtree %s of filesystem %s error: %v", name, parentName, err)
+		return err
+	}
+	if dTree == nil {
+		return utils.Errorf(ctx, "dtree %s of filesystem %s to expand does not exist", name, parentName)
+	}
+
+	dTreeID := dTree["ID"].(string)
+	quota, err := p.cli.GetQuotaByDTreeID(ctx, dTreeID, p.vStoreID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get quota of dtree %s error: %v", name, err)
+		return err
+	}
+
+	if quota == nil {
+		_, err = p.cli.CreateDTreeQuota(ctx, dTreeID, newSize, p.vStoreID)
+		return err
+	}
+
+	curSize := getSpaceHardQuota(quota)
+	if newSize <= curSize {
+		return utils.Errorf(ctx, "dtree %s newSize %d must be greater than curSize %d", name, newSize, curSize)
+	}
+
+	return p.cli.UpdateQuotaSpaceHardQuota(ctx, quota["ID"].(string), newSize, p.vStoreID)
+}
@@ -0,0 +1,200 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package volume
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"huawei-csi-driver/utils/log"
+)
+
+// CreateGroupSnapshot used for create the snapshots of the luns and activate them at the same point in time, so
+// the snapshots are crash-consistent. The snapshot of names[i] is named as snapshotNames[i].
+func (p *SAN) CreateGroupSnapshot(ctx context.Context, names, snapshotNames []string) (
+	[]map[string]interface{}, error) {
+	if len(names) != len(snapshotNames) {
+		return nil, errors.New("the count of the snapshot names does not match the count of the luns")
+	}
+
+	var activeIDs, inactiveIDs, createdIDs []string
+	for i, name := range names {
+		snapshot, created, err := p.getOrCreateGroupMemberSnapshot(ctx, name, snapshotNames[i])
+		if err != nil {
+			p.deleteLunSnapshots(ctx, createdIDs)
+			return nil, err
+		}
+
+		snapshotID := snapshot["ID"].(string)
+		if created {
+			createdIDs = append(createdIDs, snapshotID)
+		}
+		if snapshot["RUNNINGSTATUS"] == snapshotRunningStatusActive {
+			activeIDs = append(activeIDs, snapshotID)
+		} else {
+			inactiveIDs = append(inactiveIDs, snapshotID)
+		}
+	}
+
+	// the snapshots are activated together, so some of them are active only if they are not created by this
+	// driver for the group
+	if len(activeIDs) > 0 && len(inactiveIDs) > 0 {
+		p.deleteLunSnapshots(ctx, createdIDs)
+		return nil, fmt.Errorf("snapshots %v are already activated, the group snapshot is not consistent",
+			activeIDs)
+	}
+
+	if len(inactiveIDs) > 0 {
+		if err := p.cli.ActivateLunSnapshots(ctx, inactiveIDs); err != nil {
+			log.AddContext(ctx).Errorf("Activate snapshots %v error: %v", inactiveIDs, err)
+			p.deleteLunSnapshots(ctx, createdIDs)
+			return nil, err
+		}
+	}
+
+	infos := make([]map[string]interface{}, 0, len(snapshotNames))
+	for _, snapshotName := range snapshotNames {
+		snapshot, err := p.cli.GetLunSnapshotByName(ctx, snapshotName)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Get lun snapshot by name %s error: %v", snapshotName, err)
+			return nil, err
+		}
+		if snapshot == nil {
+			return nil, fmt.Errorf("snapshot %s is deleted after activated", snapshotName)
+		}
+
+		snapshotSize, _ := strconv.ParseInt(snapshot["USERCAPACITY"].(string), 10, 64)
+		infos = append(infos, p.getSnapshotReturnInfo(snapshot, snapshotSize))
+	}
+	return infos, nil
+}
+
+// getOrCreateGroupMemberSnapshot used for get the snapshot of the lun, or create it if it does not exist, the
+// snapshot created is not activated
+func (p *SAN) getOrCreateGroupMemberSnapshot(ctx context.Context, name, snapshotName string) (
+	map[string]interface{}, bool, error) {
+	lunName := p.cli.MakeLunName(name)
+	lun, err := p.cli.GetLunByName(ctx, lunName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get lun by name %s error: %v", lunName, err)
+		return nil, false, err
+	}
+	if lun == nil {
+		return nil, false, fmt.Errorf("lun %s to create snapshot does not exist", lunName)
+	}
+
+	lunID := lun["ID"].(string)
+	snapshot, err := p.cli.GetLunSnapshotByName(ctx, snapshotName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get lun snapshot by name %s error: %v", snapshotName, err)
+		return nil, false, err
+	}
+	if snapshot != nil {
+		if snapshot["PARENTID"] != lunID {
+			return nil, false, fmt.Errorf("snapshot %s is already exist, but the parent lun %s is incompatible",
+				snapshotName, lunName)
+		}
+		return snapshot, false, nil
+	}
+
+	snapshot, err = p.cli.CreateLunSnapshot(ctx, snapshotName, lunID, "")
+	if err != nil {
+		log.AddContext(ctx).Errorf("Create snapshot %s for lun %s error: %v", snapshotName, lunName, err)
+		return nil, false, err
+	}
+
+	if err = p.waitSnapshotReady(ctx, snapshotName); err != nil {
+		log.AddContext(ctx).Errorf("Wait snapshot ready by name %s error: %v", snapshotName, err)
+		p.deleteLunSnapshots(ctx, []string{snapshot["ID"].(string)})
+		return nil, false, err
+	}
+	return snapshot, true, nil
+}
+
+func (p *SAN) deleteLunSnapshots(ctx context.Context, snapshotIDs []string) {
+	for _, snapshotID := range snapshotIDs {
+		if err := p.cli.DeleteLunSnapshot(ctx, snapshotID); err != nil {
+			log.AddContext(ctx).Warningf("Delete snapshot %s error: %v", snapshotID, err)
+		}
+	}
+}
+
+// CreateGroupSnapshot used for create the snapshots of the filesystems at the same point in time. The snapshot
+// of fsNames[i] is named as snapshotNames[i].
+func (p *NAS) CreateGroupSnapshot(ctx context.Context, fsNames, snapshotNames []string) (
+	[]map[string]interface{}, error) {
+	if len(fsNames) != len(snapshotNames) {
+		return nil, errors.New("the count of the snapshot names does not match the count of the filesystems")
+	}
+
+	fsList := make([]map[string]interface{}, 0, len(fsNames))
+	fsIDs := make([]string, 0, len(fsNames))
+	existCount := 0
+	for i, fsName := range fsNames {
+		fs, err := p.cli.GetFileSystemByName(ctx, fsName)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Get filesystem by name %s error: %v", fsName, err)
+			return nil, err
+		}
+		if fs == nil {
+			return nil, fmt.Errorf("filesystem %s to create snapshot does not exist", fsName)
+		}
+
+		snapshot, err := p.cli.GetFSSnapshotByName(ctx, fs["ID"].(string), snapshotNames[i])
+		if err != nil {
+			log.AddContext(ctx).Errorf("Get filesystem snapshot by name %s error: %v", snapshotNames[i], err)
+			return nil, err
+		}
+		if snapshot != nil {
+			existCount++
+		}
+
+		fsList = append(fsList, fs)
+		fsIDs = append(fsIDs, fs["ID"].(string))
+	}
+
+	// the snapshots are created together, so some of them exist only if they are not created for the group
+	if existCount > 0 && existCount < len(fsNames) {
+		return nil, errors.New("some of the snapshots already exist, the group snapshot is not consistent")
+	}
+
+	if existCount == 0 {
+		if err := p.cli.CreateFSConsistentSnapshots(ctx, snapshotNames, fsIDs); err != nil {
+			log.AddContext(ctx).Errorf("Create consistent snapshots %v error: %v", snapshotNames, err)
+			return nil, err
+		}
+	}
+
+	infos := make([]map[string]interface{}, 0, len(snapshotNames))
+	for i, snapshotName := range snapshotNames {
+		snapshot, err := p.cli.GetFSSnapshotByName(ctx, fsIDs[i], snapshotName)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Get filesystem snapshot by name %s error: %v", snapshotName, err)
+			return nil, err
+		}
+		if snapshot == nil {
+			return nil, fmt.Errorf("snapshot %s of filesystem %s does not exist after created",
+				snapshotName, fsNames[i])
+		}
+
+		snapshotSize, _ := strconv.ParseInt(fsList[i]["CAPACITY"].(string), 10, 64)
+		infos = append(infos, p.getSnapshotReturnInfo(snapshot, snapshotSize))
+	}
+	return infos, nil
+}
@@ -97,6 +97,37 @@ func (p *NAS) preCreate(ctx context.Context, params map[string]interface{}) erro
 		return err
 	}
 
+	err = getNfsSquash(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	if val, ok := params["snapshotdirectoryvisibility"].(string); ok {
+		if strings.EqualFold(val, visibleString) {
+			params["isshowsnapdir"] = true
+		} else if strings.EqualFold(val, invisibleString) {
+			params["isshowsnapdir"] = false
+		} else {
+			return utils.Errorf(ctx, "parameter snapshotDirectoryVisibility [%v] in sc must be %s or %s.",
+				params["snapshotdirectoryvisibility"], visibleString, invisibleString)
+		}
+	}
+
+	// convert reservedsnapshotspaceratio to int
+	if val, exist := params["reservedsnapshotspaceratio"].(string); exist {
+		intVal, err := strconv.Atoi(val)
+		if err != nil {
+			return err
+		}
+		params["reservedsnapshotspaceratio"] = intVal
+	}
+
+	return nil
+}
+
+// getNfsSquash used for convert the allSquash and rootSquash parameters of the storage class to the values of
+// the nfs share access
+func getNfsSquash(ctx context.Context, params map[string]interface{}) error {
 	// all_squash  all_squash: 0  no_all_squash: 1
 	val, exist := params["allsquash"].(string)
 
@@ -128,26 +159,6 @@ func (p *NAS) preCreate(ctx context.Context, params map[string]interface{}) erro
 		}
 	}
 
-	if val, ok := params["snapshotdirectoryvisibility"].(string); ok {
-		if strings.EqualFold(val, visibleString) {
-			params["isshowsnapdir"] = true
-		} else if strings.EqualFold(val, invisibleString) {
-			params["isshowsnapdir"] = false
-		} else {
-			return utils.Errorf(ctx, "parameter snapshotDirectoryVisibility [%v] in sc must be %s or %s.",
-				params["snapshotdirectoryvisibility"], visibleString, invisibleString)
-		}
-	}
-
-	// convert reservedsnapshotspaceratio to int
-	if val, exist := params["reservedsnapshotspaceratio"].(string); exist {
-		intVal, err := strconv.Atoi(val)
-		if err != nil {
-			return err
-		}
-		params["reservedsnapshotspaceratio"] = intVal
-	}
-
 	return nil
 }
 
@@ -754,6 +765,85 @@ func (p *NAS) Query(ctx context.Context, fsName string, params map[string]interf
 	return volObj, nil
 }
 
+// List used for get all filesystems with the given name prefix which belong to the given pools
+func (p *NAS) List(ctx context.Context, prefix string, pools []string) ([]utils.Volume, error) {
+	fsPrefix := utils.GetFileSystemName(prefix)
+	fsList, err := p.cli.GetFileSystemsByNamePrefix(ctx, fsPrefix)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystems by name prefix %s error: %v", fsPrefix, err)
+		return nil, err
+	}
+
+	var volumes []utils.Volume
+	for _, fs := range fsList {
+		if parentName, ok := fs["PARENTNAME"].(string); !ok || !utils.IsContain(parentName, pools) {
+			continue
+		}
+
+		volObj := utils.NewVolume(fs["NAME"].(string))
+		// set the size, need to trans Sectors to Bytes
+		if capacity, err := strconv.ParseInt(fs["CAPACITY"].(string), 10, 64); err == nil {
+			volObj.SetSize(utils.TransK8SCapacity(capacity, 512))
+		}
+		volumes = append(volumes, volObj)
+	}
+
+	return volumes, nil
+}
+
+// GetCondition used for get whether the filesystem is abnormal, return nil if the filesystem does not exist
+func (p *NAS) GetCondition(ctx context.Context, fsName string) (map[string]interface{}, error) {
+	fs, err := p.cli.GetFileSystemByName(ctx, fsName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystem %s error: %v", fsName, err)
+		return nil, err
+	}
+	if fs == nil {
+		return nil, nil
+	}
+
+	var message string
+	if fs["HEALTHSTATUS"] != filesystemHealthStatusNormal {
+		message = fmt.Sprintf("filesystem %s is abnormal, health status: %v", fsName, fs["HEALTHSTATUS"])
+	} else {
+		var replicationIDs, hyperMetroIDs []string
+		replicationIDStr, _ := fs["REMOTEREPLICATIONIDS"].(string)
+		json.Unmarshal([]byte(replicationIDStr), &replicationIDs)
+		hyperMetroIDStr, _ := fs["HYPERMETROPAIRIDS"].(string)
+		json.Unmarshal([]byte(hyperMetroIDStr), &hyperMetroIDs)
+		message, err = p.getPairsAbnormalMessage(ctx, fs["ID"].(string), resourceTypeFS,
+			len(hyperMetroIDs) > 0, len(replicationIDs) > 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return map[string]interface{}{
+		"Abnormal": message != "",
+		"Message":  message,
+	}, nil
+}
+
+// GetPairsStatus used for query the status of the hyperMetro and the replication pairs of the filesystem,
+// return nil if the filesystem does not exist
+func (p *NAS) GetPairsStatus(ctx context.Context, fsName string) ([]map[string]interface{}, error) {
+	fs, err := p.cli.GetFileSystemByName(ctx, fsName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystem %s error: %v", fsName, err)
+		return nil, err
+	}
+	if fs == nil {
+		return nil, nil
+	}
+
+	var replicationIDs, hyperMetroIDs []string
+	replicationIDStr, _ := fs["REMOTEREPLICATIONIDS"].(string)
+	json.Unmarshal([]byte(replicationIDStr), &replicationIDs)
+	hyperMetroIDStr, _ := fs["HYPERMETROPAIRIDS"].(string)
+	json.Unmarshal([]byte(hyperMetroIDStr), &hyperMetroIDs)
+	return p.getPairsStatus(ctx, fs["ID"].(string), resourceTypeFS, len(hyperMetroIDs) > 0, len(replicationIDs) > 0)
+}
+
 func (p *NAS) Delete(ctx context.Context, fsName string) error {
 	fs, err := p.cli.GetFileSystemByName(ctx, fsName)
 	if err != nil {
@@ -1024,7 +1114,7 @@ func (p *NAS) getReplicationParams(ctx context.Context,
 		"remotePoolID":   remotePoolID,
 		"remoteCli":      p.replicaRemoteCli,
 		"remoteDeviceID": remoteDeviceID,
-		"resType":        40,
+		"resType":        resourceTypeFS,
 	}
 
 	if vStorePairID != "" {
@@ -1495,7 +1585,10 @@ func (p *NAS) expandLocalFS(ctx context.Context,
 	return nil, err
 }
 
-func (p *NAS) CreateSnapshot(ctx context.Context, fsName, snapshotName string) (map[string]interface{}, error) {
+// CreateSnapshot used to create the snapshot of the filesystem, the description of the snapshot is the name of
+// the snapshot in kubernetes, which may be converted in the snapshot name on the storage
+func (p *NAS) CreateSnapshot(ctx context.Context,
+	fsName, snapshotName, description string) (map[string]interface{}, error) {
 	fs, err := p.cli.GetFileSystemByName(ctx, fsName)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Get filesystem by name %s error: %v", fsName, err)
@@ -1520,7 +1613,7 @@ func (p *NAS) CreateSnapshot(ctx context.Context, fsName, snapshotName string) (
 		return p.getSnapshotReturnInfo(snapshot, snapshotSize), nil
 	}
 
-	snapshot, err = p.cli.CreateFSSnapshot(ctx, snapshotName, fsId)
+	snapshot, err = p.cli.CreateFSSnapshot(ctx, snapshotName, fsId, description)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Create snapshot %s for filesystem %s error: %v",
 			snapshotName, fsId, err)
@@ -1552,6 +1645,59 @@ func (p *NAS) DeleteSnapshot(ctx context.Context, snapshotParentId, snapshotName
 	return nil
 }
 
+// QuerySnapshot used for get the snapshot of the filesystem, return nil if the snapshot does not exist
+func (p *NAS) QuerySnapshot(ctx context.Context, parentID, snapshotName string) (map[string]interface{}, error) {
+	snapshot, err := p.cli.GetFSSnapshotByName(ctx, parentID, snapshotName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystem snapshot by name %s error: %v", snapshotName, err)
+		return nil, err
+	}
+	if snapshot == nil {
+		log.AddContext(ctx).Infof("Filesystem snapshot %s of filesystem %s does not exist", snapshotName, parentID)
+		return nil, nil
+	}
+
+	fs, err := p.cli.GetFileSystemByID(ctx, parentID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystem by id %s error: %v", parentID, err)
+		return nil, err
+	}
+
+	snapshotSize, _ := strconv.ParseInt(fs["CAPACITY"].(string), 10, 64)
+	info := p.getSnapshotListInfo(snapshot, snapshotSize)
+	info["ParentName"] = fs["NAME"]
+	return info, nil
+}
+
+// ListSnapshots used for get all snapshots of the filesystem
+func (p *NAS) ListSnapshots(ctx context.Context, fsName string) ([]map[string]interface{}, error) {
+	fs, err := p.cli.GetFileSystemByName(ctx, fsName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystem by name %s error: %v", fsName, err)
+		return nil, err
+	}
+	if fs == nil {
+		log.AddContext(ctx).Infof("Filesystem %s to list snapshots does not exist", fsName)
+		return nil, nil
+	}
+
+	snapshots, err := p.cli.GetFSSnapshotsByParentId(ctx, fs["ID"].(string))
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get snapshots of filesystem %s error: %v", fsName, err)
+		return nil, err
+	}
+
+	snapshotSize, _ := strconv.ParseInt(fs["CAPACITY"].(string), 10, 64)
+	var snapshotInfos []map[string]interface{}
+	for _, snapshot := range snapshots {
+		info := p.getSnapshotListInfo(snapshot, snapshotSize)
+		info["ParentName"] = fsName
+		snapshotInfos = append(snapshotInfos, info)
+	}
+
+	return snapshotInfos, nil
+}
+
 func (p *NAS) getActiveClient(taskResult map[string]interface{}) client.BaseClientInterface {
 	activeClient, exist := taskResult["activeClient"].(client.BaseClientInterface)
 	if !exist {
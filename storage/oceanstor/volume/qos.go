@@ -0,0 +1,188 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package volume
+
+import (
+	"context"
+	"encoding/json"
+
+	"huawei-csi-driver/storage/oceanstor/client"
+	"huawei-csi-driver/storage/oceanstor/smartx"
+	"huawei-csi-driver/utils"
+	"huawei-csi-driver/utils/log"
+)
+
+// parseQoS used to validate the qos config against the product, nil is returned for an empty config
+func (p *Base) parseQoS(ctx context.Context, qosConfig string) (map[string]int, error) {
+	if qosConfig == "" {
+		return nil, nil
+	}
+
+	err := smartx.CheckQoSParameterSupport(ctx, p.product, qosConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"qos": qosConfig,
+	}
+	err = p.getQoS(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return params["qos"].(map[string]int), nil
+}
+
+// modifyObjectQoS used to set the qos of the lun or filesystem to the qos params, the qos of the object is
+// removed if the qos params are empty
+func (p *Base) modifyObjectQoS(ctx context.Context, cli client.BaseClientInterface,
+	obj map[string]interface{}, objType string, qos map[string]int) error {
+	objID, _ := obj["ID"].(string)
+	qosID, _ := obj["IOCLASSID"].(string)
+	vStoreID, _ := obj["vstoreId"].(string)
+	smartX := smartx.NewSmartX(cli)
+
+	if len(qos) == 0 {
+		if qosID == "" {
+			return nil
+		}
+
+		err := smartX.DeleteQos(ctx, qosID, objID, objType, vStoreID)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Remove obj %s of type %s from qos %s error: %v", objID, objType, qosID, err)
+		}
+		return err
+	}
+
+	var err error
+	if qosID == "" {
+		qosID, err = smartX.CreateQos(ctx, objID, objType, vStoreID, qos)
+	} else {
+		qosID, err = smartX.UpdateQos(ctx, qosID, objID, objType, vStoreID, qos)
+	}
+	if err != nil {
+		log.AddContext(ctx).Errorf("Modify qos of obj %s of type %s to %v error: %v", objID, objType, qos, err)
+		return err
+	}
+
+	log.AddContext(ctx).Infof("The qos of obj %s of type %s is %s now", objID, objType, qosID)
+	return nil
+}
+
+// ModifyQoS used to change the qos of the lun online, the remote lun of the hyperMetro is changed as well.
+// The qos is removed if qosConfig is empty.
+func (p *SAN) ModifyQoS(ctx context.Context, name, qosConfig string) error {
+	qos, err := p.parseQoS(ctx, qosConfig)
+	if err != nil {
+		return err
+	}
+
+	lunName := p.cli.MakeLunName(name)
+	lun, err := p.cli.GetLunByName(ctx, lunName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get lun by name %s error: %v", lunName, err)
+		return err
+	}
+	if lun == nil {
+		return utils.Errorf(ctx, "lun %s to modify qos does not exist", lunName)
+	}
+
+	var rss map[string]string
+	rssStr, _ := lun["HASRSSOBJECT"].(string)
+	_ = json.Unmarshal([]byte(rssStr), &rss)
+	if rss["HyperMetro"] == "TRUE" {
+		if p.metroRemoteCli == nil {
+			return utils.Errorln(ctx, "remote client for hypermetro is nil")
+		}
+
+		remoteLun, err := p.metroRemoteCli.GetLunByName(ctx, lunName)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Get hypermetro remote lun by name %s error: %v", lunName, err)
+			return err
+		}
+		if remoteLun == nil {
+			return utils.Errorf(ctx, "hypermetro remote lun %s to modify qos does not exist", lunName)
+		}
+
+		err = p.modifyObjectQoS(ctx, p.metroRemoteCli, remoteLun, "lun", qos)
+		if err != nil {
+			return err
+		}
+	}
+
+	return p.modifyObjectQoS(ctx, p.cli, lun, "lun", qos)
+}
+
+// ModifyQoS used to change the qos of the filesystem online. The qos of the hyperMetro filesystem of DoradoV6 is
+// only on the active site as it is created, the remote filesystem of the other products is changed as well.
+// The qos is removed if qosConfig is empty.
+func (p *NAS) ModifyQoS(ctx context.Context, fsName, qosConfig string) error {
+	qos, err := p.parseQoS(ctx, qosConfig)
+	if err != nil {
+		return err
+	}
+
+	fs, err := p.cli.GetFileSystemByName(ctx, fsName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystem %s error: %v", fsName, err)
+		return err
+	}
+	if fs == nil {
+		return utils.Errorf(ctx, "filesystem %s to modify qos does not exist", fsName)
+	}
+
+	var hyperMetroIDs []string
+	hyperMetroIDStr, _ := fs["HYPERMETROPAIRIDS"].(string)
+	_ = json.Unmarshal([]byte(hyperMetroIDStr), &hyperMetroIDs)
+	if len(hyperMetroIDs) == 0 {
+		return p.modifyObjectQoS(ctx, p.cli, fs, "fs", qos)
+	}
+
+	if p.metroRemoteCli == nil {
+		return utils.Errorln(ctx, "remote client for hypermetro is nil")
+	}
+
+	clients := []client.BaseClientInterface{p.cli, p.metroRemoteCli}
+	if p.product == "DoradoV6" && p.FsHyperMetroActiveSite {
+		clients = []client.BaseClientInterface{p.cli}
+	} else if p.product == "DoradoV6" {
+		clients = []client.BaseClientInterface{p.metroRemoteCli}
+	}
+
+	for _, cli := range clients {
+		if err = p.modifyFSQoS(ctx, cli, fsName, qos); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *NAS) modifyFSQoS(ctx context.Context, cli client.BaseClientInterface,
+	fsName string, qos map[string]int) error {
+	fs, err := cli.GetFileSystemByName(ctx, fsName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystem %s error: %v", fsName, err)
+		return err
+	}
+	if fs == nil {
+		return utils.Errorf(ctx, "filesystem %s to modify qos does not exist", fsName)
+	}
+
+	return p.modifyObjectQoS(ctx, cli, fs, "fs", qos)
+}
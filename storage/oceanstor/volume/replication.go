@@ -0,0 +1,387 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package volume
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"huawei-csi-driver/storage/oceanstor/client"
+	"huawei-csi-driver/utils/log"
+)
+
+const (
+	replicationPairIsPrimary = "true"
+
+	// resourceTypeLun is the type of the lun used to query the pairs and the lun groups of the lun
+	resourceTypeLun = 11
+	// resourceTypeFS is the type of the filesystem used to query the pairs of the filesystem
+	resourceTypeFS = 40
+)
+
+// getReplicationPairs used for get the replication pairs of the object, return error if there is none
+func (p *Base) getReplicationPairs(ctx context.Context, objID string, resType int) (
+	[]map[string]interface{}, error) {
+	pairs, err := p.cli.GetReplicationPairByResID(ctx, objID, resType)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get replication pairs of %s error: %v", objID, err)
+		return nil, err
+	}
+
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("object %s does not have any replication pair", objID)
+	}
+
+	return pairs, nil
+}
+
+// splitReplicationPair used for split the replication pair if it is synchronizing
+func (p *Base) splitReplicationPair(ctx context.Context, pair map[string]interface{}) error {
+	pairID, _ := pair["ID"].(string)
+	runningStatus, _ := pair["RUNNINGSTATUS"].(string)
+	if runningStatus != replicationPairRunningStatusNormal &&
+		runningStatus != replicationPairRunningStatusSync {
+		return nil
+	}
+
+	if err := p.cli.SplitReplicationPair(ctx, pairID); err != nil {
+		log.AddContext(ctx).Errorf("Split replication pair %s error: %v", pairID, err)
+		return err
+	}
+	return nil
+}
+
+// failoverReplication used for promote the local object when the primary site is lost, the replication pairs
+// are split and the local secondary object is set writable
+func (p *Base) failoverReplication(ctx context.Context, objID string, resType int) error {
+	pairs, err := p.getReplicationPairs(ctx, objID, resType)
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range pairs {
+		pairID, _ := pair["ID"].(string)
+		if pair["ISPRIMARY"] == replicationPairIsPrimary {
+			log.AddContext(ctx).Infof("Object %s is the primary of replication pair %s, skip failover",
+				objID, pairID)
+			continue
+		}
+
+		if err := p.splitReplicationPair(ctx, pair); err != nil {
+			return err
+		}
+
+		err := p.cli.SetReplicationPairSecondaryAccess(ctx, pairID, client.ReplicationSecondaryReadWrite)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Set secondary of replication pair %s writable error: %v", pairID, err)
+			return err
+		}
+		log.AddContext(ctx).Infof("Replication pair %s of object %s is failed over", pairID, objID)
+	}
+
+	return nil
+}
+
+// switchoverReplication used for swap the primary and the secondary of the replication pairs in a planned way,
+// the local object becomes the primary and the remote one becomes the read-only secondary
+func (p *Base) switchoverReplication(ctx context.Context, objID string, resType int) error {
+	pairs, err := p.getReplicationPairs(ctx, objID, resType)
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range pairs {
+		pairID, _ := pair["ID"].(string)
+		if pair["ISPRIMARY"] == replicationPairIsPrimary {
+			log.AddContext(ctx).Infof("Object %s is the primary of replication pair %s, skip switchover",
+				objID, pairID)
+			continue
+		}
+
+		if pair["RUNNINGSTATUS"] != replicationPairRunningStatusNormal {
+			return fmt.Errorf("replication pair %s must be normal to switch over, running status: %v",
+				pairID, pair["RUNNINGSTATUS"])
+		}
+
+		if err := p.splitReplicationPair(ctx, pair); err != nil {
+			return err
+		}
+
+		if err := p.reverseReplicationPair(ctx, pairID); err != nil {
+			return err
+		}
+		log.AddContext(ctx).Infof("Replication pair %s of object %s is switched over", pairID, objID)
+	}
+
+	return nil
+}
+
+// resyncReplication used for resume the split replication pairs after the failed site recovers. If reverse,
+// the local object becomes the primary, otherwise the data of the remote primary overwrites the local object
+func (p *Base) resyncReplication(ctx context.Context, objID string, resType int, reverse bool) error {
+	pairs, err := p.getReplicationPairs(ctx, objID, resType)
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range pairs {
+		pairID, _ := pair["ID"].(string)
+		runningStatus, _ := pair["RUNNINGSTATUS"].(string)
+		if runningStatus == replicationPairRunningStatusNormal ||
+			runningStatus == replicationPairRunningStatusSync {
+			log.AddContext(ctx).Infof("Replication pair %s is already %s, skip resync",
+				pairID, getStatusName(replicationPairRunningStatuses, runningStatus))
+			continue
+		}
+
+		if reverse && pair["ISPRIMARY"] != replicationPairIsPrimary {
+			err = p.reverseReplicationPair(ctx, pairID)
+		} else {
+			err = p.protectAndSyncReplicationPair(ctx, pairID)
+		}
+		if err != nil {
+			return err
+		}
+		log.AddContext(ctx).Infof("Replication pair %s of object %s is resynchronized, reverse: %v",
+			pairID, objID, reverse)
+	}
+
+	return nil
+}
+
+// reverseReplicationPair used for switch the split pair so that the local secondary object becomes the primary,
+// then synchronize the data to the new secondary object
+func (p *Base) reverseReplicationPair(ctx context.Context, pairID string) error {
+	err := p.cli.SetReplicationPairSecondaryAccess(ctx, pairID, client.ReplicationSecondaryReadWrite)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Set secondary of replication pair %s writable error: %v", pairID, err)
+		return err
+	}
+
+	if err = p.cli.SwitchReplicationPair(ctx, pairID); err != nil {
+		log.AddContext(ctx).Errorf("Switch replication pair %s error: %v", pairID, err)
+		return err
+	}
+
+	return p.protectAndSyncReplicationPair(ctx, pairID)
+}
+
+// protectAndSyncReplicationPair used for set the secondary object read-only and synchronize the pair
+func (p *Base) protectAndSyncReplicationPair(ctx context.Context, pairID string) error {
+	err := p.cli.SetReplicationPairSecondaryAccess(ctx, pairID, client.ReplicationSecondaryReadOnly)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Set secondary of replication pair %s read-only error: %v", pairID, err)
+		return err
+	}
+
+	if err = p.cli.SyncReplicationPair(ctx, pairID); err != nil {
+		log.AddContext(ctx).Errorf("Sync replication pair %s error: %v", pairID, err)
+		return err
+	}
+	return nil
+}
+
+func (p *SAN) getReplicatedLunID(ctx context.Context, name string) (string, error) {
+	lunName := p.cli.MakeLunName(name)
+	lun, err := p.cli.GetLunByName(ctx, lunName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get lun by name %s error: %v", lunName, err)
+		return "", err
+	}
+	if lun == nil {
+		return "", fmt.Errorf("lun %s does not exist", lunName)
+	}
+
+	var rss map[string]string
+	rssStr, _ := lun["HASRSSOBJECT"].(string)
+	json.Unmarshal([]byte(rssStr), &rss)
+	if rss["RemoteReplication"] != "TRUE" {
+		return "", fmt.Errorf("lun %s is not protected by replication", lunName)
+	}
+
+	return lun["ID"].(string), nil
+}
+
+// remapLun used for remove the lun from its lun groups and add it back, so that the hosts which the lun is
+// mapped to take the changed access of the lun
+func (p *SAN) remapLun(ctx context.Context, lunID string) error {
+	lunGroups, err := p.cli.QueryAssociateLunGroup(ctx, resourceTypeLun, lunID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Query associated lun groups of lun %s error: %v", lunID, err)
+		return err
+	}
+
+	for _, i := range lunGroups {
+		group, ok := i.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		groupID, _ := group["ID"].(string)
+		if err = p.cli.RemoveLunFromGroup(ctx, lunID, groupID); err != nil {
+			log.AddContext(ctx).Errorf("Remove lun %s from group %s error: %v", lunID, groupID, err)
+			return err
+		}
+
+		if err = p.cli.AddLunToGroup(ctx, lunID, groupID); err != nil {
+			log.AddContext(ctx).Errorf("Add lun %s to group %s error: %v", lunID, groupID, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FailoverReplication used for promote the local lun when the primary site is lost, the lun is writable and
+// remapped to its hosts once the failover is done
+func (p *SAN) FailoverReplication(ctx context.Context, name string) error {
+	lunID, err := p.getReplicatedLunID(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if err = p.failoverReplication(ctx, lunID, resourceTypeLun); err != nil {
+		return err
+	}
+	return p.remapLun(ctx, lunID)
+}
+
+// SwitchoverReplication used for make the local lun the primary of its replication pairs in a planned way
+func (p *SAN) SwitchoverReplication(ctx context.Context, name string) error {
+	lunID, err := p.getReplicatedLunID(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if err = p.switchoverReplication(ctx, lunID, resourceTypeLun); err != nil {
+		return err
+	}
+	return p.remapLun(ctx, lunID)
+}
+
+// ResyncReplication used for resume the replication pairs of the lun after the failed site recovers
+func (p *SAN) ResyncReplication(ctx context.Context, name string, reverse bool) error {
+	lunID, err := p.getReplicatedLunID(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	return p.resyncReplication(ctx, lunID, resourceTypeLun, reverse)
+}
+
+func (p *NAS) getReplicatedFSID(ctx context.Context, fsName string) (string, error) {
+	fs, err := p.cli.GetFileSystemByName(ctx, fsName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystem %s error: %v", fsName, err)
+		return "", err
+	}
+	if fs == nil {
+		return "", fmt.Errorf("filesystem %s does not exist", fsName)
+	}
+
+	var replicationIDs []string
+	replicationIDStr, _ := fs["REMOTEREPLICATIONIDS"].(string)
+	json.Unmarshal([]byte(replicationIDStr), &replicationIDs)
+	if len(replicationIDs) == 0 {
+		return "", fmt.Errorf("filesystem %s is not protected by replication", fsName)
+	}
+
+	return fs["ID"].(string), nil
+}
+
+// exportFS used for create the nfs share of the promoted filesystem and allow the authclient to access it,
+// the share of the secondary filesystem is not created with the filesystem
+func (p *NAS) exportFS(ctx context.Context, fsName, fsID string, params map[string]interface{}) error {
+	taskParams := map[string]interface{}{
+		"name":        fsName,
+		"description": "",
+		"authclient":  params["authclient"],
+		"allsquash":   params["allsquash"],
+		"rootsquash":  params["rootsquash"],
+	}
+	taskResult := map[string]interface{}{
+		"localFSID":     fsID,
+		"localVStoreID": p.LocVStoreID,
+	}
+
+	shareResult, err := p.createShare(ctx, taskParams, taskResult)
+	if err != nil {
+		return err
+	}
+
+	taskResult["shareID"] = shareResult["shareID"]
+	_, err = p.allowShareAccess(ctx, taskParams, taskResult)
+	return err
+}
+
+// checkExportParams used for check the authclient, allsquash and rootsquash parameters used to export the
+// filesystem, they are named as the parameters of the storage class
+func checkExportParams(ctx context.Context, params map[string]interface{}) error {
+	if authClient, _ := params["authclient"].(string); authClient == "" {
+		return errors.New("authClient must be provided to export the filesystem")
+	}
+
+	return getNfsSquash(ctx, params)
+}
+
+// FailoverReplication used for promote the local filesystem when the primary site is lost, the filesystem is
+// writable and exported to the authclient of the params once the failover is done
+func (p *NAS) FailoverReplication(ctx context.Context, fsName string, params map[string]interface{}) error {
+	if err := checkExportParams(ctx, params); err != nil {
+		return err
+	}
+
+	fsID, err := p.getReplicatedFSID(ctx, fsName)
+	if err != nil {
+		return err
+	}
+
+	if err = p.failoverReplication(ctx, fsID, resourceTypeFS); err != nil {
+		return err
+	}
+	return p.exportFS(ctx, fsName, fsID, params)
+}
+
+// SwitchoverReplication used for make the local filesystem the primary of its replication pairs in a planned
+// way, the filesystem is exported to the authclient of the params once the switchover is done
+func (p *NAS) SwitchoverReplication(ctx context.Context, fsName string, params map[string]interface{}) error {
+	if err := checkExportParams(ctx, params); err != nil {
+		return err
+	}
+
+	fsID, err := p.getReplicatedFSID(ctx, fsName)
+	if err != nil {
+		return err
+	}
+
+	if err = p.switchoverReplication(ctx, fsID, resourceTypeFS); err != nil {
+		return err
+	}
+	return p.exportFS(ctx, fsName, fsID, params)
+}
+
+// ResyncReplication used for resume the replication pairs of the filesystem after the failed site recovers
+func (p *NAS) ResyncReplication(ctx context.Context, fsName string, reverse bool) error {
+	fsID, err := p.getReplicatedFSID(ctx, fsName)
+	if err != nil {
+		return err
+	}
+
+	return p.resyncReplication(ctx, fsID, resourceTypeFS, reverse)
+}
@@ -0,0 +1,269 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package volume
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"huawei-csi-driver/storage/oceanstor/client"
+	"huawei-csi-driver/utils/log"
+)
+
+const (
+	logName = "volumeTest.log"
+)
+
+func TestMain(m *testing.M) {
+	log.MockInitLogging(logName)
+	defer log.MockStopLogging(logName)
+
+	m.Run()
+}
+
+// fakeReplicationClient is a BaseClientInterface which records the calls changing the storage, the methods not
+// overridden panic since the embedded interface is nil
+type fakeReplicationClient struct {
+	client.BaseClientInterface
+
+	calls     []string
+	pairs     []map[string]interface{}
+	lunGroups []interface{}
+	share     map[string]interface{}
+	accesses  []interface{}
+	splitErr  error
+}
+
+func (f *fakeReplicationClient) GetReplicationPairByResID(_ context.Context, _ string, resType int) (
+	[]map[string]interface{}, error) {
+	if resType != resourceTypeLun && resType != resourceTypeFS {
+		return nil, errors.New("unknown resource type")
+	}
+	return f.pairs, nil
+}
+
+func (f *fakeReplicationClient) SplitReplicationPair(_ context.Context, pairID string) error {
+	f.calls = append(f.calls, "split "+pairID)
+	return f.splitErr
+}
+
+func (f *fakeReplicationClient) SetReplicationPairSecondaryAccess(_ context.Context, pairID, access string) error {
+	if access == client.ReplicationSecondaryReadWrite {
+		f.calls = append(f.calls, "readWrite "+pairID)
+	} else {
+		f.calls = append(f.calls, "readOnly "+pairID)
+	}
+	return nil
+}
+
+func (f *fakeReplicationClient) SwitchReplicationPair(_ context.Context, pairID string) error {
+	f.calls = append(f.calls, "switch "+pairID)
+	return nil
+}
+
+func (f *fakeReplicationClient) SyncReplicationPair(_ context.Context, pairID string) error {
+	f.calls = append(f.calls, "sync "+pairID)
+	return nil
+}
+
+func (f *fakeReplicationClient) QueryAssociateLunGroup(_ context.Context, objType int, _ string) (
+	[]interface{}, error) {
+	if objType != resourceTypeLun {
+		return nil, errors.New("unknown object type")
+	}
+	return f.lunGroups, nil
+}
+
+func (f *fakeReplicationClient) RemoveLunFromGroup(_ context.Context, lunID, groupID string) error {
+	f.calls = append(f.calls, "remove "+lunID+" from "+groupID)
+	return nil
+}
+
+func (f *fakeReplicationClient) AddLunToGroup(_ context.Context, lunID, groupID string) error {
+	f.calls = append(f.calls, "add "+lunID+" to "+groupID)
+	return nil
+}
+
+func (f *fakeReplicationClient) GetNfsShareByPath(_ context.Context, _, _ string) (map[string]interface{}, error) {
+	return f.share, nil
+}
+
+func (f *fakeReplicationClient) CreateNfsShare(_ context.Context, params map[string]interface{}) (
+	map[string]interface{}, error) {
+	f.calls = append(f.calls, "createShare "+params["sharepath"].(string))
+	f.share = map[string]interface{}{"ID": "share"}
+	return f.share, nil
+}
+
+func (f *fakeReplicationClient) GetNfsShareAccessCount(_ context.Context, _, _ string) (int64, error) {
+	return int64(len(f.accesses)), nil
+}
+
+func (f *fakeReplicationClient) GetNfsShareAccessRange(_ context.Context, _, _ string, _, _ int64) (
+	[]interface{}, error) {
+	return f.accesses, nil
+}
+
+func (f *fakeReplicationClient) AllowNfsShareAccess(_ context.Context,
+	req *client.AllowNfsShareAccessRequest) error {
+	f.calls = append(f.calls, "allow "+req.Name)
+	f.accesses = append(f.accesses, map[string]interface{}{"ID": req.Name, "NAME": req.Name})
+	return nil
+}
+
+func (f *fakeReplicationClient) DeleteNfsShareAccess(_ context.Context, accessID, _ string) error {
+	f.calls = append(f.calls, "deleteAccess "+accessID)
+	return nil
+}
+
+func newTestPair(runningStatus string, isPrimary bool) map[string]interface{} {
+	pair := map[string]interface{}{"ID": "pair", "RUNNINGSTATUS": runningStatus, "ISPRIMARY": "false"}
+	if isPrimary {
+		pair["ISPRIMARY"] = replicationPairIsPrimary
+	}
+	return pair
+}
+
+type replicationTestCase struct {
+	name      string
+	pair      map[string]interface{}
+	reverse   bool
+	splitErr  error
+	wantCalls []string
+	wantErr   bool
+}
+
+func runReplicationTestCases(t *testing.T, cases []replicationTestCase,
+	action func(*Base, replicationTestCase) error) {
+	for _, c := range cases {
+		cli := &fakeReplicationClient{pairs: []map[string]interface{}{c.pair}, splitErr: c.splitErr}
+		err := action(&Base{cli: cli}, c)
+		if (err != nil) != c.wantErr {
+			t.Errorf("Test case %s failed, error: %v, wantErr: %v", c.name, err, c.wantErr)
+		}
+		if !reflect.DeepEqual(cli.calls, c.wantCalls) {
+			t.Errorf("Test case %s failed, calls: %v, want: %v", c.name, cli.calls, c.wantCalls)
+		}
+	}
+}
+
+func TestFailoverReplication(t *testing.T) {
+	cases := []replicationTestCase{
+		{name: "Primary", pair: newTestPair(replicationPairRunningStatusNormal, true)},
+		{name: "Normal", pair: newTestPair(replicationPairRunningStatusNormal, false),
+			wantCalls: []string{"split pair", "readWrite pair"}},
+		{name: "Synchronizing", pair: newTestPair(replicationPairRunningStatusSync, false),
+			wantCalls: []string{"split pair", "readWrite pair"}},
+		{name: "Split", pair: newTestPair(replicationPairRunningStatusSplit, false),
+			wantCalls: []string{"readWrite pair"}},
+		{name: "Interrupted", pair: newTestPair(replicationPairRunningStatusInterrupted, false),
+			wantCalls: []string{"readWrite pair"}},
+		{name: "SplitFailed", pair: newTestPair(replicationPairRunningStatusNormal, false),
+			splitErr: errors.New("split error"), wantCalls: []string{"split pair"}, wantErr: true},
+	}
+
+	runReplicationTestCases(t, cases, func(p *Base, _ replicationTestCase) error {
+		return p.failoverReplication(context.Background(), "obj", resourceTypeLun)
+	})
+}
+
+func TestSwitchoverReplication(t *testing.T) {
+	cases := []replicationTestCase{
+		{name: "Primary", pair: newTestPair(replicationPairRunningStatusNormal, true)},
+		{name: "Normal", pair: newTestPair(replicationPairRunningStatusNormal, false),
+			wantCalls: []string{"split pair", "readWrite pair", "switch pair", "readOnly pair", "sync pair"}},
+		{name: "Split", pair: newTestPair(replicationPairRunningStatusSplit, false), wantErr: true},
+		{name: "Synchronizing", pair: newTestPair(replicationPairRunningStatusSync, false), wantErr: true},
+		{name: "SplitFailed", pair: newTestPair(replicationPairRunningStatusNormal, false),
+			splitErr: errors.New("split error"), wantCalls: []string{"split pair"}, wantErr: true},
+	}
+
+	runReplicationTestCases(t, cases, func(p *Base, _ replicationTestCase) error {
+		return p.switchoverReplication(context.Background(), "obj", resourceTypeFS)
+	})
+}
+
+func TestResyncReplication(t *testing.T) {
+	cases := []replicationTestCase{
+		{name: "Normal", pair: newTestPair(replicationPairRunningStatusNormal, false), reverse: true},
+		{name: "Synchronizing", pair: newTestPair(replicationPairRunningStatusSync, false)},
+		{name: "SplitSecondaryReverse", pair: newTestPair(replicationPairRunningStatusSplit, false), reverse: true,
+			wantCalls: []string{"readWrite pair", "switch pair", "readOnly pair", "sync pair"}},
+		{name: "SplitSecondary", pair: newTestPair(replicationPairRunningStatusSplit, false),
+			wantCalls: []string{"readOnly pair", "sync pair"}},
+		{name: "SplitPrimaryReverse", pair: newTestPair(replicationPairRunningStatusSplit, true), reverse: true,
+			wantCalls: []string{"readOnly pair", "sync pair"}},
+		{name: "ToBeRecovered", pair: newTestPair(replicationPairRunningStatusToBeRecovered, false),
+			wantCalls: []string{"readOnly pair", "sync pair"}},
+	}
+
+	runReplicationTestCases(t, cases, func(p *Base, c replicationTestCase) error {
+		return p.resyncReplication(context.Background(), "obj", resourceTypeLun, c.reverse)
+	})
+}
+
+func TestRemapLun(t *testing.T) {
+	cli := &fakeReplicationClient{lunGroups: []interface{}{
+		map[string]interface{}{"ID": "group1"},
+		map[string]interface{}{"ID": "group2"},
+	}}
+	san := NewSAN(cli, nil, nil, "")
+	want := []string{"remove lun from group1", "add lun to group1", "remove lun from group2", "add lun to group2"}
+
+	// the lun is still in the same groups after the remap, so remapping again does the same
+	for i := 0; i < 2; i++ {
+		cli.calls = nil
+		if err := san.remapLun(context.Background(), "lun"); err != nil {
+			t.Errorf("TestRemapLun failed, error: %v", err)
+		}
+		if !reflect.DeepEqual(cli.calls, want) {
+			t.Errorf("TestRemapLun failed, calls: %v, want: %v", cli.calls, want)
+		}
+	}
+}
+
+func TestExportFS(t *testing.T) {
+	cli := &fakeReplicationClient{}
+	nas := NewNAS(cli, nil, nil, "", NASHyperMetro{})
+	params := map[string]interface{}{"authclient": "client1;client2"}
+	if err := checkExportParams(context.Background(), params); err != nil {
+		t.Fatalf("TestExportFS failed, check params error: %v", err)
+	}
+
+	err := nas.exportFS(context.Background(), "fs", "fsID", params)
+	want := []string{"createShare /fs/", "allow client1", "allow client2"}
+	if err != nil || !reflect.DeepEqual(cli.calls, want) {
+		t.Errorf("TestExportFS failed, error: %v, calls: %v, want: %v", err, cli.calls, want)
+	}
+
+	// the share and the accesses already exist, so exporting again changes nothing
+	cli.calls = nil
+	if err = nas.exportFS(context.Background(), "fs", "fsID", params); err != nil || len(cli.calls) != 0 {
+		t.Errorf("TestExportFS failed, error: %v, calls of the second export: %v", err, cli.calls)
+	}
+
+	// the access of the other clients is removed
+	cli.calls = nil
+	params["authclient"] = "client1"
+	err = nas.exportFS(context.Background(), "fs", "fsID", params)
+	want = []string{"deleteAccess client2"}
+	if err != nil || !reflect.DeepEqual(cli.calls, want) {
+		t.Errorf("TestExportFS failed, error: %v, calls: %v, want: %v", err, cli.calls, want)
+	}
+}
@@ -0,0 +1,161 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package volume
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"huawei-csi-driver/utils"
+	"huawei-csi-driver/utils/log"
+)
+
+// waitSnapshotRollbackFinish used for wait until the snapshot returned by getSnapshot is not rolling back
+func (p *Base) waitSnapshotRollbackFinish(ctx context.Context, snapshotName string,
+	getSnapshot func() (map[string]interface{}, error)) error {
+	return utils.WaitUntil(func() (bool, error) {
+		snapshot, err := getSnapshot()
+		if err != nil {
+			return false, err
+		}
+		if snapshot == nil {
+			return false, fmt.Errorf("snapshot %s is deleted while rolling back", snapshotName)
+		}
+
+		if snapshot["RUNNINGSTATUS"] == snapshotRunningStatusRollingBack {
+			log.AddContext(ctx).Infof("Snapshot %s is rolling back, progress: %v%%",
+				snapshotName, snapshot["ROLLBACKRATE"])
+			return false, nil
+		}
+		return true, nil
+	}, time.Hour*6, time.Second*5)
+}
+
+// RevertSnapshot used for roll the lun back to its snapshot in place. The lun must not be mapped to any host,
+// otherwise the data cached by the host is inconsistent with the lun.
+func (p *SAN) RevertSnapshot(ctx context.Context, name, snapshotParentID, snapshotName string) error {
+	lunName := p.cli.MakeLunName(name)
+	lun, err := p.cli.GetLunByName(ctx, lunName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get lun by name %s error: %v", lunName, err)
+		return err
+	}
+	if lun == nil {
+		return fmt.Errorf("lun %s to revert does not exist", lunName)
+	}
+
+	lunID := lun["ID"].(string)
+	if lunID != snapshotParentID {
+		return fmt.Errorf("snapshot %s is not taken from lun %s", snapshotName, lunName)
+	}
+
+	hosts, err := p.cli.GetHostsByLunId(ctx, lunID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get hosts of lun %s error: %v", lunName, err)
+		return err
+	}
+	if len(hosts) > 0 {
+		return fmt.Errorf("lun %s is still mapped to %d hosts, it can be reverted only after unpublished",
+			lunName, len(hosts))
+	}
+
+	getSnapshot := func() (map[string]interface{}, error) {
+		return p.cli.GetLunSnapshotByName(ctx, snapshotName)
+	}
+	snapshot, err := getSnapshot()
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get lun snapshot by name %s error: %v", snapshotName, err)
+		return err
+	}
+	if snapshot == nil || snapshot["PARENTID"] != lunID {
+		return fmt.Errorf("snapshot %s of lun %s does not exist", snapshotName, lunName)
+	}
+
+	// the rollback may be started by the last attempt, so just wait for it
+	if snapshot["RUNNINGSTATUS"] != snapshotRunningStatusRollingBack {
+		if snapshot["RUNNINGSTATUS"] != snapshotRunningStatusActive {
+			return fmt.Errorf("snapshot %s is not activated, running status: %v",
+				snapshotName, snapshot["RUNNINGSTATUS"])
+		}
+
+		err = p.cli.RollbackLunSnapshot(ctx, snapshot["ID"].(string), snapshotRollbackSpeedHigh)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Rollback lun %s to snapshot %s error: %v", lunName, snapshotName, err)
+			return err
+		}
+	}
+
+	err = p.waitSnapshotRollbackFinish(ctx, snapshotName, getSnapshot)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Wait rollback of lun %s to snapshot %s error: %v", lunName, snapshotName, err)
+		return err
+	}
+
+	log.AddContext(ctx).Infof("Lun %s is reverted to snapshot %s", lunName, snapshotName)
+	return nil
+}
+
+// RevertSnapshot used for roll the filesystem back to its snapshot in place. The storage does not know whether
+// the filesystem is mounted, so the caller must make sure it is not used by any client.
+func (p *NAS) RevertSnapshot(ctx context.Context, fsName, snapshotParentID, snapshotName string) error {
+	fs, err := p.cli.GetFileSystemByName(ctx, fsName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystem by name %s error: %v", fsName, err)
+		return err
+	}
+	if fs == nil {
+		return fmt.Errorf("filesystem %s to revert does not exist", fsName)
+	}
+
+	fsID := fs["ID"].(string)
+	if fsID != snapshotParentID {
+		return fmt.Errorf("snapshot %s is not taken from filesystem %s", snapshotName, fsName)
+	}
+
+	getSnapshot := func() (map[string]interface{}, error) {
+		return p.cli.GetFSSnapshotByName(ctx, fsID, snapshotName)
+	}
+	snapshot, err := getSnapshot()
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get filesystem snapshot by name %s error: %v", snapshotName, err)
+		return err
+	}
+	if snapshot == nil {
+		return fmt.Errorf("snapshot %s of filesystem %s does not exist", snapshotName, fsName)
+	}
+
+	// the rollback may be started by the last attempt, so just wait for it
+	if snapshot["RUNNINGSTATUS"] != snapshotRunningStatusRollingBack {
+		err = p.cli.RollbackFSSnapshot(ctx, snapshot["ID"].(string), snapshotRollbackSpeedHigh)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Rollback filesystem %s to snapshot %s error: %v",
+				fsName, snapshotName, err)
+			return err
+		}
+	}
+
+	err = p.waitSnapshotRollbackFinish(ctx, snapshotName, getSnapshot)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Wait rollback of filesystem %s to snapshot %s error: %v",
+			fsName, snapshotName, err)
+		return err
+	}
+
+	log.AddContext(ctx).Infof("Filesystem %s is reverted to snapshot %s", fsName, snapshotName)
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"huawei-csi-driver/storage/oceanstor/client"
@@ -137,6 +138,103 @@ func (p *SAN) Query(ctx context.Context, name string) (utils.Volume, error) {
 	return volObj, nil
 }
 
+// List used for get all luns with the given name prefix which belong to the given pools
+func (p *SAN) List(ctx context.Context, prefix string, pools []string) ([]utils.Volume, error) {
+	lunPrefix := p.cli.MakeLunName(prefix)
+	luns, err := p.cli.GetLunsByNamePrefix(ctx, lunPrefix)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get luns by name prefix %s error: %v", lunPrefix, err)
+		return nil, err
+	}
+
+	var volumes []utils.Volume
+	for _, lun := range luns {
+		if parentName, ok := lun["PARENTNAME"].(string); !ok || !utils.IsContain(parentName, pools) {
+			continue
+		}
+
+		volObj := utils.NewVolume(lun["NAME"].(string))
+		if lunWWN, ok := lun["WWN"].(string); ok {
+			volObj.SetLunWWN(lunWWN)
+		}
+		// set the size, need to trans Sectors to Bytes
+		if capacity, err := strconv.ParseInt(lun["CAPACITY"].(string), 10, 64); err == nil {
+			volObj.SetSize(utils.TransK8SCapacity(capacity, 512))
+		}
+		volumes = append(volumes, volObj)
+	}
+
+	return volumes, nil
+}
+
+// GetCondition used for get the hosts which the lun is mapped to and whether the lun is abnormal,
+// return nil if the lun does not exist
+func (p *SAN) GetCondition(ctx context.Context, name string) (map[string]interface{}, error) {
+	lun, err := p.cli.GetLunByName(ctx, name)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get lun by name %s error: %v", name, err)
+		return nil, err
+	}
+	if lun == nil {
+		return nil, nil
+	}
+
+	lunID := lun["ID"].(string)
+	hosts, err := p.cli.GetHostsByLunId(ctx, lunID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get hosts of lun %s error: %v", name, err)
+		return nil, err
+	}
+
+	var hostNames []string
+	for _, host := range hosts {
+		// the host created by the attacher is named as k8s_<node host name>
+		if hostName, ok := host["NAME"].(string); ok && strings.HasPrefix(hostName, "k8s_") {
+			hostNames = append(hostNames, strings.TrimPrefix(hostName, "k8s_"))
+		}
+	}
+
+	var message string
+	if lun["HEALTHSTATUS"] != lunHealthStatusNormal {
+		message = fmt.Sprintf("lun %s is abnormal, health status: %v", name, lun["HEALTHSTATUS"])
+	} else {
+		var rss map[string]string
+		rssStr, _ := lun["HASRSSOBJECT"].(string)
+		json.Unmarshal([]byte(rssStr), &rss)
+		message, err = p.getPairsAbnormalMessage(ctx, lunID, resourceTypeLun,
+			rss["HyperMetro"] == "TRUE", rss["RemoteReplication"] == "TRUE")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return map[string]interface{}{
+		"PublishedNodes": hostNames,
+		"Abnormal":       message != "",
+		"Message":        message,
+	}, nil
+}
+
+// GetPairsStatus used for query the status of the hyperMetro and the replication pairs of the lun,
+// return nil if the lun does not exist
+func (p *SAN) GetPairsStatus(ctx context.Context, name string) ([]map[string]interface{}, error) {
+	lunName := p.cli.MakeLunName(name)
+	lun, err := p.cli.GetLunByName(ctx, lunName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get lun by name %s error: %v", lunName, err)
+		return nil, err
+	}
+	if lun == nil {
+		return nil, nil
+	}
+
+	var rss map[string]string
+	rssStr, _ := lun["HASRSSOBJECT"].(string)
+	json.Unmarshal([]byte(rssStr), &rss)
+	return p.getPairsStatus(ctx, lun["ID"].(string), resourceTypeLun,
+		rss["HyperMetro"] == "TRUE", rss["RemoteReplication"] == "TRUE")
+}
+
 func (p *SAN) Delete(ctx context.Context, name string) error {
 	lunName := p.cli.MakeLunName(name)
 	lun, err := p.cli.GetLunByName(ctx, lunName)
@@ -1289,8 +1387,10 @@ func (p *SAN) expandLocalLun(ctx context.Context,
 	return nil, nil
 }
 
+// CreateSnapshot used to create the snapshot of the lun, the description of the snapshot is the name of the
+// snapshot in kubernetes, which may be truncated in the snapshot name on the storage
 func (p *SAN) CreateSnapshot(ctx context.Context,
-	lunName, snapshotName string) (map[string]interface{}, error) {
+	lunName, snapshotName, description string) (map[string]interface{}, error) {
 	lun, err := p.cli.GetLunByName(ctx, lunName)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Get lun by name %s error: %v", lunName, err)
@@ -1326,8 +1426,9 @@ func (p *SAN) CreateSnapshot(ctx context.Context,
 	taskflow.AddTask("Active-Snapshot", p.activateSnapshot, nil)
 
 	params := map[string]interface{}{
-		"lunID":        lunId,
-		"snapshotName": snapshotName,
+		"lunID":               lunId,
+		"snapshotName":        snapshotName,
+		"snapshotDescription": description,
 	}
 
 	result, err := taskflow.Run(params)
@@ -1370,12 +1471,57 @@ func (p *SAN) DeleteSnapshot(ctx context.Context, snapshotName string) error {
 	return err
 }
 
+// QuerySnapshot used for get the snapshot of the lun, return nil if the snapshot does not exist
+func (p *SAN) QuerySnapshot(ctx context.Context, parentID, snapshotName string) (map[string]interface{}, error) {
+	snapshot, err := p.cli.GetLunSnapshotByName(ctx, snapshotName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get lun snapshot by name %s error: %v", snapshotName, err)
+		return nil, err
+	}
+
+	if snapshot == nil || snapshot["PARENTID"] != parentID {
+		log.AddContext(ctx).Infof("Lun snapshot %s of lun %s does not exist", snapshotName, parentID)
+		return nil, nil
+	}
+
+	snapshotSize, _ := strconv.ParseInt(snapshot["USERCAPACITY"].(string), 10, 64)
+	return p.getSnapshotListInfo(snapshot, snapshotSize), nil
+}
+
+// ListSnapshots used for get all snapshots of the lun
+func (p *SAN) ListSnapshots(ctx context.Context, lunName string) ([]map[string]interface{}, error) {
+	lun, err := p.cli.GetLunByName(ctx, lunName)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get lun by name %s error: %v", lunName, err)
+		return nil, err
+	}
+	if lun == nil {
+		log.AddContext(ctx).Infof("Lun %s to list snapshots does not exist", lunName)
+		return nil, nil
+	}
+
+	snapshots, err := p.cli.GetLunSnapshotsByParentId(ctx, lun["ID"].(string))
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get snapshots of lun %s error: %v", lunName, err)
+		return nil, err
+	}
+
+	var snapshotInfos []map[string]interface{}
+	for _, snapshot := range snapshots {
+		snapshotSize, _ := strconv.ParseInt(snapshot["USERCAPACITY"].(string), 10, 64)
+		snapshotInfos = append(snapshotInfos, p.getSnapshotListInfo(snapshot, snapshotSize))
+	}
+
+	return snapshotInfos, nil
+}
+
 func (p *SAN) createSnapshot(ctx context.Context,
 	params, taskResult map[string]interface{}) (map[string]interface{}, error) {
 	lunID := params["lunID"].(string)
 	snapshotName := params["snapshotName"].(string)
+	description, _ := params["snapshotDescription"].(string)
 
-	snapshot, err := p.cli.CreateLunSnapshot(ctx, snapshotName, lunID)
+	snapshot, err := p.cli.CreateLunSnapshot(ctx, snapshotName, lunID, description)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Create snapshot %s for lun %s error: %v", snapshotName, lunID, err)
 		return nil, err
@@ -1500,7 +1646,7 @@ func (p *SAN) getReplicationParams(ctx context.Context,
 		"remotePoolID":   remotePoolID,
 		"remoteCli":      p.replicaRemoteCli,
 		"remoteDeviceID": remoteDeviceID,
-		"resType":        11,
+		"resType":        resourceTypeLun,
 	}
 
 	return res, nil
@@ -1510,7 +1656,7 @@ func (p *SAN) deleteReplicationPair(ctx context.Context,
 	params, taskResult map[string]interface{}) (map[string]interface{}, error) {
 	lunID := params["lunID"].(string)
 
-	pairs, err := p.cli.GetReplicationPairByResID(ctx, lunID, 11)
+	pairs, err := p.cli.GetReplicationPairByResID(ctx, lunID, resourceTypeLun)
 	if err != nil {
 		return nil, err
 	}
@@ -1586,7 +1732,7 @@ func (p *SAN) splitReplication(ctx context.Context,
 	params, taskResult map[string]interface{}) (map[string]interface{}, error) {
 	lunID := params["lunID"].(string)
 
-	pairs, err := p.cli.GetReplicationPairByResID(ctx, lunID, 11)
+	pairs, err := p.cli.GetReplicationPairByResID(ctx, lunID, resourceTypeLun)
 	if err != nil {
 		return nil, err
 	}
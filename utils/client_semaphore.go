@@ -16,11 +16,29 @@
 
 package utils
 
+import (
+	"sync"
+	"sync/atomic"
+)
+
 type Semaphore struct {
 	permits int
 	channel chan int
+	waiting int32
+}
+
+// SemaphoreStats stores the usage of the request semaphore of one backend
+type SemaphoreStats struct {
+	Permits  int
+	InFlight int
+	Waiting  int
 }
 
+var (
+	backendSemaphores     = make(map[string]*Semaphore)
+	backendSemaphoresLock sync.Mutex
+)
+
 func NewSemaphore(permits int) *Semaphore {
 	return &Semaphore{
 		channel: make(chan int, permits),
@@ -28,10 +46,65 @@ func NewSemaphore(permits int) *Semaphore {
 	}
 }
 
+// NewBackendSemaphore used to get the request semaphore of the backend, the semaphore is shared by all clients
+// of the same backend and is recreated when the permits changed
+func NewBackendSemaphore(backendID string, permits int) *Semaphore {
+	if backendID == "" {
+		return NewSemaphore(permits)
+	}
+
+	backendSemaphoresLock.Lock()
+	defer backendSemaphoresLock.Unlock()
+
+	if semaphore, exist := backendSemaphores[backendID]; exist && semaphore.permits == permits {
+		return semaphore
+	}
+
+	semaphore := NewSemaphore(permits)
+	backendSemaphores[backendID] = semaphore
+	return semaphore
+}
+
+// RemoveBackendSemaphore used to remove the request semaphore of the backend
+func RemoveBackendSemaphore(backendID string) {
+	backendSemaphoresLock.Lock()
+	defer backendSemaphoresLock.Unlock()
+
+	delete(backendSemaphores, backendID)
+}
+
+// GetBackendSemaphoreStats used to get the in-flight and waiting requests of all backends
+func GetBackendSemaphoreStats() map[string]SemaphoreStats {
+	backendSemaphoresLock.Lock()
+	defer backendSemaphoresLock.Unlock()
+
+	stats := make(map[string]SemaphoreStats, len(backendSemaphores))
+	for backendID, semaphore := range backendSemaphores {
+		stats[backendID] = semaphore.Stats()
+	}
+	return stats
+}
+
 func (s *Semaphore) Acquire() {
+	if s.TryAcquire() {
+		return
+	}
+
+	atomic.AddInt32(&s.waiting, 1)
+	defer atomic.AddInt32(&s.waiting, -1)
 	s.channel <- 0
 }
 
+// TryAcquire used to acquire the permit without blocking, return false if all permits are in use
+func (s *Semaphore) TryAcquire() bool {
+	select {
+	case s.channel <- 0:
+		return true
+	default:
+		return false
+	}
+}
+
 func (s *Semaphore) Release() {
 	<-s.channel
 }
@@ -43,3 +116,12 @@ func (s *Semaphore) GetChannel() chan int {
 func (s *Semaphore) AvailablePermits() int {
 	return s.permits - len(s.channel)
 }
+
+// Stats used to get the permits, in-flight and waiting requests of the semaphore
+func (s *Semaphore) Stats() SemaphoreStats {
+	return SemaphoreStats{
+		Permits:  s.permits,
+		InFlight: len(s.channel),
+		Waiting:  int(atomic.LoadInt32(&s.waiting)),
+	}
+}
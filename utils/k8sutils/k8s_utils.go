@@ -27,6 +27,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
@@ -63,10 +64,15 @@ type Interface interface {
 	secretOps
 	ConfigmapOps
 	persistentVolumeClaimOps
+	persistentVolumeOps
+	volumeSnapshotOps
+	leaseOps
 }
 
 type KubeClient struct {
 	clientSet kubernetes.Interface
+	// dynamicClient used to access the resources without typed clients, such as the VolumeSnapshots
+	dynamicClient dynamic.Interface
 
 	// pvc resources cache
 	pvcIndexer            cache.Indexer
@@ -81,9 +87,10 @@ type KubeClient struct {
 // NewK8SUtils returns an object of Kubernetes utility interface
 func NewK8SUtils(kubeConfig string, volumeNamePrefix string, volumeLabels map[string]string) (Interface, error) {
 	var (
-		config    *rest.Config
-		clientset *kubernetes.Clientset
-		err       error
+		config        *rest.Config
+		clientset     *kubernetes.Clientset
+		dynamicClient dynamic.Interface
+		err           error
 	)
 
 	if kubeConfig != "" {
@@ -103,8 +110,14 @@ func NewK8SUtils(kubeConfig string, volumeNamePrefix string, volumeLabels map[st
 		return nil, err
 	}
 
+	dynamicClient, err = dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
 	helper := &KubeClient{
 		clientSet:             clientset,
+		dynamicClient:         dynamicClient,
 		pvcControllerStopChan: make(chan struct{}),
 		volumeNamePrefix:      volumeNamePrefix,
 		volumeLabels:          volumeLabels,
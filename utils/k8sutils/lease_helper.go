@@ -0,0 +1,85 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Package k8sutils provides Kubernetes utilities
+package k8sutils
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"huawei-csi-driver/utils/log"
+)
+
+// LeaseConf is the configuration of the lease used to elect the leader
+type LeaseConf struct {
+	Namespace     string
+	Name          string
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+}
+
+type leaseOps interface {
+	// RunAsLeader runs the function whenever the lease is held until the ctx is done, the ctx passed to the
+	// function is canceled once the lease is lost
+	RunAsLeader(ctx context.Context, conf LeaseConf, run func(ctx context.Context)) error
+}
+
+// RunAsLeader runs the function whenever the lease is held until the ctx is done
+func (k *KubeClient) RunAsLeader(ctx context.Context, conf LeaseConf, run func(ctx context.Context)) error {
+	id, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+
+	lock, err := resourcelock.New(resourcelock.LeasesResourceLock, conf.Namespace, conf.Name,
+		k.clientSet.CoreV1(), k.clientSet.CoordinationV1(), resourcelock.ResourceLockConfig{Identity: id})
+	if err != nil {
+		return err
+	}
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   conf.LeaseDuration,
+		RenewDeadline:   conf.RenewDeadline,
+		RetryPeriod:     conf.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            conf.Name,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: run,
+			OnStoppedLeading: func() {
+				log.AddContext(ctx).Warningf("Lease %s/%s is not held by %s", conf.Namespace, conf.Name, id)
+			},
+			OnNewLeader: func(identity string) {
+				log.AddContext(ctx).Infof("Lease %s/%s is held by %s", conf.Namespace, conf.Name, identity)
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// the elector returns once the lease is lost, so campaign again until the ctx is done
+	for ctx.Err() == nil {
+		elector.Run(ctx)
+	}
+	return nil
+}
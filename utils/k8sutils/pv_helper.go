@@ -0,0 +1,53 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Package k8sutils provides Kubernetes utilities
+package k8sutils
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type persistentVolumeOps interface {
+	// ListVolumesByDriver returns the PersistentVolumes provisioned by the CSI driver
+	ListVolumesByDriver(ctx context.Context, driverName string) ([]corev1.PersistentVolume, error)
+	// GetVolumeByName returns the PersistentVolume of the name
+	GetVolumeByName(ctx context.Context, name string) (*corev1.PersistentVolume, error)
+}
+
+// ListVolumesByDriver returns the PersistentVolumes provisioned by the CSI driver
+func (k *KubeClient) ListVolumesByDriver(ctx context.Context, driverName string) ([]corev1.PersistentVolume, error) {
+	pvList, err := k.clientSet.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var volumes []corev1.PersistentVolume
+	for _, pv := range pvList.Items {
+		if pv.Spec.CSI != nil && pv.Spec.CSI.Driver == driverName {
+			volumes = append(volumes, pv)
+		}
+	}
+	return volumes, nil
+}
+
+// GetVolumeByName returns the PersistentVolume of the name
+func (k *KubeClient) GetVolumeByName(ctx context.Context, name string) (*corev1.PersistentVolume, error) {
+	return k.getPVByName(ctx, name)
+}
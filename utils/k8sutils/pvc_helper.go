@@ -52,6 +52,15 @@ var (
 type persistentVolumeClaimOps interface {
 	// GetVolumeConfiguration returns PVC's volume info
 	GetVolumeConfiguration(ctx context.Context, pvName string) (map[string]string, error)
+	// GetVolumeClaim returns the PVC of the name in the namespace
+	GetVolumeClaim(ctx context.Context, namespace, name string) (*v1.PersistentVolumeClaim, error)
+	// UpdateVolumeClaim updates the PVC
+	UpdateVolumeClaim(ctx context.Context, pvc *v1.PersistentVolumeClaim) (*v1.PersistentVolumeClaim, error)
+	// WatchVolumeClaims watch the PVCs of all namespaces until the ctx is done
+	WatchVolumeClaims(ctx context.Context, handler cache.ResourceEventHandler) error
+	// ListVolumeClaimUsers returns the running pods which use the PVC and the nodes which its volume is
+	// attached to
+	ListVolumeClaimUsers(ctx context.Context, pvc *v1.PersistentVolumeClaim) ([]string, error)
 }
 
 func initPVCWatcher(ctx context.Context, helper *KubeClient) {
@@ -197,3 +206,75 @@ func (k *KubeClient) getCachedPVCByUID(uid string) (*v1.PersistentVolumeClaim, e
 		return pvc, nil
 	}
 }
+
+// GetVolumeClaim returns the PVC of the name in the namespace
+func (k *KubeClient) GetVolumeClaim(ctx context.Context, namespace, name string) (*v1.PersistentVolumeClaim, error) {
+	return k.clientSet.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metaV1.GetOptions{})
+}
+
+// UpdateVolumeClaim updates the PVC
+func (k *KubeClient) UpdateVolumeClaim(ctx context.Context,
+	pvc *v1.PersistentVolumeClaim) (*v1.PersistentVolumeClaim, error) {
+	return k.clientSet.CoreV1().PersistentVolumeClaims(pvc.Namespace).Update(ctx, pvc, metaV1.UpdateOptions{})
+}
+
+// WatchVolumeClaims watch the PVCs of all namespaces until the ctx is done, the PVCs are not filtered by the
+// volume labels like the PVC cache
+func (k *KubeClient) WatchVolumeClaims(ctx context.Context, handler cache.ResourceEventHandler) error {
+	source := &cache.ListWatch{
+		ListFunc: func(options metaV1.ListOptions) (runtime.Object, error) {
+			return k.clientSet.CoreV1().PersistentVolumeClaims(v1.NamespaceAll).List(ctx, options)
+		},
+		WatchFunc: func(options metaV1.ListOptions) (watch.Interface, error) {
+			return k.clientSet.CoreV1().PersistentVolumeClaims(v1.NamespaceAll).Watch(ctx, options)
+		},
+	}
+
+	informer := cache.NewSharedIndexInformer(source, &v1.PersistentVolumeClaim{}, cacheSyncPeriod, cache.Indexers{})
+	if _, err := informer.AddEventHandler(handler); err != nil {
+		return err
+	}
+
+	go informer.Run(ctx.Done())
+	return nil
+}
+
+// ListVolumeClaimUsers returns the pods which use the PVC and are not terminated, and the nodes which the
+// volume of the PVC is attached to
+func (k *KubeClient) ListVolumeClaimUsers(ctx context.Context, pvc *v1.PersistentVolumeClaim) ([]string, error) {
+	podList, err := k.clientSet.CoreV1().Pods(pvc.Namespace).List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var users []string
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == pvc.Name {
+				users = append(users, fmt.Sprintf("pod %s/%s", pod.Namespace, pod.Name))
+				break
+			}
+		}
+	}
+
+	if pvc.Spec.VolumeName == "" {
+		return users, nil
+	}
+
+	attachments, err := k.clientSet.StorageV1().VolumeAttachments().List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range attachments.Items {
+		pvName := attachment.Spec.Source.PersistentVolumeName
+		if pvName != nil && *pvName == pvc.Spec.VolumeName && attachment.Status.Attached {
+			users = append(users, fmt.Sprintf("node %s", attachment.Spec.NodeName))
+		}
+	}
+	return users, nil
+}
@@ -22,6 +22,9 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
 )
 
 type secretOps interface {
@@ -33,6 +36,8 @@ type secretOps interface {
 	UpdateSecret(ctx context.Context, secret *corev1.Secret) (*corev1.Secret, error)
 	// DeleteSecret delete secret
 	DeleteSecret(ctx context.Context, secretName, namespace string) error
+	// WatchSecrets watch the secrets in the namespace until the ctx is done
+	WatchSecrets(ctx context.Context, namespace string, handler cache.ResourceEventHandler) error
 }
 
 // GetSecret get secret
@@ -54,3 +59,23 @@ func (k *KubeClient) UpdateSecret(ctx context.Context, secret *corev1.Secret) (*
 func (k *KubeClient) DeleteSecret(ctx context.Context, secretName, namespace string) error {
 	return k.clientSet.CoreV1().Secrets(namespace).Delete(ctx, secretName, metav1.DeleteOptions{})
 }
+
+// WatchSecrets watch the secrets in the namespace until the ctx is done
+func (k *KubeClient) WatchSecrets(ctx context.Context, namespace string, handler cache.ResourceEventHandler) error {
+	source := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return k.clientSet.CoreV1().Secrets(namespace).List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return k.clientSet.CoreV1().Secrets(namespace).Watch(ctx, options)
+		},
+	}
+
+	informer := cache.NewSharedIndexInformer(source, &corev1.Secret{}, cacheSyncPeriod, cache.Indexers{})
+	if _, err := informer.AddEventHandler(handler); err != nil {
+		return err
+	}
+
+	go informer.Run(ctx.Done())
+	return nil
+}
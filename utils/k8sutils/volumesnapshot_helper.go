@@ -0,0 +1,73 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Package k8sutils provides Kubernetes utilities
+package k8sutils
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	volumeSnapshotResource = schema.GroupVersionResource{
+		Group:    "snapshot.storage.k8s.io",
+		Version:  "v1",
+		Resource: "volumesnapshots",
+	}
+	volumeSnapshotContentResource = schema.GroupVersionResource{
+		Group:    "snapshot.storage.k8s.io",
+		Version:  "v1",
+		Resource: "volumesnapshotcontents",
+	}
+)
+
+type volumeSnapshotOps interface {
+	// GetVolumeSnapshotHandle returns the snapshot handle of the ready VolumeSnapshot, which is the snapshot id
+	// returned by the CSI driver
+	GetVolumeSnapshotHandle(ctx context.Context, namespace, name string) (string, error)
+}
+
+// GetVolumeSnapshotHandle returns the snapshot handle of the ready VolumeSnapshot
+func (k *KubeClient) GetVolumeSnapshotHandle(ctx context.Context, namespace, name string) (string, error) {
+	snapshot, err := k.dynamicClient.Resource(volumeSnapshotResource).Namespace(namespace).Get(ctx, name,
+		metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	ready, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+	contentName, _, _ := unstructured.NestedString(snapshot.Object, "status", "boundVolumeSnapshotContentName")
+	if !ready || contentName == "" {
+		return "", fmt.Errorf("VolumeSnapshot %s/%s is not ready to use", namespace, name)
+	}
+
+	content, err := k.dynamicClient.Resource(volumeSnapshotContentResource).Get(ctx, contentName,
+		metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	handle, _, _ := unstructured.NestedString(content.Object, "status", "snapshotHandle")
+	if handle == "" {
+		return "", fmt.Errorf("VolumeSnapshotContent %s has no snapshot handle", contentName)
+	}
+	return handle, nil
+}
@@ -0,0 +1,240 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Package metrics provides the prometheus metrics of the csi controller and node plugins
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"huawei-csi-driver/utils"
+	"huawei-csi-driver/utils/log"
+)
+
+const (
+	namespace = "huawei_csi"
+
+	resultSuccess = "success"
+	resultFailed  = "failed"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	// backendPools records the pools of each backend, used to remove the pool metrics of the removed backend
+	backendPools     = make(map[string]map[string]bool)
+	backendPoolsLock sync.Mutex
+
+	grpcRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "Duration of the gRPC requests handled by the plugin, partitioned by method and gRPC code.",
+		Buckets:   []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60, 120, 300},
+	}, []string{"method", "code"})
+
+	storageRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "storage_request_duration_seconds",
+		Help:      "Duration of the REST requests sent to the storage, partitioned by backend, method and result.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10, 30, 60},
+	}, []string{"backend", "method", "result"})
+
+	storageReLoginTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "storage_relogin_total",
+		Help:      "Number of the re-logins to the storage, partitioned by backend and result.",
+	}, []string{"backend", "result"})
+
+	poolCapacityBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "pool_capacity_bytes",
+		Help:      "Capacity of the storage pools, partitioned by backend, pool and type of total or free.",
+	}, []string{"backend", "pool", "type"})
+
+	backendAvailable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "backend_available",
+		Help:      "Whether the backend is available, 1 means available and 0 means unavailable.",
+	}, []string{"backend"})
+
+	connectorDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "connector_duration_seconds",
+		Help:      "Duration of the connector operations on the node, partitioned by protocol, operation and result.",
+		Buckets:   []float64{0.5, 1, 2, 5, 10, 20, 30, 60, 120, 300},
+	}, []string{"protocol", "operation", "result"})
+
+	storageRequestsDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "storage_requests"),
+		"Number of the in-flight and waiting REST requests to the storage, partitioned by backend and state.",
+		[]string{"backend", "state"}, nil)
+	storageRequestLimitDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "storage_request_limit"),
+		"Max concurrent REST requests to the storage of the backend, configured by maxClientThreads.",
+		[]string{"backend"}, nil)
+	backendURLUpDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "backend_url_up"),
+		"Whether the management url of the backend is reachable in the last health check, 1 means reachable.",
+		[]string{"backend", "url"}, nil)
+	backendURLLatencyDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "backend_url_probe_seconds"),
+		"Latency of the last health check of the management url of the backend.",
+		[]string{"backend", "url"}, nil)
+)
+
+// semaphoreCollector used to collect the in-flight and waiting requests of the backends when scraping
+type semaphoreCollector struct{}
+
+// Describe used to describe the metrics of the backend semaphores
+func (c semaphoreCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- storageRequestsDesc
+	ch <- storageRequestLimitDesc
+}
+
+// Collect used to collect the metrics of the backend semaphores
+func (c semaphoreCollector) Collect(ch chan<- prometheus.Metric) {
+	for backend, stats := range utils.GetBackendSemaphoreStats() {
+		ch <- prometheus.MustNewConstMetric(storageRequestsDesc, prometheus.GaugeValue,
+			float64(stats.InFlight), backend, "in_flight")
+		ch <- prometheus.MustNewConstMetric(storageRequestsDesc, prometheus.GaugeValue,
+			float64(stats.Waiting), backend, "waiting")
+		ch <- prometheus.MustNewConstMetric(storageRequestLimitDesc, prometheus.GaugeValue,
+			float64(stats.Permits), backend)
+	}
+}
+
+// urlHealthCollector used to collect the result of the last health check of the backends when scraping
+type urlHealthCollector struct{}
+
+// Describe used to describe the metrics of the backend health check
+func (c urlHealthCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- backendURLUpDesc
+	ch <- backendURLLatencyDesc
+}
+
+// Collect used to collect the metrics of the backend health check
+func (c urlHealthCollector) Collect(ch chan<- prometheus.Metric) {
+	for backend, healths := range utils.GetBackendURLHealths() {
+		for _, health := range healths {
+			var up float64
+			if health.Healthy {
+				up = 1
+			}
+			ch <- prometheus.MustNewConstMetric(backendURLUpDesc, prometheus.GaugeValue, up, backend, health.URL)
+			ch <- prometheus.MustNewConstMetric(backendURLLatencyDesc, prometheus.GaugeValue,
+				health.Latency.Seconds(), backend, health.URL)
+		}
+	}
+}
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		grpcRequestDuration,
+		storageRequestDuration,
+		storageReLoginTotal,
+		poolCapacityBytes,
+		backendAvailable,
+		connectorDuration,
+		semaphoreCollector{},
+		urlHealthCollector{},
+	)
+}
+
+func getResult(err error) string {
+	if err != nil {
+		return resultFailed
+	}
+	return resultSuccess
+}
+
+// Serve used to serve the metrics on the address until the listener fails
+func Serve(address, path string) {
+	mux := http.NewServeMux()
+	mux.Handle(path, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	log.Infof("Starting metrics server, listening on %s%s", address, path)
+	if err := http.ListenAndServe(address, mux); err != nil {
+		log.Errorf("Metrics server listening on %s stopped, error: %v", address, err)
+	}
+}
+
+// UnaryServerInterceptor used to record the duration and the gRPC code of the requests
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	grpcRequestDuration.WithLabelValues(info.FullMethod, status.Code(err).String()).
+		Observe(time.Since(start).Seconds())
+	return resp, err
+}
+
+// ObserveStorageRequest used to record the duration and the result of the REST request to the storage
+func ObserveStorageRequest(backend, method string, start time.Time, err error) {
+	storageRequestDuration.WithLabelValues(backend, method, getResult(err)).Observe(time.Since(start).Seconds())
+}
+
+// IncStorageReLogin used to count the re-login to the storage
+func IncStorageReLogin(backend string, err error) {
+	storageReLoginTotal.WithLabelValues(backend, getResult(err)).Inc()
+}
+
+// SetPoolCapacity used to record the total and free capacity in bytes of the storage pool
+func SetPoolCapacity(backend, pool string, total, free int64) {
+	backendPoolsLock.Lock()
+	if _, exist := backendPools[backend]; !exist {
+		backendPools[backend] = make(map[string]bool)
+	}
+	backendPools[backend][pool] = true
+	backendPoolsLock.Unlock()
+
+	poolCapacityBytes.WithLabelValues(backend, pool, "total").Set(float64(total))
+	poolCapacityBytes.WithLabelValues(backend, pool, "free").Set(float64(free))
+}
+
+// SetBackendAvailable used to record whether the backend is available
+func SetBackendAvailable(backend string, available bool) {
+	var value float64
+	if available {
+		value = 1
+	}
+	backendAvailable.WithLabelValues(backend).Set(value)
+}
+
+// DeleteBackend used to remove the status metrics of the backend after it is removed, the request metrics are
+// kept since they are cumulative
+func DeleteBackend(backend string) {
+	backendAvailable.DeleteLabelValues(backend)
+
+	backendPoolsLock.Lock()
+	defer backendPoolsLock.Unlock()
+	for pool := range backendPools[backend] {
+		poolCapacityBytes.DeleteLabelValues(backend, pool, "total")
+		poolCapacityBytes.DeleteLabelValues(backend, pool, "free")
+	}
+	delete(backendPools, backend)
+}
+
+// ObserveConnectorOperation used to record the duration and the result of the connector operation
+func ObserveConnectorOperation(protocol, operation string, start time.Time, err error) {
+	connectorDuration.WithLabelValues(protocol, operation, getResult(err)).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,51 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetPoolCapacityAndDeleteBackend(t *testing.T) {
+	SetBackendAvailable("ns/backend", true)
+	SetPoolCapacity("ns/backend", "pool", 4096, 1024)
+	assert.Equal(t, float64(1), testutil.ToFloat64(backendAvailable.WithLabelValues("ns/backend")))
+	assert.Equal(t, float64(4096), testutil.ToFloat64(poolCapacityBytes.WithLabelValues("ns/backend", "pool", "total")))
+	assert.Equal(t, float64(1024), testutil.ToFloat64(poolCapacityBytes.WithLabelValues("ns/backend", "pool", "free")))
+
+	DeleteBackend("ns/backend")
+	assert.Equal(t, 0, testutil.CollectAndCount(backendAvailable))
+	assert.Equal(t, 0, testutil.CollectAndCount(poolCapacityBytes))
+}
+
+func TestIncStorageReLogin(t *testing.T) {
+	IncStorageReLogin("ns/backend", nil)
+	IncStorageReLogin("ns/backend", errors.New("login failed"))
+	IncStorageReLogin("ns/backend", errors.New("login failed"))
+	assert.Equal(t, float64(1), testutil.ToFloat64(storageReLoginTotal.WithLabelValues("ns/backend", resultSuccess)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(storageReLoginTotal.WithLabelValues("ns/backend", resultFailed)))
+}
+
+func TestObserveConnectorOperation(t *testing.T) {
+	ObserveConnectorOperation("iSCSI", "connect", time.Now(), nil)
+	assert.Equal(t, 1, testutil.CollectAndCount(connectorDuration))
+}
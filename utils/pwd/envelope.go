@@ -0,0 +1,100 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package pwd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math"
+	"strings"
+)
+
+const (
+	dataKeyLength        = 32
+	wrappedKeyLengthSize = 2
+)
+
+// KeyProvider wraps and unwraps the data keys of the envelope encryption, such as a KMS
+type KeyProvider interface {
+	// WrapKey used to encrypt the data key
+	WrapKey(ctx context.Context, key []byte) ([]byte, error)
+	// UnwrapKey used to decrypt the data key wrapped by WrapKey
+	UnwrapKey(ctx context.Context, wrappedKey []byte) ([]byte, error)
+}
+
+// EnvelopeEncrypt used to encrypt the password by AES-GCM with a random data key, the data key is wrapped by
+// the key provider and stored with the encrypted password
+func EnvelopeEncrypt(ctx context.Context, password string, provider KeyProvider) (string, error) {
+	dataKey := make([]byte, dataKeyLength)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+
+	wrappedKey, err := provider.WrapKey(ctx, dataKey)
+	if err != nil {
+		return "", err
+	}
+	if len(wrappedKey) > math.MaxUint16 {
+		return "", errors.New("the wrapped data key is too long")
+	}
+
+	sealed, err := sealGCM([]byte(password), dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	envelope := make([]byte, wrappedKeyLengthSize, wrappedKeyLengthSize+len(wrappedKey)+len(sealed))
+	binary.BigEndian.PutUint16(envelope, uint16(len(wrappedKey)))
+	envelope = append(envelope, wrappedKey...)
+	envelope = append(envelope, sealed...)
+	return versionEnvelope + base64.StdEncoding.EncodeToString(envelope), nil
+}
+
+// EnvelopeDecrypt used to decrypt the value encrypted by EnvelopeEncrypt
+func EnvelopeDecrypt(ctx context.Context, code string, provider KeyProvider) (string, error) {
+	if !IsEnvelope(code) {
+		return "", errors.New("the value is not encrypted by the key provider")
+	}
+
+	envelope, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(code, versionEnvelope))
+	if err != nil {
+		return "", err
+	}
+	if len(envelope) < wrappedKeyLengthSize {
+		return "", errors.New("the encrypted value is too short")
+	}
+
+	wrappedKeyLength := int(binary.BigEndian.Uint16(envelope))
+	envelope = envelope[wrappedKeyLengthSize:]
+	if len(envelope) < wrappedKeyLength {
+		return "", errors.New("the encrypted value is too short")
+	}
+
+	dataKey, err := provider.UnwrapKey(ctx, envelope[:wrappedKeyLength])
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := openGCM(envelope[wrappedKeyLength:], dataKey)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,114 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package pwd
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// The KMS plugin serves the KeyManagement service with the WrapKey and UnwrapKey methods, both of them take
+// and return a google.protobuf.BytesValue, so that the plugin can be implemented without the generated code.
+const (
+	kmsServiceName  = "xuanwu.kms.v1.KeyManagement"
+	wrapKeyMethod   = "WrapKey"
+	unwrapKeyMethod = "UnwrapKey"
+)
+
+// GRPCKeyProvider is the KeyProvider which wraps the data keys by the KMS plugin over gRPC
+type GRPCKeyProvider struct {
+	conn *grpc.ClientConn
+}
+
+// NewGRPCKeyProvider used to connect to the KMS plugin listening on the endpoint, such as
+// unix:///var/run/kms/kms.sock
+func NewGRPCKeyProvider(ctx context.Context, endpoint string, opts ...grpc.DialOption) (*GRPCKeyProvider, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.DialContext(ctx, endpoint, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GRPCKeyProvider{conn: conn}, nil
+}
+
+// WrapKey used to encrypt the data key by the KMS plugin
+func (p *GRPCKeyProvider) WrapKey(ctx context.Context, key []byte) ([]byte, error) {
+	return p.invoke(ctx, wrapKeyMethod, key)
+}
+
+// UnwrapKey used to decrypt the data key by the KMS plugin
+func (p *GRPCKeyProvider) UnwrapKey(ctx context.Context, wrappedKey []byte) ([]byte, error) {
+	return p.invoke(ctx, unwrapKeyMethod, wrappedKey)
+}
+
+// Close used to close the connection to the KMS plugin
+func (p *GRPCKeyProvider) Close() error {
+	return p.conn.Close()
+}
+
+func (p *GRPCKeyProvider) invoke(ctx context.Context, method string, value []byte) ([]byte, error) {
+	resp := &wrappers.BytesValue{}
+	err := p.conn.Invoke(ctx, "/"+kmsServiceName+"/"+method, &wrappers.BytesValue{Value: value}, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetValue(), nil
+}
+
+// RegisterKeyProviderServer used to serve the KeyProvider as a KMS plugin by the gRPC server
+func RegisterKeyProviderServer(server *grpc.Server, provider KeyProvider) {
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: kmsServiceName,
+		HandlerType: (*KeyProvider)(nil),
+		Methods: []grpc.MethodDesc{
+			{MethodName: wrapKeyMethod, Handler: newKeyHandler(wrapKeyMethod, KeyProvider.WrapKey)},
+			{MethodName: unwrapKeyMethod, Handler: newKeyHandler(unwrapKeyMethod, KeyProvider.UnwrapKey)},
+		},
+	}, provider)
+}
+
+type keyFunc func(provider KeyProvider, ctx context.Context, key []byte) ([]byte, error)
+
+func newKeyHandler(method string, handle keyFunc) func(interface{}, context.Context, func(interface{}) error,
+	grpc.UnaryServerInterceptor) (interface{}, error) {
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error,
+		interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		req := &wrappers.BytesValue{}
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+
+		call := func(ctx context.Context, req interface{}) (interface{}, error) {
+			value, err := handle(srv.(KeyProvider), ctx, req.(*wrappers.BytesValue).GetValue())
+			if err != nil {
+				return nil, err
+			}
+			return &wrappers.BytesValue{Value: value}, nil
+		}
+
+		if interceptor == nil {
+			return call(ctx, req)
+		}
+		info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + kmsServiceName + "/" + method}
+		return interceptor(ctx, req, info, call)
+	}
+}
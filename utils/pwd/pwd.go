@@ -19,51 +19,174 @@ package pwd
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	lowerLetters   = "abcdefghijklmnopqrstuvwxyz"
+	upperLetters   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digits         = "0123456789"
+	specialLetters = "~!@#%^*_-+="
+
+	// versionGCM is the prefix of the value encrypted by AES-GCM with the key
+	versionGCM = "v2:"
+	// versionEnvelope is the prefix of the value encrypted by AES-GCM with a data key wrapped by the key provider
+	versionEnvelope = "v3:"
 )
 
 var (
+	// commonIV is only used to decrypt the legacy value encrypted by AES-CFB
 	commonIV = []byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f}
+
+	passwordCharsets = []string{lowerLetters, upperLetters, digits, specialLetters}
 )
 
-//加密
+// Encrypt used to encrypt the password by AES-GCM with a random nonce, the key must be 16, 24 or 32 bytes.
+// The result is prefixed with the version of the format, so that it can be told from the legacy value.
 func Encrypt(password, keyText string) (string, error) {
-	plaintext := []byte(password)
-	bytesText := []byte(keyText)
-	// 创建加密算法aes
-	c, err := aes.NewCipher(bytesText)
+	sealed, err := sealGCM([]byte(password), []byte(keyText))
 	if err != nil {
 		return "", err
 	}
 
-	ciphertext := make([]byte, len(plaintext))
+	return versionGCM + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt used to decrypt the value encrypted by Encrypt, the legacy value encrypted by AES-CFB is also
+// supported for migration
+func Decrypt(code, keyText string) (string, error) {
+	switch {
+	case strings.HasPrefix(code, versionGCM):
+		sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(code, versionGCM))
+		if err != nil {
+			return "", err
+		}
+
+		plaintext, err := openGCM(sealed, []byte(keyText))
+		if err != nil {
+			return "", err
+		}
+		return string(plaintext), nil
+	case strings.HasPrefix(code, versionEnvelope):
+		return "", errors.New("the value is encrypted by the key provider, decrypt it by EnvelopeDecrypt")
+	default:
+		return decryptLegacy(code, keyText)
+	}
+}
 
-	//加密字符串
-	cfb := cipher.NewCFBEncrypter(c, commonIV)
-	cfb.XORKeyStream(ciphertext, plaintext)
+// IsLegacy returns whether the value is encrypted by the legacy AES-CFB format, which should be re-encrypted
+func IsLegacy(code string) bool {
+	return !strings.HasPrefix(code, versionGCM) && !IsEnvelope(code)
+}
 
-	return hex.EncodeToString(ciphertext), nil
+// IsEnvelope returns whether the value is encrypted by EnvelopeEncrypt, which should be decrypted by EnvelopeDecrypt
+func IsEnvelope(code string) bool {
+	return strings.HasPrefix(code, versionEnvelope)
 }
 
-//解密
-func Decrypt(code, keyText string) (string, error) {
+func sealGCM(plaintext, key []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func openGCM(sealed, key []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("the encrypted value is too short")
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func decryptLegacy(code, keyText string) (string, error) {
 	ciphertext, err := hex.DecodeString(code)
 	if err != nil {
 		return "", err
 	}
 
-	bytesText := []byte(keyText)
-	// 创建加密算法aes
-	c, err := aes.NewCipher(bytesText)
+	c, err := aes.NewCipher([]byte(keyText))
 	if err != nil {
 		return "", err
 	}
 
 	plaintextCopy := make([]byte, len(ciphertext))
-
-	// 解密字符串
 	cfbdec := cipher.NewCFBDecrypter(c, commonIV)
 	cfbdec.XORKeyStream(plaintextCopy, ciphertext)
 
 	return string(plaintextCopy), nil
 }
+
+// GeneratePassword used to generate a random password which contains lowercase letters, uppercase letters, digits
+// and special characters, so that it meets the password complexity policy of the storage
+func GeneratePassword(length int) (string, error) {
+	if length < len(passwordCharsets) {
+		return "", fmt.Errorf("the length of the password must be at least %d", len(passwordCharsets))
+	}
+
+	var allLetters string
+	password := make([]byte, 0, length)
+	for _, charset := range passwordCharsets {
+		allLetters += charset
+		letter, err := randomLetter(charset)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, letter)
+	}
+
+	for len(password) < length {
+		letter, err := randomLetter(allLetters)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, letter)
+	}
+
+	// shuffle the password so that the letters of each charset are not always at the beginning
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+
+	return string(password), nil
+}
+
+func randomLetter(charset string) (byte, error) {
+	index, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+	if err != nil {
+		return 0, err
+	}
+	return charset[index.Int64()], nil
+}
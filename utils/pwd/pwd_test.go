@@ -0,0 +1,121 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package pwd
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"net"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testKey = "0123456789abcdef0123456789abcdef"
+
+// fakeKMS wraps the data keys by AES-GCM with a local key
+type fakeKMS struct {
+	key []byte
+}
+
+func (f *fakeKMS) WrapKey(_ context.Context, key []byte) ([]byte, error) {
+	return sealGCM(key, f.key)
+}
+
+func (f *fakeKMS) UnwrapKey(_ context.Context, wrappedKey []byte) ([]byte, error) {
+	return openGCM(wrappedKey, f.key)
+}
+
+func TestEncryptAndDecrypt(t *testing.T) {
+	first, err := Encrypt("Admin@123", testKey)
+	if err != nil {
+		t.Fatalf("Encrypt failed, error: %v", err)
+	}
+	second, _ := Encrypt("Admin@123", testKey)
+	if first == second || !strings.HasPrefix(first, versionGCM) || IsLegacy(first) {
+		t.Errorf("Encrypt should use random nonces with the version prefix, got %s and %s", first, second)
+	}
+
+	if password, err := Decrypt(first, testKey); err != nil || password != "Admin@123" {
+		t.Errorf("Decrypt got %s, error: %v", password, err)
+	}
+
+	tampered := first[:len(first)-2] + "AA"
+	if _, err = Decrypt(tampered, testKey); err == nil {
+		t.Errorf("Decrypt of the tampered value should fail")
+	}
+}
+
+func TestDecryptLegacy(t *testing.T) {
+	block, _ := aes.NewCipher([]byte(testKey))
+	ciphertext := make([]byte, len("Admin@123"))
+	cipher.NewCFBEncrypter(block, commonIV).XORKeyStream(ciphertext, []byte("Admin@123"))
+	legacy := hex.EncodeToString(ciphertext)
+
+	if !IsLegacy(legacy) {
+		t.Errorf("IsLegacy of %s should be true", legacy)
+	}
+	if password, err := Decrypt(legacy, testKey); err != nil || password != "Admin@123" {
+		t.Errorf("Decrypt of the legacy value got %s, error: %v", password, err)
+	}
+}
+
+func TestEnvelopeEncryptWithGRPCKeyProvider(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	RegisterKeyProviderServer(server, &fakeKMS{key: []byte(testKey)})
+	go server.Serve(listener)
+	defer server.Stop()
+
+	ctx := context.Background()
+	provider, err := NewGRPCKeyProvider(ctx, "bufnet", grpc.WithContextDialer(
+		func(context.Context, string) (net.Conn, error) { return listener.Dial() }))
+	if err != nil {
+		t.Fatalf("NewGRPCKeyProvider failed, error: %v", err)
+	}
+	defer provider.Close()
+
+	code, err := EnvelopeEncrypt(ctx, "Admin@123", provider)
+	if err != nil || !strings.HasPrefix(code, versionEnvelope) {
+		t.Fatalf("EnvelopeEncrypt got %s, error: %v", code, err)
+	}
+
+	if password, err := EnvelopeDecrypt(ctx, code, provider); err != nil || password != "Admin@123" {
+		t.Errorf("EnvelopeDecrypt got %s, error: %v", password, err)
+	}
+
+	if _, err = EnvelopeDecrypt(ctx, code, &fakeKMS{key: []byte("fedcba9876543210")}); err == nil {
+		t.Errorf("EnvelopeDecrypt with another KMS key should fail")
+	}
+}
+
+func TestGeneratePassword(t *testing.T) {
+	password, err := GeneratePassword(16)
+	if err != nil || len(password) != 16 {
+		t.Fatalf("GeneratePassword got %s, error: %v", password, err)
+	}
+
+	for _, charset := range passwordCharsets {
+		if !strings.ContainsAny(password, charset) {
+			t.Errorf("GeneratePassword got %s, which does not contain any of %s", password, charset)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2023-2023. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package utils
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const urlProbeTimeout = 5 * time.Second
+
+var (
+	backendURLHealths     = make(map[string][]URLHealth)
+	backendURLHealthsLock sync.RWMutex
+)
+
+// HTTPClient is the http client used to probe the management urls of the storage
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// URLHealth records the result of the last probe of one management url of the storage
+type URLHealth struct {
+	URL       string
+	Healthy   bool
+	Latency   time.Duration
+	LastError string
+	CheckTime time.Time
+}
+
+// ProbeURL used to check whether the management url of the storage is reachable. Any http response means the url
+// is reachable, since the probe request is sent without authentication.
+func ProbeURL(ctx context.Context, client HTTPClient, url string) URLHealth {
+	health := URLHealth{URL: url, CheckTime: time.Now()}
+
+	probeCtx, cancel := context.WithTimeout(ctx, urlProbeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(probeCtx, http.MethodGet, url, nil)
+	if err != nil {
+		health.LastError = err.Error()
+		return health
+	}
+
+	resp, err := client.Do(req)
+	health.Latency = time.Since(health.CheckTime)
+	if err != nil {
+		health.LastError = err.Error()
+		return health
+	}
+	resp.Body.Close()
+
+	health.Healthy = true
+	return health
+}
+
+// IsAnyURLHealthy returns whether at least one management url of the storage is healthy
+func IsAnyURLHealthy(healths []URLHealth) bool {
+	for _, health := range healths {
+		if health.Healthy {
+			return true
+		}
+	}
+	return false
+}
+
+// SetBackendURLHealth used to record the result of the last health check of the backend
+func SetBackendURLHealth(backendID string, healths []URLHealth) {
+	backendURLHealthsLock.Lock()
+	defer backendURLHealthsLock.Unlock()
+	backendURLHealths[backendID] = healths
+}
+
+// RemoveBackendURLHealth used to forget the health of the removed backend
+func RemoveBackendURLHealth(backendID string) {
+	backendURLHealthsLock.Lock()
+	defer backendURLHealthsLock.Unlock()
+	delete(backendURLHealths, backendID)
+}
+
+// GetBackendURLHealths used to get the result of the last health check of all backends
+func GetBackendURLHealths() map[string][]URLHealth {
+	backendURLHealthsLock.RLock()
+	defer backendURLHealthsLock.RUnlock()
+
+	healths := make(map[string][]URLHealth, len(backendURLHealths))
+	for backendID, health := range backendURLHealths {
+		healths[backendID] = health
+	}
+	return healths
+}
@@ -267,6 +267,12 @@ func GetFSSnapshotName(name string) string {
 	return strings.Replace(name, "-", "_", -1)
 }
 
+// GetK8sFSSnapshotName used to convert the name of the filesystem snapshot back to the name in kubernetes, which
+// is the reverse of GetFSSnapshotName
+func GetK8sFSSnapshotName(name string) string {
+	return strings.Replace(name, "_", "-", -1)
+}
+
 func GetSharePath(name string) string {
 	return "/" + strings.Replace(name, "-", "_", -1) + "/"
 }
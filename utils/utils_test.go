@@ -19,6 +19,8 @@ package utils
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"reflect"
 	"testing"
@@ -237,6 +239,41 @@ func TestIsContainFileType(t *testing.T) {
 	}
 }
 
+func TestNewBackendSemaphore(t *testing.T) {
+	defer RemoveBackendSemaphore("ns/backend-a")
+	defer RemoveBackendSemaphore("ns/backend-b")
+
+	semaphoreA := NewBackendSemaphore("ns/backend-a", 20)
+	semaphoreB := NewBackendSemaphore("ns/backend-b", 30)
+	assert.Same(t, semaphoreA, NewBackendSemaphore("ns/backend-a", 20))
+	assert.NotSame(t, semaphoreA, semaphoreB)
+
+	semaphoreA.Acquire()
+	stats := GetBackendSemaphoreStats()
+	assert.Equal(t, SemaphoreStats{Permits: 20, InFlight: 1}, stats["ns/backend-a"])
+	assert.Equal(t, SemaphoreStats{Permits: 30}, stats["ns/backend-b"])
+	semaphoreA.Release()
+
+	resized := NewBackendSemaphore("ns/backend-a", 40)
+	assert.Equal(t, 40, resized.Stats().Permits)
+	assert.Equal(t, 30, NewBackendSemaphore("ns/backend-b", 30).Stats().Permits)
+}
+
+func TestProbeURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	healthy := ProbeURL(context.TODO(), server.Client(), server.URL)
+	assert.True(t, healthy.Healthy, healthy.LastError)
+
+	server.Close()
+	unhealthy := ProbeURL(context.TODO(), server.Client(), server.URL)
+	assert.False(t, unhealthy.Healthy)
+	assert.NotEmpty(t, unhealthy.LastError)
+	assert.True(t, IsAnyURLHealthy([]URLHealth{unhealthy, healthy}))
+	assert.False(t, IsAnyURLHealthy([]URLHealth{unhealthy}))
+}
+
 func TestMain(m *testing.M) {
 	log.MockInitLogging(logName)
 	defer log.MockStopLogging(logName)
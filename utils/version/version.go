@@ -26,6 +26,9 @@ import (
 )
 
 const (
+	// DefaultVersionFile is the file in the driver container which the version of the driver is written to
+	DefaultVersionFile = "/csi/version"
+
 	versionFilePermission = 0644
 	newline               = '\n'
 )